@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mybeerlog/data"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/utils"
+	"os"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// バッチジョブの名前（BATCH_JOB に指定する）
+const (
+	// batchJobUserRecap 年間の振り返りを集計するバッチジョブ
+	batchJobUserRecap = "user_recap"
+	// batchJobSeedBeerStyles 同梱のビアスタイルの初期データを投入するバッチジョブ
+	batchJobSeedBeerStyles = "seed_beer_styles"
+	// batchJobSeedBadges 同梱のバッジ定義の初期データを投入するバッチジョブ
+	batchJobSeedBadges = "seed_badges"
+	// batchJobPurgeIdempotencyKeys 期限切れの冪等キーを削除するバッチジョブ
+	batchJobPurgeIdempotencyKeys = "purge_idempotency_keys"
+)
+
+// RecapJobInput 年間の振り返りの集計ジョブの入力（EventBridge のスケジュールの入力、またはローカル実行時の BATCH_JOB_INPUT）
+type RecapJobInput struct {
+	Year int `json:"year"` // 集計する年（省略時は前年）
+}
+
+// RecapJobOutput 年間の振り返りの集計ジョブの結果
+type RecapJobOutput struct {
+	Year      int `json:"year"`
+	Generated int `json:"generated"`
+}
+
+// RecapJobHandler 年間の振り返りを集計するバッチジョブの Lambda ハンドラー
+// EventBridge のスケジュール（年明け）から起動し、前年に訪問のある全ユーザーの振り返りを user_recap に保存する
+func RecapJobHandler(ctx context.Context, input RecapJobInput) (*RecapJobOutput, error) {
+	loc, err := time.LoadLocation(beego.AppConfig.DefaultString("visit.default_time_zone", "Asia/Tokyo"))
+	if err != nil {
+		return nil, err
+	}
+
+	year := input.Year
+	if year == 0 {
+		year = time.Now().In(loc).Year() - 1
+	}
+	batchSize := beego.AppConfig.DefaultInt("recap.batch_size", 100)
+
+	recapUsecase := usecase.NewRecapUsecase(repository.NewUserRecapRepository(), repository.NewVisitStatsRepository())
+	generated, err := recapUsecase.GenerateRecaps(year, loc, batchSize)
+	fields := map[string]interface{}{
+		"year":      year,
+		"generated": generated,
+	}
+	if err != nil {
+		utils.LogError(ctx, err, "Recap generation failed", fields)
+		return nil, err
+	}
+
+	utils.LogInfo(ctx, "Recaps generated", fields)
+	return &RecapJobOutput{Year: year, Generated: generated}, nil
+}
+
+// SeedStylesJobOutput ビアスタイルの初期データ投入ジョブの結果
+type SeedStylesJobOutput struct {
+	Seeded int `json:"seeded"`
+}
+
+// SeedStylesJobHandler 同梱のビアスタイルの初期データ（data/beer_styles.json）を投入するバッチジョブの Lambda ハンドラー
+// デプロイ後に1回起動する。変更のないスタイルは書き込まないため、再実行しても結果は変わらない
+func SeedStylesJobHandler(ctx context.Context) (*SeedStylesJobOutput, error) {
+	styleUsecase := usecase.NewBeerStyleUsecase(repository.NewBeerStyleRepository(), repository.NewBreweryRepository())
+	seeded, err := styleUsecase.SeedStyles(data.BeerStylesJSON)
+	if err != nil {
+		utils.LogError(ctx, err, "Beer style seeding failed")
+		return nil, err
+	}
+
+	utils.LogInfo(ctx, "Beer styles seeded", map[string]interface{}{
+		"seeded": seeded,
+	})
+	return &SeedStylesJobOutput{Seeded: seeded}, nil
+}
+
+// SeedBadgesJobOutput バッジ定義の初期データ投入ジョブの結果
+type SeedBadgesJobOutput struct {
+	Seeded int `json:"seeded"`
+}
+
+// SeedBadgesJobHandler 同梱のバッジ定義の初期データ（data/badges.json）を投入するバッチジョブの Lambda ハンドラー
+// デプロイ後に1回起動する。変更のないバッジは書き込まないため、再実行しても結果は変わらない
+func SeedBadgesJobHandler(ctx context.Context) (*SeedBadgesJobOutput, error) {
+	badgeUsecase := usecase.NewBadgeUsecase(repository.NewBadgeRepository())
+	seeded, err := badgeUsecase.SeedBadges(data.BadgesJSON)
+	if err != nil {
+		utils.LogError(ctx, err, "Badge seeding failed")
+		return nil, err
+	}
+
+	utils.LogInfo(ctx, "Badges seeded", map[string]interface{}{
+		"seeded": seeded,
+	})
+	return &SeedBadgesJobOutput{Seeded: seeded}, nil
+}
+
+// PurgeIdempotencyKeysJobOutput 期限切れの冪等キーの削除ジョブの結果
+type PurgeIdempotencyKeysJobOutput struct {
+	Purged int64 `json:"purged"`
+}
+
+// PurgeIdempotencyKeysJobHandler 期限切れの冪等キーを削除するバッチジョブの Lambda ハンドラー
+// EventBridge のスケジュール（1日1回程度）から起動する
+func PurgeIdempotencyKeysJobHandler(ctx context.Context) (*PurgeIdempotencyKeysJobOutput, error) {
+	purged, err := utils.PurgeExpiredIdempotencyKeys(time.Now())
+	fields := map[string]interface{}{
+		"purged": purged,
+	}
+	if err != nil {
+		utils.LogError(ctx, err, "Idempotency key purge failed", fields)
+		return nil, err
+	}
+
+	utils.LogInfo(ctx, "Expired idempotency keys purged", fields)
+	return &PurgeIdempotencyKeysJobOutput{Purged: purged}, nil
+}
+
+// startBatchJob BATCH_JOB に指定したバッチジョブを Lambda のハンドラーとして開始する
+func startBatchJob(job string) error {
+	switch job {
+	case batchJobUserRecap:
+		lambda.Start(RecapJobHandler)
+		return nil
+	case batchJobSeedBeerStyles:
+		lambda.Start(SeedStylesJobHandler)
+		return nil
+	case batchJobSeedBadges:
+		lambda.Start(SeedBadgesJobHandler)
+		return nil
+	case batchJobPurgeIdempotencyKeys:
+		lambda.Start(PurgeIdempotencyKeysJobHandler)
+		return nil
+	default:
+		return fmt.Errorf("unknown batch job: %s", job)
+	}
+}
+
+// runBatchJobOnce BATCH_JOB に指定したバッチジョブをローカルで1回実行する（入力は BATCH_JOB_INPUT の JSON）
+func runBatchJobOnce(job string) error {
+	switch job {
+	case batchJobUserRecap:
+		var input RecapJobInput
+		if value := os.Getenv("BATCH_JOB_INPUT"); value != "" {
+			if err := json.Unmarshal([]byte(value), &input); err != nil {
+				return fmt.Errorf("invalid BATCH_JOB_INPUT: %w", err)
+			}
+		}
+		_, err := RecapJobHandler(context.Background(), input)
+		return err
+	case batchJobSeedBeerStyles:
+		_, err := SeedStylesJobHandler(context.Background())
+		return err
+	case batchJobSeedBadges:
+		_, err := SeedBadgesJobHandler(context.Background())
+		return err
+	case batchJobPurgeIdempotencyKeys:
+		_, err := PurgeIdempotencyKeysJobHandler(context.Background())
+		return err
+	default:
+		return fmt.Errorf("unknown batch job: %s", job)
+	}
+}
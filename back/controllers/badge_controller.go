@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+)
+
+// BadgeController バッジ関連のHTTPリクエストを処理するコントローラー
+type BadgeController struct {
+	BaseController
+	badgeUsecase       usecase.BadgeUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewBadgeController 新しいバッジコントローラーを作成する
+func NewBadgeController() *BadgeController {
+	badgeRepo := repository.NewBadgeRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &BadgeController{
+		badgeUsecase:       usecase.NewBadgeUsecase(badgeRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetMyBadges 認証されたユーザーが獲得したバッジの一覧を取得する
+// @Title Get My Badges
+// @Description Get badges earned by the authenticated user
+// @Success 200 {object} dto.UserBadgesResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/badges [get]
+func (c *BadgeController) GetMyBadges() {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	badges, err := c.badgeUsecase.GetUserBadges(userProfile.ID())
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.UserBadgesResponse{
+		Badges: mapper.UserBadgeEntitiesToResponses(badges),
+		Total:  len(badges),
+	}
+	c.JSONResponse(response)
+}
@@ -2,11 +2,13 @@ package controllers
 
 import (
 	"errors"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
 	"mybeerlog/interfaces/dto"
 	"mybeerlog/utils"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/astaxie/beego"
 )
@@ -101,132 +103,161 @@ func (c *BaseController) HandleInternalError(err error) {
 	c.ErrorResponseDetailed(http.StatusInternalServerError, "Internal server error", err.Error(), dto.ErrorCodeInternalServer, nil)
 }
 
-// GetCognitoSub API GatewayからCognito Sub情報を取得する
-func (c *BaseController) GetCognitoSub() (string, error) {
-	// 1. API Gateway Authorizer から設定されるヘッダーを確認
-	// Lambda Authorizerまたは Cognito Authorizer が設定するヘッダー
-	cognitoSub := c.getCognitoSubFromHeaders()
-	if cognitoSub != "" {
-		utils.LogDebug(c.Ctx.Request.Context(), "Cognito Sub obtained from API Gateway headers", map[string]interface{}{
-			"cognito_sub": cognitoSub,
-		})
-		return cognitoSub, nil
+// domainErrorResponse ドメインエラーに対応するHTTPレスポンスの定義
+type domainErrorResponse struct {
+	target     error
+	httpCode   int
+	errorCode  string
+	userMessage string
+}
+
+// domainErrorResponses 個別のHTTPレスポンスを返すドメインエラーの一覧
+// ここにないドメインエラーはエラーの種別から汎用のレスポンスを返す
+var domainErrorResponses = []domainErrorResponse{
+	{domainerr.ErrUserProfileNotFound, http.StatusNotFound, dto.ErrorCodeProfileNotFound, "User profile not found"},
+	{domainerr.ErrUserProfileAlreadyExists, http.StatusConflict, dto.ErrorCodeProfileExists, "Profile already exists"},
+	{domainerr.ErrHandleAlreadyTaken, http.StatusConflict, dto.ErrorCodeHandleTaken, "Handle is already taken"},
+	{domainerr.ErrBreweryNotFound, http.StatusNotFound, dto.ErrorCodeBreweryNotFound, "Brewery not found"},
+	{domainerr.ErrBreweryModified, http.StatusConflict, dto.ErrorCodeResourceConflict, "Brewery has been modified by another request"},
+	{domainerr.ErrBeerNotFound, http.StatusNotFound, dto.ErrorCodeBeerNotFound, "Beer not found"},
+	{domainerr.ErrBeerModified, http.StatusConflict, dto.ErrorCodeResourceConflict, "Beer has been modified by another request"},
+	{domainerr.ErrBeerLogNotFound, http.StatusNotFound, dto.ErrorCodeBeerLogNotFound, "Beer log entry not found"},
+	{domainerr.ErrBreweryPostNotFound, http.StatusNotFound, dto.ErrorCodePostNotFound, "Post not found"},
+	{domainerr.ErrEventNotFound, http.StatusNotFound, dto.ErrorCodeEventNotFound, "Event not found"},
+	{domainerr.ErrEventRSVPNotFound, http.StatusNotFound, dto.ErrorCodeRSVPNotFound, "You have not RSVPed to this event"},
+	{domainerr.ErrEventClosed, http.StatusConflict, dto.ErrorCodeEventClosed, "Event has already ended"},
+	{domainerr.ErrEventNotInProgress, http.StatusBadRequest, dto.ErrorCodeEventNotInProgress, "Event check-in is not open"},
+	{domainerr.ErrNotGoingToEvent, http.StatusForbidden, dto.ErrorCodeNotGoingToEvent, "Only confirmed attendees can check in to the event"},
+	{domainerr.ErrRallyNotFound, http.StatusNotFound, dto.ErrorCodeRallyNotFound, "Rally not found"},
+	{domainerr.ErrRallyNotJoined, http.StatusNotFound, dto.ErrorCodeRallyNotJoined, "You have not joined this rally"},
+	{domainerr.ErrRallyClosed, http.StatusConflict, dto.ErrorCodeRallyClosed, "Rally has already ended"},
+	{domainerr.ErrNotRallyOrganizer, http.StatusForbidden, dto.ErrorCodeForbidden, "You must manage the rally's breweries"},
+	{domainerr.ErrRallyRewardNotFound, http.StatusNotFound, dto.ErrorCodeRewardNotFound, "Reward code not found"},
+	{domainerr.ErrRallyRewardRedeemed, http.StatusConflict, dto.ErrorCodeRewardRedeemed, "Reward has already been redeemed"},
+	{domainerr.ErrCalendarFeedNotFound, http.StatusNotFound, dto.ErrorCodeCalendarNotFound, "Calendar feed not found"},
+	{domainerr.ErrWishlistItemNotFound, http.StatusNotFound, dto.ErrorCodeWishlistNotFound, "Brewery is not in the wishlist"},
+	{domainerr.ErrNotFollowingBrewery, http.StatusNotFound, dto.ErrorCodeNotFollowing, "You are not following this brewery"},
+	{domainerr.ErrInvalidCursor, http.StatusBadRequest, dto.ErrorCodeInvalidParameter, "Invalid cursor"},
+	{domainerr.ErrBreweryManagerNotFound, http.StatusNotFound, dto.ErrorCodeManagerNotFound, "Brewery manager not found"},
+	{domainerr.ErrBreweryManagerAlreadyExists, http.StatusConflict, dto.ErrorCodeManagerExists, "User is already a manager of this brewery"},
+	{domainerr.ErrVisitNotFound, http.StatusNotFound, dto.ErrorCodeVisitNotFound, "Visit not found"},
+	{domainerr.ErrVisitAccessDenied, http.StatusForbidden, dto.ErrorCodeForbidden, "Access denied"},
+	{domainerr.ErrTooFarFromBrewery, http.StatusBadRequest, dto.ErrorCodeLocationTooFar, "Too far from brewery for check-in"},
+	{domainerr.ErrLocationAccuracyRequired, http.StatusBadRequest, dto.ErrorCodeLocationUnreliable, "Location is not reliable enough for check-in"},
+	{domainerr.ErrLocationAccuracyInvalid, http.StatusBadRequest, dto.ErrorCodeLocationUnreliable, "Location is not reliable enough for check-in"},
+	{domainerr.ErrLocationAccuracyTooLow, http.StatusBadRequest, dto.ErrorCodeLocationUnreliable, "Location is not reliable enough for check-in"},
+	{domainerr.ErrImpossibleTravel, http.StatusBadRequest, dto.ErrorCodeImpossibleTravel, "Travel from your previous check-in is not plausible"},
+	{domainerr.ErrDuplicateCheckin, http.StatusBadRequest, dto.ErrorCodeDuplicateCheckin, "Already checked in to this brewery recently"},
+	{domainerr.ErrInvalidCheckinCode, http.StatusBadRequest, dto.ErrorCodeInvalidCheckinCode, "Invalid check-in code"},
+	{domainerr.ErrCheckinCodeExpired, http.StatusBadRequest, dto.ErrorCodeCheckinCodeExpired, "Check-in code has expired, please scan the current code"},
+}
+
+// HandleDomainError ユースケース・リポジトリが返したエラーをHTTPレスポンスに変換する
+// ドメインエラーでないエラー（DB障害など）は 500 Internal Server Error として扱う
+func (c *BaseController) HandleDomainError(err error) {
+	if err == nil {
+		return
 	}
 
-	// 2. 開発環境でのテストトークン処理
-	if beego.BConfig.RunMode == "dev" {
-		authHeader := c.Ctx.Request.Header.Get("Authorization")
-		if authHeader != "" {
-			parts := strings.Split(authHeader, " ")
-			if len(parts) == 2 && parts[0] == "Bearer" {
-				authManager := utils.GetTestAuthTokenManager()
-				if testCognitoSub, err := authManager.ValidateToken(parts[1]); err == nil {
-					utils.LogDebug(c.Ctx.Request.Context(), "Test token validated in dev environment", map[string]interface{}{
-						"cognito_sub": testCognitoSub,
-					})
-					return testCognitoSub, nil
-				}
-			}
+	for _, response := range domainErrorResponses {
+		if errors.Is(err, response.target) {
+			c.ErrorResponseDetailed(response.httpCode, response.userMessage, err.Error(), response.errorCode, nil)
+			return
 		}
 	}
 
-	// 3. 認証情報が取得できない場合のエラー
-	utils.LogWarn(c.Ctx.Request.Context(), "Failed to obtain Cognito Sub from API Gateway")
-	return "", errors.New("authentication required: cognito sub not found")
+	switch domainerr.KindOf(err) {
+	case domainerr.KindInvalid:
+		c.ErrorResponseDetailed(http.StatusBadRequest, err.Error(), err.Error(), dto.ErrorCodeValidationFailed, nil)
+	case domainerr.KindForbidden:
+		c.ErrorResponseDetailed(http.StatusForbidden, "Access denied", err.Error(), dto.ErrorCodeForbidden, nil)
+	case domainerr.KindNotFound:
+		c.ErrorResponseDetailed(http.StatusNotFound, "Resource not found", err.Error(), dto.ErrorCodeNotFound, nil)
+	case domainerr.KindConflict:
+		c.ErrorResponseDetailed(http.StatusConflict, "Resource already exists", err.Error(), dto.ErrorCodeResourceExists, nil)
+	default:
+		c.HandleInternalError(err)
+	}
 }
 
-// getCognitoSubFromHeaders API Gatewayが設定する各種ヘッダーからCognito Subを取得
-func (c *BaseController) getCognitoSubFromHeaders() string {
-	// API Gateway Cognito Authorizer が設定するヘッダー（一般的なパターン）
-	headers := []string{
-		"X-Cognito-Sub",                    // Cognito Authorizer
-		"X-Amzn-Cognito-Sub",              // AWS Lambda Proxy統合
-		"X-Amz-User-Sub",                  // カスタムヘッダー
-		"X-User-Sub",                      // カスタムヘッダー
-	}
-	
-	for _, header := range headers {
-		if value := c.Ctx.Request.Header.Get(header); value != "" {
-			return value
-		}
+// GetAuthClaims AuthenticationMiddlewareで検証済みのクレームを取得する
+func (c *BaseController) GetAuthClaims() *utils.AuthClaims {
+	return utils.GetAuthClaimsFromContext(c.Ctx.Request.Context())
+}
+
+// GetCognitoSub 検証済みトークンからCognito Sub情報を取得する
+func (c *BaseController) GetCognitoSub() (string, error) {
+	claims := c.GetAuthClaims()
+	if claims != nil && claims.Sub != "" {
+		return claims.Sub, nil
 	}
-	
-	// Lambda環境での requestContext からの取得（追加の確認）
-	if c.Ctx.Request.Header.Get("X-Amzn-Requestid") != "" {
-		// API Gateway Lambda プロキシ統合でのリクエストコンテキスト情報
-		if value := c.Ctx.Request.Header.Get("X-Amzn-Requestcontext-Authorizer-Claims-Sub"); value != "" {
-			return value
-		}
+
+	// 認証情報が取得できない場合のエラー
+	if authErr := utils.GetAuthErrorFromContext(c.Ctx.Request.Context()); authErr != nil {
+		return "", authErr
 	}
-	
-	return ""
+	return "", errors.New("authentication required: cognito sub not found")
 }
 
 // IsAdmin 管理者権限をチェックする
 func (c *BaseController) IsAdmin() bool {
-	// API Gateway Authorizer からグループ情報を取得
-	groups := c.getCognitoGroupsFromHeaders()
-	for _, group := range groups {
-		if group == "admin" || group == "administrators" {
-			return true
-		}
+	claims := c.GetAuthClaims()
+	if claims == nil {
+		return false
 	}
-	return false
+	return claims.HasGroup("admin", "administrators")
 }
 
-// getCognitoGroupsFromHeaders API Gatewayが設定するヘッダーからCognitoグループ情報を取得
-func (c *BaseController) getCognitoGroupsFromHeaders() []string {
-	// API Gateway Cognito Authorizer が設定するグループヘッダー
-	groupsHeader := c.Ctx.Request.Header.Get("X-Cognito-Groups")
-	if groupsHeader == "" {
-		groupsHeader = c.Ctx.Request.Header.Get("X-Amzn-Cognito-Groups")
-	}
-	if groupsHeader == "" {
-		groupsHeader = c.Ctx.Request.Header.Get("X-Amzn-Requestcontext-Authorizer-Claims-Cognito-Groups")
+// IsBreweryManager 醸造所管理者グループに所属しているかをチェックする
+func (c *BaseController) IsBreweryManager() bool {
+	claims := c.GetAuthClaims()
+	if claims == nil {
+		return false
 	}
-	
-	if groupsHeader != "" {
-		// カンマ区切りまたはスペース区切りでグループが設定される場合がある
-		groups := strings.Split(groupsHeader, ",")
-		for i, group := range groups {
-			groups[i] = strings.TrimSpace(group)
-		}
-		return groups
+	return claims.HasGroup("brewery_manager")
+}
+
+// GetIntPathParam 整数型のパスパラメータを取得する（不正な値の場合は0を返す）
+func (c *BaseController) GetIntPathParam(key string) int {
+	value, err := strconv.Atoi(c.Ctx.Input.Param(":" + key))
+	if err != nil {
+		return 0
 	}
-	
-	return []string{}
+	return value
 }
 
 // GetIntQuery 整数型のクエリパラメータを取得する
-func (c *BaseController) GetIntQuery(key string, defaultValue int) int {
+// 不正な値の場合はバリデーションエラーのレスポンスを書き込み、false を返す
+func (c *BaseController) GetIntQuery(key string, defaultValue int) (int, bool) {
 	value := c.GetString(key)
 	if value == "" {
-		return defaultValue
+		return defaultValue, true
 	}
 
 	intValue, err := strconv.Atoi(value)
 	if err != nil {
 		c.HandleValidationError(key, "Invalid integer value", value)
-		return defaultValue
+		return defaultValue, false
 	}
 
-	return intValue
+	return intValue, true
 }
 
 // GetFloatQuery 浮動小数点型のクエリパラメータを取得する
-func (c *BaseController) GetFloatQuery(key string, defaultValue float64) float64 {
+// 不正な値の場合はバリデーションエラーのレスポンスを書き込み、false を返す
+func (c *BaseController) GetFloatQuery(key string, defaultValue float64) (float64, bool) {
 	value := c.GetString(key)
 	if value == "" {
-		return defaultValue
+		return defaultValue, true
 	}
 
 	floatValue, err := strconv.ParseFloat(value, 64)
 	if err != nil {
 		c.HandleValidationError(key, "Invalid float value", value)
-		return defaultValue
+		return defaultValue, false
 	}
 
-	return floatValue
+	return floatValue, true
 }
 
 // GetStringQuery 文字列型のクエリパラメータを取得する
@@ -243,7 +274,14 @@ func (c *BaseController) GetStringQuery(key string, defaultValue string) string
 func (c *BaseController) RequireAuth() (string, bool) {
 	cognitoSub, err := c.GetCognitoSub()
 	if err != nil {
-		c.HandleUnauthorized("Authentication required")
+		switch {
+		case errors.Is(err, utils.ErrTokenExpired):
+			c.ErrorResponseDetailed(http.StatusUnauthorized, "Token has expired", "", dto.ErrorCodeTokenExpired, nil)
+		case utils.GetAuthErrorFromContext(c.Ctx.Request.Context()) != nil:
+			c.ErrorResponseDetailed(http.StatusUnauthorized, "Invalid token", "", dto.ErrorCodeInvalidToken, nil)
+		default:
+			c.HandleUnauthorized("Authentication required")
+		}
 		return "", false
 	}
 	return cognitoSub, true
@@ -263,3 +301,45 @@ func (c *BaseController) RequireAdmin() (string, bool) {
 	
 	return cognitoSub, true
 }
+
+// RequireBreweryManager 指定された醸造所の管理権限が必要なエンドポイント用のヘルパー
+// PF管理者は全ての醸造所を管理できる。醸造所管理者は自分に紐付く醸造所のみ管理できる
+func (c *BaseController) RequireBreweryManager(breweryID int) (string, bool) {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return "", false
+	}
+
+	if c.IsAdmin() {
+		return cognitoSub, true
+	}
+
+	if !c.IsBreweryManager() {
+		c.ErrorResponseDetailed(http.StatusForbidden, "Brewery manager access required", "", dto.ErrorCodeForbidden, nil)
+		return "", false
+	}
+
+	userProfileRepo := repository.NewUserProfileRepository()
+	userProfile, err := userProfileRepo.GetByCognitoSub(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return "", false
+	}
+
+	breweryManagerUsecase := usecase.NewBreweryManagerUsecase(
+		repository.NewBreweryManagerRepository(),
+		repository.NewBreweryRepository(),
+		userProfileRepo,
+	)
+	isManager, err := breweryManagerUsecase.IsManager(userProfile.ID(), breweryID)
+	if err != nil {
+		c.HandleInternalError(err)
+		return "", false
+	}
+	if !isManager {
+		c.ErrorResponseDetailed(http.StatusForbidden, "You are not a manager of this brewery", "", dto.ErrorCodeForbidden, nil)
+		return "", false
+	}
+
+	return cognitoSub, true
+}
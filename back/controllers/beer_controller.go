@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"encoding/json"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// BeerController ビール関連のHTTPリクエストを処理するコントローラー
+type BeerController struct {
+	BaseController
+	beerUsecase usecase.BeerUsecase
+}
+
+// NewBeerController 新しいビールコントローラーを作成する
+func NewBeerController() *BeerController {
+	beerRepo := repository.NewBeerRepository()
+	breweryRepo := repository.NewBreweryRepository()
+
+	return &BeerController{
+		beerUsecase: usecase.NewBeerUsecase(beerRepo, breweryRepo),
+	}
+}
+
+// GetBreweryBeers 醸造所のビール一覧を取得する
+// @Title Get Brewery Beers
+// @Description Get beers brewed by the brewery
+// @Param brewery_id path int true "Brewery ID"
+// @Param limit query int false "Limit (default: 20, max: 100)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} dto.BeersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/beers [get]
+func (c *BeerController) GetBreweryBeers() {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return
+	}
+
+	limit, offset, ok := c.getLimitOffset()
+	if !ok {
+		return
+	}
+
+	beers, total, err := c.beerUsecase.GetBreweryBeers(breweryID, limit, offset)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.BeersResponse{
+		Beers: mapper.BeerEntitiesToResponses(beers),
+		Total: total,
+	}
+	c.JSONResponse(response)
+}
+
+// CreateBeer 醸造所にビールを登録する（管理者または担当の醸造所管理者のみ）
+// @Title Create Beer
+// @Description Register a beer of the brewery (admin or manager of the brewery)
+// @Param brewery_id path int true "Brewery ID"
+// @Param body body dto.BeerRequest true "Beer data"
+// @Success 201 {object} dto.BeerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/beers [post]
+func (c *BeerController) CreateBeer() {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(breweryID)
+	if !ok {
+		return
+	}
+
+	var request dto.BeerRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	input, ok := c.beerInputFromRequest(&request)
+	if !ok {
+		return
+	}
+
+	beer, err := c.beerUsecase.CreateBeer(breweryID, input)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Beer created", map[string]interface{}{
+		"beer_id":     beer.ID(),
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(mapper.BeerEntityToResponse(beer), "Beer created successfully")
+}
+
+// GetBeer IDでビールを取得する
+// @Title Get Beer
+// @Description Get beer by ID
+// @Param beer_id path int true "Beer ID"
+// @Success 200 {object} dto.BeerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /beers/:beer_id [get]
+func (c *BeerController) GetBeer() {
+	beerID := c.GetIntPathParam("beer_id")
+	if beerID <= 0 {
+		c.HandleValidationError("beer_id", "Invalid beer ID", c.Ctx.Input.Param(":beer_id"))
+		return
+	}
+
+	beer, err := c.beerUsecase.GetBeer(beerID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.BeerEntityToResponse(beer))
+}
+
+// UpdateBeer ビール情報を更新する（管理者または担当の醸造所管理者のみ）
+// @Title Update Beer
+// @Description Replace beer data (admin or manager of the brewery). updated_at must match the current value
+// @Param beer_id path int true "Beer ID"
+// @Param body body dto.BeerUpdateRequest true "Beer data"
+// @Success 200 {object} dto.BeerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /beers/:beer_id [put]
+func (c *BeerController) UpdateBeer() {
+	beerID := c.GetIntPathParam("beer_id")
+	if beerID <= 0 {
+		c.HandleValidationError("beer_id", "Invalid beer ID", c.Ctx.Input.Param(":beer_id"))
+		return
+	}
+
+	beer, err := c.beerUsecase.GetBeer(beerID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(beer.BreweryID())
+	if !ok {
+		return
+	}
+
+	var request dto.BeerUpdateRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+	if request.UpdatedAt.IsZero() {
+		c.HandleValidationError("updated_at", "updated_at is required for optimistic concurrency control", "")
+		return
+	}
+
+	input, ok := c.beerInputFromRequest(&request.BeerRequest)
+	if !ok {
+		return
+	}
+
+	updatedBeer, err := c.beerUsecase.UpdateBeer(beerID, input, request.UpdatedAt)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Beer updated", map[string]interface{}{
+		"beer_id":     beerID,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.JSONResponseWithMessage(mapper.BeerEntityToResponse(updatedBeer), "Beer updated successfully")
+}
+
+// DeleteBeer ビールを削除する（管理者または担当の醸造所管理者のみ）
+// @Title Delete Beer
+// @Description Delete beer (admin or manager of the brewery)
+// @Param beer_id path int true "Beer ID"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /beers/:beer_id [delete]
+func (c *BeerController) DeleteBeer() {
+	beerID := c.GetIntPathParam("beer_id")
+	if beerID <= 0 {
+		c.HandleValidationError("beer_id", "Invalid beer ID", c.Ctx.Input.Param(":beer_id"))
+		return
+	}
+
+	beer, err := c.beerUsecase.GetBeer(beerID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(beer.BreweryID())
+	if !ok {
+		return
+	}
+
+	if err := c.beerUsecase.DeleteBeer(beerID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Beer deleted", map[string]interface{}{
+		"beer_id":     beerID,
+		"brewery_id":  beer.BreweryID(),
+		"cognito_sub": cognitoSub,
+	})
+
+	c.JSONResponseWithMessage(map[string]int{"beer_id": beerID}, "Beer deleted successfully")
+}
+
+// beerInputFromRequest リクエストをユースケースの入力に変換する（提供期間の形式が不正な場合はエラーレスポンスを返す）
+func (c *BeerController) beerInputFromRequest(request *dto.BeerRequest) (usecase.BeerInput, bool) {
+	availableFrom, ok := c.parseBeerDate("available_from", request.AvailableFrom)
+	if !ok {
+		return usecase.BeerInput{}, false
+	}
+	availableUntil, ok := c.parseBeerDate("available_until", request.AvailableUntil)
+	if !ok {
+		return usecase.BeerInput{}, false
+	}
+
+	return usecase.BeerInput{
+		Name:           request.Name,
+		Style:          request.Style,
+		ABV:            request.ABV,
+		IBU:            request.IBU,
+		Description:    request.Description,
+		IsSeasonal:     request.IsSeasonal,
+		IsLimited:      request.IsLimited,
+		AvailableFrom:  availableFrom,
+		AvailableUntil: availableUntil,
+	}, true
+}
+
+// parseBeerDate YYYY-MM-DD 形式の日付を解析する（未指定・空文字の場合は nil）
+func (c *BeerController) parseBeerDate(field string, value *string) (*time.Time, bool) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, true
+	}
+
+	date, err := time.Parse(mapper.BeerDateFormat, strings.TrimSpace(*value))
+	if err != nil {
+		c.HandleValidationError(field, field+" must be a date in YYYY-MM-DD format", *value)
+		return nil, false
+	}
+	return &date, true
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"encoding/json"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+)
+
+// BeerLogController 訪問中に飲んだビールの記録に関するHTTPリクエストを処理するコントローラー
+type BeerLogController struct {
+	BaseController
+	beerLogUsecase     usecase.BeerLogUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewBeerLogController 新しいビールの記録コントローラーを作成する
+func NewBeerLogController() *BeerLogController {
+	beerLogRepo := repository.NewBeerLogRepository()
+	visitRepo := repository.NewVisitRepository()
+	beerRepo := repository.NewBeerRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &BeerLogController{
+		beerLogUsecase:     usecase.NewBeerLogUsecase(beerLogRepo, visitRepo, beerRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// AddBeerLog 訪問に飲んだビールの記録を追加する（訪問の所有者のみ）
+// @Title Add Beer Log
+// @Description Log a beer drunk during the visit (owner only)
+// @Param visit_id path int true "Visit ID"
+// @Param body body dto.BeerLogRequest true "Beer log data"
+// @Success 201 {object} dto.BeerLogResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /visits/:visit_id/beers [post]
+func (c *BeerLogController) AddBeerLog() {
+	visitID := c.GetIntPathParam("visit_id")
+	if visitID <= 0 {
+		c.HandleValidationError("visit_id", "Invalid visit ID", c.Ctx.Input.Param(":visit_id"))
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	var request dto.BeerLogRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	beerLog, err := c.beerLogUsecase.AddBeerLog(visitID, userProfileID, beerLogInputFromRequest(&request))
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Beer log added", map[string]interface{}{
+		"beer_log_id": beerLog.ID(),
+		"visit_id":    visitID,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(mapper.BeerLogEntityToResponse(beerLog), "Beer log added successfully")
+}
+
+// UpdateBeerLog 飲んだビールの記録を更新する（訪問の所有者のみ）
+// @Title Update Beer Log
+// @Description Replace a beer log entry of the visit (owner only)
+// @Param visit_id path int true "Visit ID"
+// @Param beer_log_id path int true "Beer log ID"
+// @Param body body dto.BeerLogRequest true "Beer log data"
+// @Success 200 {object} dto.BeerLogResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /visits/:visit_id/beers/:beer_log_id [put]
+func (c *BeerLogController) UpdateBeerLog() {
+	visitID, beerLogID, ok := c.getBeerLogPathParams()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	var request dto.BeerLogRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	beerLog, err := c.beerLogUsecase.UpdateBeerLog(visitID, beerLogID, userProfileID, beerLogInputFromRequest(&request))
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponseWithMessage(mapper.BeerLogEntityToResponse(beerLog), "Beer log updated successfully")
+}
+
+// DeleteBeerLog 飲んだビールの記録を削除する（訪問の所有者のみ）
+// @Title Delete Beer Log
+// @Description Delete a beer log entry of the visit (owner only)
+// @Param visit_id path int true "Visit ID"
+// @Param beer_log_id path int true "Beer log ID"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /visits/:visit_id/beers/:beer_log_id [delete]
+func (c *BeerLogController) DeleteBeerLog() {
+	visitID, beerLogID, ok := c.getBeerLogPathParams()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	if err := c.beerLogUsecase.DeleteBeerLog(visitID, beerLogID, userProfileID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponseWithMessage(map[string]int{"beer_log_id": beerLogID}, "Beer log deleted successfully")
+}
+
+// requireUserProfileID 認証済みユーザーのプロファイルIDを取得する（失敗時はエラーレスポンスを返す）
+func (c *BeerLogController) requireUserProfileID() (int, bool) {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return 0, false
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return 0, false
+	}
+
+	return userProfile.ID(), true
+}
+
+// getBeerLogPathParams パスパラメータから訪問IDとビールの記録IDを取得する
+func (c *BeerLogController) getBeerLogPathParams() (int, int, bool) {
+	visitID := c.GetIntPathParam("visit_id")
+	if visitID <= 0 {
+		c.HandleValidationError("visit_id", "Invalid visit ID", c.Ctx.Input.Param(":visit_id"))
+		return 0, 0, false
+	}
+
+	beerLogID := c.GetIntPathParam("beer_log_id")
+	if beerLogID <= 0 {
+		c.HandleValidationError("beer_log_id", "Invalid beer log ID", c.Ctx.Input.Param(":beer_log_id"))
+		return 0, 0, false
+	}
+
+	return visitID, beerLogID, true
+}
+
+// beerLogInputFromRequest リクエストをユースケースの入力に変換する
+func beerLogInputFromRequest(request *dto.BeerLogRequest) usecase.BeerLogInput {
+	return usecase.BeerLogInput{
+		BeerID:      request.BeerID,
+		BeerName:    request.BeerName,
+		ServingType: request.ServingType,
+		Rating:      request.Rating,
+		Notes:       request.Notes,
+		FlavorTags:  request.FlavorTags,
+	}
+}
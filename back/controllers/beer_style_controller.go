@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"encoding/json"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+)
+
+// BeerStyleController ビアスタイル関連のHTTPリクエストを処理するコントローラー
+type BeerStyleController struct {
+	BaseController
+	styleUsecase usecase.BeerStyleUsecase
+}
+
+// NewBeerStyleController 新しいビアスタイルコントローラーを作成する
+func NewBeerStyleController() *BeerStyleController {
+	styleRepo := repository.NewBeerStyleRepository()
+	breweryRepo := repository.NewBreweryRepository()
+
+	return &BeerStyleController{
+		styleUsecase: usecase.NewBeerStyleUsecase(styleRepo, breweryRepo),
+	}
+}
+
+// GetStyles ビアスタイルの分類を木構造で取得する
+// @Title Get Beer Styles
+// @Description Get the beer style tree (family → style → substyle)
+// @Success 200 {object} dto.BeerStylesResponse
+// @router /styles [get]
+func (c *BeerStyleController) GetStyles() {
+	tree, err := c.styleUsecase.GetStyleTree()
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(dto.BeerStylesResponse{
+		Styles: mapper.BeerStyleTreeToResponses(tree),
+	})
+}
+
+// GetBreweryStyles 醸造所が扱うビアスタイルを取得する
+// @Title Get Brewery Styles
+// @Description Get beer styles the brewery makes
+// @Param brewery_id path int true "Brewery ID"
+// @Success 200 {object} dto.BreweryStylesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/styles [get]
+func (c *BeerStyleController) GetBreweryStyles() {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return
+	}
+
+	styles, err := c.styleUsecase.GetBreweryStyles(breweryID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(dto.BreweryStylesResponse{
+		BreweryID: breweryID,
+		Styles:    mapper.BeerStyleEntitiesToResponses(styles),
+	})
+}
+
+// UpdateBreweryStyles 醸造所が扱うビアスタイルを置き換える（管理者のみ）
+// @Title Update Brewery Styles
+// @Description Replace beer styles the brewery makes (admin only)
+// @Param brewery_id path int true "Brewery ID"
+// @Param body body dto.BreweryStylesRequest true "Style codes"
+// @Success 200 {object} dto.BreweryStylesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/styles [put]
+func (c *BeerStyleController) UpdateBreweryStyles() {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return
+	}
+
+	cognitoSub, ok := c.RequireAdmin()
+	if !ok {
+		return
+	}
+
+	var request dto.BreweryStylesRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	styles, err := c.styleUsecase.UpdateBreweryStyles(breweryID, request.Styles)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery styles updated", map[string]interface{}{
+		"brewery_id":  breweryID,
+		"styles":      request.Styles,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.JSONResponseWithMessage(dto.BreweryStylesResponse{
+		BreweryID: breweryID,
+		Styles:    mapper.BeerStyleEntitiesToResponses(styles),
+	}, "Brewery styles updated successfully")
+}
@@ -2,89 +2,165 @@ package controllers
 
 import (
 	"encoding/json"
-	"mybeerlog/domain/entity"
+	"errors"
+	"mybeerlog/domain/domainerr"
 	"mybeerlog/domain/repository"
 	"mybeerlog/domain/usecase"
 	"mybeerlog/interfaces/dto"
 	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"time"
+
+	"github.com/astaxie/beego"
 )
 
+// breweriesCursorScope 醸造所一覧のカーソルの発行元（認証の有無に関係なく共通）
+const breweriesCursorScope = "breweries"
+
 // BreweryController 醸造所関連のHTTPリクエストを処理するコントローラー
 type BreweryController struct {
 	BaseController
 	breweryUsecase usecase.BreweryUsecase
+	feedUsecase    usecase.FeedUsecase
 }
 
 // NewBreweryController 新しい醸造所コントローラーを作成する
 func NewBreweryController() *BreweryController {
 	breweryRepo := repository.NewBreweryRepository()
-	breweryUsecase := usecase.NewBreweryUsecase(breweryRepo)
+	styleRepo := repository.NewBeerStyleRepository()
+	breweryUsecase := usecase.NewBreweryUsecase(breweryRepo, styleRepo)
+	feedUsecase := usecase.NewFeedUsecase(repository.NewBreweryActivityRepository(), breweryRepo, repository.NewVisitRepository())
 
 	return &BreweryController{
 		breweryUsecase: breweryUsecase,
+		feedUsecase:    feedUsecase,
 	}
 }
 
 // GetBreweries 醸造所の一覧を取得する
+// lat と lng を指定した場合は半径 radius km 以内の醸造所を距離の近い順に返す
+// style を指定した場合は、そのスタイルまたは子孫のスタイルを扱う醸造所に絞り込む
 // @Title Get Breweries
-// @Description Get list of breweries
+// @Description Get list of breweries. When lat and lng are given, returns breweries within radius ordered by distance
 // @Param lat query float64 false "Latitude for location search"
 // @Param lng query float64 false "Longitude for location search"
 // @Param radius query float64 false "Search radius in km (default: 10)"
+// @Param style query string false "Style code (includes descendant styles)"
 // @Param limit query int false "Limit (default: 20, max: 100)"
-// @Param offset query int false "Offset (default: 0)"
+// @Param offset query int false "Offset (default: 0, ignored when cursor is given)"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page (not available for location search)"
+// @Param include_total query bool false "Include total (default: true without cursor, false with cursor)"
 // @Success 200 {object} dto.BreweriesResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @router /breweries [get]
 func (c *BreweryController) GetBreweries() {
-	lat := c.GetFloatQuery("lat", 0)
-	lng := c.GetFloatQuery("lng", 0)
-	radius := c.GetFloatQuery("radius", 10.0)
-	limit := c.GetIntQuery("limit", 20)
-	offset := c.GetIntQuery("offset", 0)
+	lat, ok := c.GetFloatQuery("lat", 0)
+	if !ok {
+		return
+	}
+	lng, ok := c.GetFloatQuery("lng", 0)
+	if !ok {
+		return
+	}
+	radius, ok := c.GetFloatQuery("radius", 10.0)
+	if !ok {
+		return
+	}
+	styleCode := c.GetString("style")
 
 	// 認証チェック（認証済みユーザーのみ位置情報取得可能）
 	cognitoSub, err := c.GetCognitoSub()
 	isAuthenticated := err == nil && cognitoSub != ""
 
-	var breweries []*entity.Brewery
-	var total int
-
-	if lat != 0 && lng != 0 {
-		// 位置情報による検索
-		breweries, total, err = c.breweryUsecase.GetBreweriesByLocation(lat, lng, radius, limit, offset)
-	} else {
-		// 全件取得
-		breweries, total, err = c.breweryUsecase.GetBreweries(limit, offset)
+	// 赤道・本初子午線上の地点も検索できるよう、0 ではなくパラメータの有無で判定する
+	if c.GetString("lat") != "" && c.GetString("lng") != "" {
+		// 距離の近い順の一覧はカーソルに対応せず、offset でページングする
+		limit, offset, ok := c.getLimitOffset()
+		if !ok {
+			return
+		}
+		c.getNearbyBreweries(lat, lng, radius, styleCode, limit, offset, isAuthenticated)
+		return
 	}
 
+	// 全件取得（登録の新しい順、カーソルによるページングに対応）
+	page, ok := c.getPageQuery(breweriesCursorScope)
+	if !ok {
+		return
+	}
+	breweries, result, err := c.breweryUsecase.GetBreweries(styleCode, page)
 	if err != nil {
-		c.ErrorResponse(400, err.Error(), "FETCH_FAILED")
+		c.HandleDomainError(err)
 		return
 	}
 
+	var nextCursor, prevCursor string
+	if len(breweries) > 0 {
+		first, last := breweries[0], breweries[len(breweries)-1]
+		nextCursor, prevCursor, err = pageCursors(breweriesCursorScope, result,
+			&repository.PageKey{Time: first.CreatedAt(), ID: first.ID()},
+			&repository.PageKey{Time: last.CreatedAt(), ID: last.ID()})
+		if err != nil {
+			c.HandleInternalError(err)
+			return
+		}
+	}
+
 	var response interface{}
 	if isAuthenticated {
 		// 認証済みユーザー: フル情報
 		response = dto.BreweriesResponse{
-			Breweries: mapper.BreweryEntitiesToResponses(breweries),
-			Total:     total,
+			Breweries:  mapper.BreweryEntitiesToResponses(breweries),
+			Total:      result.Total,
+			NextCursor: nextCursor,
+			PrevCursor: prevCursor,
 		}
 	} else {
 		// ゲスト: 基本情報のみ
-		publicBreweries := mapper.BreweryEntitiesToPublicResponses(breweries)
-		response = struct {
-			Breweries []*dto.BreweryPublicResponse `json:"breweries"`
-			Total     int                          `json:"total"`
-		}{
-			Breweries: publicBreweries,
-			Total:     total,
+		response = publicBreweriesResponse(mapper.BreweryEntitiesToPublicResponses(breweries), result.Total, nextCursor, prevCursor)
+	}
+
+	c.JSONResponse(response)
+}
+
+// getNearbyBreweries 位置情報による検索結果（距離の近い順）を返す
+func (c *BreweryController) getNearbyBreweries(lat, lng, radius float64, styleCode string, limit, offset int, isAuthenticated bool) {
+	breweries, total, err := c.breweryUsecase.GetBreweriesByLocation(lat, lng, radius, styleCode, limit, offset)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	var response interface{}
+	if isAuthenticated {
+		// 認証済みユーザー: フル情報（distance_m を含む）
+		response = dto.BreweriesResponse{
+			Breweries: mapper.NearbyBreweriesToResponses(breweries),
+			Total:     &total,
 		}
+	} else {
+		// ゲスト: 基本情報のみ
+		response = publicBreweriesResponse(mapper.NearbyBreweriesToPublicResponses(breweries), &total, "", "")
 	}
 
 	c.JSONResponse(response)
 }
 
+// publicBreweriesResponse ゲスト向けの醸造所一覧レスポンスを組み立てる
+func publicBreweriesResponse(breweries []*dto.BreweryPublicResponse, total *int, nextCursor, prevCursor string) interface{} {
+	return struct {
+		Breweries  []*dto.BreweryPublicResponse `json:"breweries"`
+		Total      *int                         `json:"total,omitempty"`
+		NextCursor string                       `json:"next_cursor,omitempty"`
+		PrevCursor string                       `json:"prev_cursor,omitempty"`
+	}{
+		Breweries:  breweries,
+		Total:      total,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	}
+}
+
 // CreateBrewery 新しい醸造所を作成する（管理者のみ）
 // @Title Create Brewery
 // @Description Create new brewery (admin only)
@@ -121,10 +197,16 @@ func (c *BreweryController) CreateBrewery() {
 		request.Longitude,
 	)
 	if err != nil {
-		c.ErrorResponse(400, err.Error(), "CREATE_FAILED")
+		c.HandleDomainError(err)
 		return
 	}
 
+	// 近隣の醸造所をフォローしているユーザーのフィードに掲載する（失敗しても登録自体は成功とする）
+	radiusKm := beego.AppConfig.DefaultFloat("feed.nearby_radius_km", 10.0)
+	if err := c.feedUsecase.RecordBreweryAdded(brewery, radiusKm); err != nil {
+		c.logActivityError(err, brewery.ID())
+	}
+
 	response := mapper.BreweryEntityToResponse(brewery)
 	c.Ctx.ResponseWriter.WriteHeader(201)
 	c.JSONResponse(response)
@@ -138,7 +220,7 @@ func (c *BreweryController) CreateBrewery() {
 // @Failure 404 {object} dto.ErrorResponse
 // @router /breweries/:brewery_id [get]
 func (c *BreweryController) GetBrewery() {
-	breweryID := c.GetIntQuery("brewery_id", 0)
+	breweryID := c.GetIntPathParam("brewery_id")
 	if breweryID <= 0 {
 		c.ErrorResponse(400, "Brewery ID is required", "INVALID_BREWERY_ID")
 		return
@@ -146,7 +228,7 @@ func (c *BreweryController) GetBrewery() {
 
 	brewery, err := c.breweryUsecase.GetBrewery(breweryID)
 	if err != nil {
-		c.ErrorResponse(404, "Brewery not found", "BREWERY_NOT_FOUND")
+		c.HandleDomainError(err)
 		return
 	}
 
@@ -165,3 +247,175 @@ func (c *BreweryController) GetBrewery() {
 
 	c.JSONResponse(response)
 }
+
+// UpdateBrewery 醸造所情報を更新する（管理者または担当の醸造所管理者のみ）
+// @Title Update Brewery
+// @Description Replace brewery data (admin or manager of the brewery). updated_at must match the current value
+// @Param brewery_id path int true "Brewery ID"
+// @Param body body dto.BreweryUpdateRequest true "Brewery data"
+// @Success 200 {object} dto.BreweryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id [put]
+func (c *BreweryController) UpdateBrewery() {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.ErrorResponse(400, "Brewery ID is required", "INVALID_BREWERY_ID")
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(breweryID)
+	if !ok {
+		return
+	}
+
+	var request dto.BreweryUpdateRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.ErrorResponse(400, "Invalid request body", "INVALID_REQUEST")
+		return
+	}
+
+	brewery, err := c.breweryUsecase.UpdateBrewery(
+		breweryID,
+		request.Name,
+		request.Address,
+		request.Description,
+		request.Latitude,
+		request.Longitude,
+		request.UpdatedAt,
+	)
+	if err != nil {
+		c.handleBreweryWriteError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery updated", map[string]interface{}{
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+	if err := c.feedUsecase.RecordBreweryUpdated(brewery); err != nil {
+		c.logActivityError(err, breweryID)
+	}
+
+	c.JSONResponseWithMessage(mapper.BreweryEntityToResponse(brewery), "Brewery updated successfully")
+}
+
+// PatchBrewery 醸造所情報を部分更新する（管理者または担当の醸造所管理者のみ）
+// @Title Patch Brewery
+// @Description Partially update brewery data (admin or manager of the brewery). updated_at must match the current value
+// @Param brewery_id path int true "Brewery ID"
+// @Param body body dto.BreweryPatchRequest true "Brewery fields to update"
+// @Success 200 {object} dto.BreweryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id [patch]
+func (c *BreweryController) PatchBrewery() {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.ErrorResponse(400, "Brewery ID is required", "INVALID_BREWERY_ID")
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(breweryID)
+	if !ok {
+		return
+	}
+
+	var request dto.BreweryPatchRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.ErrorResponse(400, "Invalid request body", "INVALID_REQUEST")
+		return
+	}
+
+	brewery, err := c.breweryUsecase.PatchBrewery(breweryID, usecase.BreweryPatch{
+		Name:        request.Name,
+		Address:     request.Address,
+		Description: request.Description,
+		Latitude:    request.Latitude,
+		Longitude:   request.Longitude,
+	}, request.UpdatedAt)
+	if err != nil {
+		c.handleBreweryWriteError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery patched", map[string]interface{}{
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+	if err := c.feedUsecase.RecordBreweryUpdated(brewery); err != nil {
+		c.logActivityError(err, breweryID)
+	}
+
+	c.JSONResponseWithMessage(mapper.BreweryEntityToResponse(brewery), "Brewery updated successfully")
+}
+
+// DeleteBrewery 醸造所をアーカイブ（論理削除）する（管理者のみ）
+// @Title Delete Brewery
+// @Description Archive brewery (admin only). Visit history is preserved
+// @Param brewery_id path int true "Brewery ID"
+// @Param updated_at query string true "Expected updated_at (RFC3339) for optimistic concurrency"
+// @Success 200 {object} dto.BreweryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id [delete]
+func (c *BreweryController) DeleteBrewery() {
+	cognitoSub, ok := c.RequireAdmin()
+	if !ok {
+		return
+	}
+
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.ErrorResponse(400, "Brewery ID is required", "INVALID_BREWERY_ID")
+		return
+	}
+
+	var expectedUpdatedAt time.Time
+	if value := c.GetString("updated_at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			c.HandleValidationError("updated_at", "updated_at must be RFC3339 date-time", value)
+			return
+		}
+		expectedUpdatedAt = parsed
+	}
+
+	brewery, err := c.breweryUsecase.ArchiveBrewery(breweryID, expectedUpdatedAt)
+	if err != nil {
+		c.handleBreweryWriteError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery archived", map[string]interface{}{
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.JSONResponseWithMessage(mapper.BreweryEntityToResponse(brewery), "Brewery archived successfully")
+}
+
+// handleBreweryWriteError 醸造所の更新・アーカイブ時のエラーをHTTPレスポンスに変換する
+func (c *BreweryController) handleBreweryWriteError(err error) {
+	if errors.Is(err, domainerr.ErrUpdatedAtRequired) {
+		c.HandleValidationError("updated_at", "updated_at is required for optimistic concurrency control", "")
+		return
+	}
+	c.HandleDomainError(err)
+}
+
+// logActivityError フィードへの掲載に失敗したことを記録する（醸造所の登録・更新自体は成功している）
+func (c *BreweryController) logActivityError(err error, breweryID int) {
+	utils.LogError(c.Ctx.Request.Context(), err, "Failed to record brewery activity", map[string]interface{}{
+		"brewery_id": breweryID,
+	})
+}
@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"encoding/json"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+)
+
+// BreweryManagerController 醸造所管理者関連のHTTPリクエストを処理するコントローラー
+type BreweryManagerController struct {
+	BaseController
+	breweryManagerUsecase usecase.BreweryManagerUsecase
+	userProfileUsecase    usecase.UserProfileUsecase
+}
+
+// NewBreweryManagerController 新しい醸造所管理者コントローラーを作成する
+func NewBreweryManagerController() *BreweryManagerController {
+	breweryManagerRepo := repository.NewBreweryManagerRepository()
+	breweryRepo := repository.NewBreweryRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &BreweryManagerController{
+		breweryManagerUsecase: usecase.NewBreweryManagerUsecase(breweryManagerRepo, breweryRepo, userProfileRepo),
+		userProfileUsecase:    usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetManagers 醸造所の管理者一覧を取得する（管理者のみ）
+// @Title Get Brewery Managers
+// @Description Get managers of the brewery (admin only)
+// @Param brewery_id path int true "Brewery ID"
+// @Success 200 {object} dto.BreweryManagersResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/managers [get]
+func (c *BreweryManagerController) GetManagers() {
+	if _, ok := c.RequireAdmin(); !ok {
+		return
+	}
+
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return
+	}
+
+	managers, err := c.breweryManagerUsecase.GetManagers(breweryID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.BreweryManagersResponse{
+		Managers: mapper.BreweryManagerEntitiesToResponses(managers),
+	}
+	c.JSONResponse(response)
+}
+
+// AssignManager ユーザーを醸造所の管理者に任命する（管理者のみ）
+// @Title Assign Brewery Manager
+// @Description Assign a user as manager of the brewery (admin only)
+// @Param brewery_id path int true "Brewery ID"
+// @Param body body dto.BreweryManagerRequest true "Manager data"
+// @Success 201 {object} dto.BreweryManagerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/managers [post]
+func (c *BreweryManagerController) AssignManager() {
+	cognitoSub, ok := c.RequireAdmin()
+	if !ok {
+		return
+	}
+
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return
+	}
+
+	var request dto.BreweryManagerRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+	if request.UserProfileID <= 0 {
+		c.HandleValidationError("user_profile_id", "User profile ID is required", "")
+		return
+	}
+
+	manager, err := c.breweryManagerUsecase.AssignManager(breweryID, request.UserProfileID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery manager assigned", map[string]interface{}{
+		"brewery_id":      breweryID,
+		"user_profile_id": request.UserProfileID,
+		"assigned_by":     cognitoSub,
+	})
+
+	response := mapper.BreweryManagerEntityToResponse(manager)
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(response, "Brewery manager assigned successfully")
+}
+
+// RevokeManager 醸造所の管理者任命を解除する（管理者のみ）
+// @Title Revoke Brewery Manager
+// @Description Revoke a manager of the brewery (admin only)
+// @Param brewery_id path int true "Brewery ID"
+// @Param user_profile_id path int true "User profile ID"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/managers/:user_profile_id [delete]
+func (c *BreweryManagerController) RevokeManager() {
+	cognitoSub, ok := c.RequireAdmin()
+	if !ok {
+		return
+	}
+
+	breweryID := c.GetIntPathParam("brewery_id")
+	userProfileID := c.GetIntPathParam("user_profile_id")
+	if breweryID <= 0 || userProfileID <= 0 {
+		c.ErrorResponse(http.StatusBadRequest, "Invalid brewery ID or user profile ID", dto.ErrorCodeInvalidParameter)
+		return
+	}
+
+	if err := c.breweryManagerUsecase.RevokeManager(breweryID, userProfileID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery manager revoked", map[string]interface{}{
+		"brewery_id":      breweryID,
+		"user_profile_id": userProfileID,
+		"revoked_by":      cognitoSub,
+	})
+
+	c.JSONResponseWithMessage(map[string]int{
+		"brewery_id":      breweryID,
+		"user_profile_id": userProfileID,
+	}, "Brewery manager revoked successfully")
+}
+
+// GetManagedBreweries 認証されたユーザーが管理する醸造所の一覧を取得する
+// @Title Get Managed Breweries
+// @Description Get breweries managed by the authenticated brewery manager
+// @Success 200 {object} dto.BreweriesResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /manager/breweries [get]
+func (c *BreweryManagerController) GetManagedBreweries() {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return
+	}
+
+	if !c.IsBreweryManager() && !c.IsAdmin() {
+		c.ErrorResponseDetailed(http.StatusForbidden, "Brewery manager access required", "", dto.ErrorCodeForbidden, nil)
+		return
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	breweries, err := c.breweryManagerUsecase.GetManagedBreweries(userProfile.ID())
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	total := len(breweries)
+	response := dto.BreweriesResponse{
+		Breweries: mapper.BreweryEntitiesToResponses(breweries),
+		Total:     &total,
+	}
+	c.JSONResponse(response)
+}
@@ -0,0 +1,319 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+)
+
+// BreweryPostController 醸造所の投稿に関するHTTPリクエストを処理するコントローラー
+type BreweryPostController struct {
+	BaseController
+	breweryPostUsecase    usecase.BreweryPostUsecase
+	breweryManagerUsecase usecase.BreweryManagerUsecase
+	userProfileUsecase    usecase.UserProfileUsecase
+}
+
+// NewBreweryPostController 新しい醸造所の投稿コントローラーを作成する
+func NewBreweryPostController() *BreweryPostController {
+	breweryPostRepo := repository.NewBreweryPostRepository()
+	breweryRepo := repository.NewBreweryRepository()
+	followRepo := repository.NewBreweryFollowRepository()
+	visitRepo := repository.NewVisitRepository()
+	breweryManagerRepo := repository.NewBreweryManagerRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &BreweryPostController{
+		breweryPostUsecase:    usecase.NewBreweryPostUsecase(breweryPostRepo, breweryRepo, followRepo, visitRepo),
+		breweryManagerUsecase: usecase.NewBreweryManagerUsecase(breweryManagerRepo, breweryRepo, userProfileRepo),
+		userProfileUsecase:    usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetPosts 醸造所の投稿一覧を取得する（閲覧者が見られる投稿のみ）
+// @Title Get Brewery Posts
+// @Description Get posts of the brewery visible to the viewer, pinned first then newest first. Managers also see scheduled and restricted posts
+// @Param brewery_id path int true "Brewery ID"
+// @Param limit query int false "Limit (default: 20, max: 100)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} dto.BreweryPostsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/posts [get]
+func (c *BreweryPostController) GetPosts() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+
+	viewer, ok := c.getPostViewer(breweryID)
+	if !ok {
+		return
+	}
+
+	limit, offset, ok := c.getLimitOffset()
+	if !ok {
+		return
+	}
+
+	posts, total, err := c.breweryPostUsecase.GetPosts(breweryID, viewer, limit, offset)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.BreweryPostsResponse{
+		Posts: mapper.BreweryPostEntitiesToResponses(posts),
+		Total: total,
+	}
+	c.JSONResponse(response)
+}
+
+// GetPost 醸造所の投稿を取得する
+// @Title Get Brewery Post
+// @Description Get a post of the brewery. Posts the viewer cannot see are reported as not found
+// @Param brewery_id path int true "Brewery ID"
+// @Param post_id path int true "Post ID"
+// @Success 200 {object} dto.BreweryPostResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/posts/:post_id [get]
+func (c *BreweryPostController) GetPost() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+	postID, ok := c.getPostIDPathParam()
+	if !ok {
+		return
+	}
+
+	viewer, ok := c.getPostViewer(breweryID)
+	if !ok {
+		return
+	}
+
+	post, err := c.breweryPostUsecase.GetPost(breweryID, postID, viewer)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.BreweryPostEntityToResponse(post))
+}
+
+// CreatePost 醸造所の投稿を作成する（管理者または担当の醸造所管理者のみ）
+// @Title Create Brewery Post
+// @Description Create a post of the brewery (admin or manager of the brewery). A future publish_at schedules the post
+// @Param brewery_id path int true "Brewery ID"
+// @Param body body dto.BreweryPostRequest true "Post data"
+// @Success 201 {object} dto.BreweryPostResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/posts [post]
+func (c *BreweryPostController) CreatePost() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(breweryID)
+	if !ok {
+		return
+	}
+
+	var request dto.BreweryPostRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	// プロファイルを作成していない管理者の場合は投稿者なしで作成する
+	authorUserProfileID := 0
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil && !errors.Is(err, domainerr.ErrUserProfileNotFound) {
+		c.HandleDomainError(err)
+		return
+	}
+	if userProfile != nil {
+		authorUserProfileID = userProfile.ID()
+	}
+
+	post, err := c.breweryPostUsecase.CreatePost(breweryID, authorUserProfileID, breweryPostInputFromRequest(&request))
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery post created", map[string]interface{}{
+		"post_id":     post.ID(),
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(mapper.BreweryPostEntityToResponse(post), "Post created successfully")
+}
+
+// UpdatePost 醸造所の投稿を更新する（管理者または担当の醸造所管理者のみ）
+// @Title Update Brewery Post
+// @Description Replace a post of the brewery (admin or manager of the brewery). publish_at is kept when omitted
+// @Param brewery_id path int true "Brewery ID"
+// @Param post_id path int true "Post ID"
+// @Param body body dto.BreweryPostRequest true "Post data"
+// @Success 200 {object} dto.BreweryPostResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/posts/:post_id [put]
+func (c *BreweryPostController) UpdatePost() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+	postID, ok := c.getPostIDPathParam()
+	if !ok {
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(breweryID)
+	if !ok {
+		return
+	}
+
+	var request dto.BreweryPostRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	post, err := c.breweryPostUsecase.UpdatePost(breweryID, postID, breweryPostInputFromRequest(&request))
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery post updated", map[string]interface{}{
+		"post_id":     postID,
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.JSONResponseWithMessage(mapper.BreweryPostEntityToResponse(post), "Post updated successfully")
+}
+
+// DeletePost 醸造所の投稿を削除する（管理者または担当の醸造所管理者のみ）
+// @Title Delete Brewery Post
+// @Description Delete a post of the brewery (admin or manager of the brewery)
+// @Param brewery_id path int true "Brewery ID"
+// @Param post_id path int true "Post ID"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/posts/:post_id [delete]
+func (c *BreweryPostController) DeletePost() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+	postID, ok := c.getPostIDPathParam()
+	if !ok {
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(breweryID)
+	if !ok {
+		return
+	}
+
+	if err := c.breweryPostUsecase.DeletePost(breweryID, postID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery post deleted", map[string]interface{}{
+		"post_id":     postID,
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.JSONResponseWithMessage(map[string]int{"post_id": postID}, "Post deleted successfully")
+}
+
+// getPostViewer 閲覧者の情報を取得する（未ログインの場合はゲストとして扱い、失敗時はエラーレスポンスを返す）
+func (c *BreweryPostController) getPostViewer(breweryID int) (usecase.PostViewer, bool) {
+	viewer := usecase.PostViewer{}
+
+	cognitoSub, err := c.GetCognitoSub()
+	if err != nil || cognitoSub == "" {
+		return viewer, true
+	}
+	if c.IsAdmin() {
+		viewer.CanManage = true
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if errors.Is(err, domainerr.ErrUserProfileNotFound) {
+		return viewer, true
+	}
+	if err != nil {
+		c.HandleDomainError(err)
+		return viewer, false
+	}
+	viewer.UserProfileID = userProfile.ID()
+
+	if !viewer.CanManage && c.IsBreweryManager() {
+		isManager, err := c.breweryManagerUsecase.IsManager(userProfile.ID(), breweryID)
+		if err != nil {
+			c.HandleInternalError(err)
+			return viewer, false
+		}
+		viewer.CanManage = isManager
+	}
+
+	return viewer, true
+}
+
+// getBreweryIDPathParam パスパラメータから醸造所IDを取得する
+func (c *BreweryPostController) getBreweryIDPathParam() (int, bool) {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return 0, false
+	}
+	return breweryID, true
+}
+
+// getPostIDPathParam パスパラメータから投稿IDを取得する
+func (c *BreweryPostController) getPostIDPathParam() (int, bool) {
+	postID := c.GetIntPathParam("post_id")
+	if postID <= 0 {
+		c.HandleValidationError("post_id", "Invalid post ID", c.Ctx.Input.Param(":post_id"))
+		return 0, false
+	}
+	return postID, true
+}
+
+// breweryPostInputFromRequest リクエストをユースケースの入力に変換する
+func breweryPostInputFromRequest(request *dto.BreweryPostRequest) usecase.BreweryPostInput {
+	return usecase.BreweryPostInput{
+		Title:      request.Title,
+		Body:       request.Body,
+		ImageURLs:  request.ImageURLs,
+		PublishAt:  request.PublishAt,
+		IsPinned:   request.IsPinned,
+		Visibility: request.Visibility,
+		MinFanRank: request.MinFanRank,
+	}
+}
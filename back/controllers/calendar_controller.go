@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+
+	"github.com/astaxie/beego"
+)
+
+// CalendarController 訪問履歴のカレンダー購読に関するHTTPリクエストを処理するコントローラー
+type CalendarController struct {
+	BaseController
+	calendarUsecase    usecase.CalendarUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewCalendarController 新しいカレンダー購読コントローラーを作成する
+func NewCalendarController() *CalendarController {
+	calendarFeedRepo := repository.NewCalendarFeedRepository()
+	visitRepo := repository.NewVisitRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &CalendarController{
+		calendarUsecase:    usecase.NewCalendarUsecase(calendarFeedRepo, visitRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetFeed 認証されたユーザーのカレンダー購読設定を取得する（トークンは発行時のみ返す）
+// @Title Get Calendar Feed
+// @Description Get whether the authenticated user's visit calendar feed is enabled. The token itself is only returned when it is rotated
+// @Success 200 {object} dto.CalendarFeedResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/calendar [get]
+func (c *CalendarController) GetFeed() {
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	feed, err := c.calendarUsecase.GetFeed(userProfileID)
+	if err != nil && !errors.Is(err, domainerr.ErrCalendarFeedNotFound) {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.CalendarFeedEntityToResponse(feed, ""))
+}
+
+// RotateToken 購読URLのトークンを発行し直す（以前のURLは無効になる）
+// @Title Rotate Calendar Token
+// @Description Issue a new secret token for the visit calendar feed. Any previously issued feed URL stops working
+// @Success 201 {object} dto.CalendarFeedResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/calendar/token [post]
+func (c *CalendarController) RotateToken() {
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	feed, token, err := c.calendarUsecase.RotateToken(userProfileID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Calendar feed token rotated", map[string]interface{}{
+		"user_profile_id": userProfileID,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(mapper.CalendarFeedEntityToResponse(feed, token), "Calendar feed token issued")
+}
+
+// RevokeToken 購読URLを無効にする
+// @Title Revoke Calendar Token
+// @Description Disable the visit calendar feed. The feed URL stops working
+// @Success 200 {object} dto.CalendarFeedResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/calendar/token [delete]
+func (c *CalendarController) RevokeToken() {
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	if err := c.calendarUsecase.RevokeToken(userProfileID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponseWithMessage(mapper.CalendarFeedEntityToResponse(nil, ""), "Calendar feed disabled")
+}
+
+// GetCalendar 購読URLのトークンに対応するユーザーの訪問履歴を iCalendar 形式で返す
+// カレンダーアプリから認証ヘッダーなしで取得できるよう、トークン自体を認証情報として扱う
+// @Title Get Visit Calendar
+// @Description Get the visit history as iCalendar (RFC 5545) data, one VEVENT per visit. Authenticated by the secret token in the URL
+// @Param token path string true "Calendar feed token"
+// @Success 200 {string} string "text/calendar"
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/calendar/:token.ics [get]
+func (c *CalendarController) GetCalendar() {
+	token := c.Ctx.Input.Param(":token")
+	maxEvents := beego.AppConfig.DefaultInt("calendar.max_events", 1000)
+
+	visits, err := c.calendarUsecase.GetVisitsByToken(token, maxEvents)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.Ctx.Output.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Ctx.Output.Header("Content-Disposition", `inline; filename="mybeerlog-visits.ics"`)
+	c.Ctx.Output.Header("Cache-Control", "private, max-age=300")
+	c.Ctx.Output.Body([]byte(mapper.VisitsToICalendar(visits, "MyBeerLog 訪問履歴")))
+}
+
+// requireUserProfileID 認証済みユーザーのプロファイルIDを取得する（失敗時はエラーレスポンスを返す）
+func (c *CalendarController) requireUserProfileID() (int, bool) {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return 0, false
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return 0, false
+	}
+
+	return userProfile.ID(), true
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+// CheckinCodeController 醸造所に掲示するQRチェックイン用コードに関するHTTPリクエストを処理するコントローラー
+type CheckinCodeController struct {
+	BaseController
+	checkinCodeUsecase usecase.CheckinCodeUsecase
+}
+
+// NewCheckinCodeController 新しいQRチェックイン用コードのコントローラーを作成する
+func NewCheckinCodeController() *CheckinCodeController {
+	checkinSecretRepo := repository.NewCheckinSecretRepository()
+	breweryRepo := repository.NewBreweryRepository()
+
+	return &CheckinCodeController{
+		checkinCodeUsecase: usecase.NewCheckinCodeUsecase(checkinSecretRepo, breweryRepo),
+	}
+}
+
+// GetCode 醸造所に掲示する現在のQRチェックイン用コードを取得する（醸造所管理者・PF管理者のみ）
+// コードは一定時間ごとに切り替わるため、表示側は expires_at を過ぎたら再取得する
+// @Title Get Check-in Code
+// @Description Get the current rotating QR check-in code of a brewery. Render the token as a QR code and fetch again after expires_at
+// @Param brewery_id path int true "Brewery ID"
+// @Success 200 {object} dto.CheckinCodeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/checkin-code [get]
+func (c *CheckinCodeController) GetCode() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+
+	if _, ok := c.RequireBreweryManager(breweryID); !ok {
+		return
+	}
+
+	code, err := c.checkinCodeUsecase.GetCurrentCode(breweryID, checkinCodePeriod())
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	// 表示中のコードがキャッシュから返されないようにする
+	c.Ctx.Output.Header("Cache-Control", "no-store")
+	c.JSONResponse(mapper.CheckinCodeEntityToResponse(code))
+}
+
+// RotateSecret QRチェックイン用のシークレットを再発行する（醸造所管理者・PF管理者のみ）
+// 以前のシークレットから算出したコードは有効期間内でも無効になる
+// @Title Rotate Check-in Secret
+// @Description Issue a new check-in secret for a brewery. Codes derived from the previous secret stop working immediately
+// @Param brewery_id path int true "Brewery ID"
+// @Success 201 {object} dto.CheckinCodeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/checkin-code/rotate [post]
+func (c *CheckinCodeController) RotateSecret() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(breweryID)
+	if !ok {
+		return
+	}
+
+	code, err := c.checkinCodeUsecase.RotateSecret(breweryID, checkinCodePeriod())
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Check-in secret rotated", map[string]interface{}{
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.Ctx.Output.Header("Cache-Control", "no-store")
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(mapper.CheckinCodeEntityToResponse(code), "Check-in secret rotated")
+}
+
+// getBreweryIDPathParam パスパラメータから醸造所IDを取得する
+func (c *CheckinCodeController) getBreweryIDPathParam() (int, bool) {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return 0, false
+	}
+	return breweryID, true
+}
+
+// checkinCodePeriod 設定からQRチェックイン用コードの切り替え間隔を取得する
+func checkinCodePeriod() time.Duration {
+	seconds := beego.AppConfig.DefaultInt("checkin.qr_period_seconds", 60)
+	if seconds <= 0 {
+		seconds = 60
+	}
+	return time.Duration(seconds) * time.Second
+}
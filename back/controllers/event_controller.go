@@ -0,0 +1,429 @@
+package controllers
+
+import (
+	"encoding/json"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+// EventController 醸造所のイベントに関するHTTPリクエストを処理するコントローラー
+type EventController struct {
+	BaseController
+	eventUsecase       usecase.EventUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewEventController 新しいイベントコントローラーを作成する
+func NewEventController() *EventController {
+	eventRepo := repository.NewEventRepository()
+	rsvpRepo := repository.NewEventRSVPRepository()
+	breweryRepo := repository.NewBreweryRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &EventController{
+		eventUsecase:       usecase.NewEventUsecase(eventRepo, rsvpRepo, breweryRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetBreweryEvents 醸造所の開催予定・開催中のイベント一覧を取得する
+// @Title Get Brewery Events
+// @Description Get upcoming and ongoing events of the brewery, earliest first
+// @Param brewery_id path int true "Brewery ID"
+// @Param limit query int false "Limit (default: 20, max: 100)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} dto.EventsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/events [get]
+func (c *EventController) GetBreweryEvents() {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return
+	}
+
+	limit, offset, ok := c.getLimitOffset()
+	if !ok {
+		return
+	}
+
+	events, total, err := c.eventUsecase.GetBreweryEvents(breweryID, limit, offset)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.EventsResponse{
+		Events: mapper.EventEntitiesToResponses(events),
+		Total:  total,
+	}
+	c.JSONResponse(response)
+}
+
+// CreateEvent 醸造所のイベントを登録する（管理者または担当の醸造所管理者のみ）
+// @Title Create Event
+// @Description Register an event of the brewery (admin or manager of the brewery)
+// @Param brewery_id path int true "Brewery ID"
+// @Param body body dto.EventRequest true "Event data"
+// @Success 201 {object} dto.EventResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/events [post]
+func (c *EventController) CreateEvent() {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(breweryID)
+	if !ok {
+		return
+	}
+
+	var request dto.EventRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	event, err := c.eventUsecase.CreateEvent(breweryID, eventInputFromRequest(&request))
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Event created", map[string]interface{}{
+		"event_id":    event.ID(),
+		"brewery_id":  breweryID,
+		"cognito_sub": cognitoSub,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(mapper.EventEntityToResponse(event), "Event created successfully")
+}
+
+// GetEvent IDでイベントを取得する
+// @Title Get Event
+// @Description Get event by ID with RSVP counts
+// @Param event_id path int true "Event ID"
+// @Success 200 {object} dto.EventResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /events/:event_id [get]
+func (c *EventController) GetEvent() {
+	eventID, ok := c.getEventIDPathParam()
+	if !ok {
+		return
+	}
+
+	event, err := c.eventUsecase.GetEvent(eventID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.EventEntityToResponse(event))
+}
+
+// UpdateEvent イベント情報を更新する（管理者または担当の醸造所管理者のみ）
+// @Title Update Event
+// @Description Replace event data (admin or manager of the brewery). Raising the capacity promotes waitlisted RSVPs
+// @Param event_id path int true "Event ID"
+// @Param body body dto.EventRequest true "Event data"
+// @Success 200 {object} dto.EventResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /events/:event_id [put]
+func (c *EventController) UpdateEvent() {
+	eventID, ok := c.getEventIDPathParam()
+	if !ok {
+		return
+	}
+
+	event, err := c.eventUsecase.GetEvent(eventID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(event.BreweryID())
+	if !ok {
+		return
+	}
+
+	var request dto.EventRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	updatedEvent, promoted, err := c.eventUsecase.UpdateEvent(eventID, eventInputFromRequest(&request))
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Event updated", map[string]interface{}{
+		"event_id":          eventID,
+		"cognito_sub":       cognitoSub,
+		"promoted_profiles": promoted,
+	})
+
+	c.JSONResponseWithMessage(mapper.EventEntityToResponse(updatedEvent), "Event updated successfully")
+}
+
+// DeleteEvent イベントを削除する（管理者または担当の醸造所管理者のみ）
+// @Title Delete Event
+// @Description Delete event and its RSVPs (admin or manager of the brewery)
+// @Param event_id path int true "Event ID"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /events/:event_id [delete]
+func (c *EventController) DeleteEvent() {
+	eventID, ok := c.getEventIDPathParam()
+	if !ok {
+		return
+	}
+
+	event, err := c.eventUsecase.GetEvent(eventID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	cognitoSub, ok := c.RequireBreweryManager(event.BreweryID())
+	if !ok {
+		return
+	}
+
+	if err := c.eventUsecase.DeleteEvent(eventID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Event deleted", map[string]interface{}{
+		"event_id":    eventID,
+		"brewery_id":  event.BreweryID(),
+		"cognito_sub": cognitoSub,
+	})
+
+	c.JSONResponseWithMessage(map[string]int{"event_id": eventID}, "Event deleted successfully")
+}
+
+// GetMyRSVP 認証されたユーザーのイベントへの参加申込を取得する
+// @Title Get My RSVP
+// @Description Get the authenticated user's RSVP to the event, including the waitlist position
+// @Param event_id path int true "Event ID"
+// @Success 200 {object} dto.EventRSVPResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /events/:event_id/rsvp [get]
+func (c *EventController) GetMyRSVP() {
+	eventID, ok := c.getEventIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	rsvp, err := c.eventUsecase.GetRSVP(eventID, userProfileID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.EventRSVPEntityToResponse(rsvp))
+}
+
+// RSVP イベントに参加を申し込む（満席の場合はキャンセル待ち、申込済みの場合は 200 を返す）
+// @Title RSVP to Event
+// @Description RSVP to the event. When the event is full the RSVP is waitlisted
+// @Param event_id path int true "Event ID"
+// @Success 201 {object} dto.EventRSVPResponse
+// @Success 200 {object} dto.EventRSVPResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /events/:event_id/rsvp [post]
+func (c *EventController) RSVP() {
+	eventID, ok := c.getEventIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	rsvp, created, err := c.eventUsecase.RSVP(eventID, userProfileID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := mapper.EventRSVPEntityToResponse(rsvp)
+	if !created {
+		c.JSONResponseWithMessage(response, "Already RSVPed to this event")
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Event RSVP created", map[string]interface{}{
+		"event_id":        eventID,
+		"user_profile_id": userProfileID,
+		"status":          rsvp.Status(),
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(response, "RSVP accepted")
+}
+
+// CancelRSVP イベントへの参加申込を取り消す（参加確定の取り消しではキャンセル待ちが繰り上がる）
+// @Title Cancel RSVP
+// @Description Cancel the RSVP to the event. The earliest waitlisted RSVP takes the freed seat
+// @Param event_id path int true "Event ID"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /events/:event_id/rsvp [delete]
+func (c *EventController) CancelRSVP() {
+	eventID, ok := c.getEventIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	promoted, err := c.eventUsecase.CancelRSVP(eventID, userProfileID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Event RSVP cancelled", map[string]interface{}{
+		"event_id":          eventID,
+		"user_profile_id":   userProfileID,
+		"promoted_profiles": promoted,
+	})
+
+	c.JSONResponseWithMessage(map[string]int{"event_id": eventID}, "RSVP cancelled")
+}
+
+// CheckIn 開催中のイベントに GPS でチェックインし、参加を確認する
+// @Title Check In to Event
+// @Description Verify attendance with a GPS check-in at the brewery during the event (confirmed RSVPs only)
+// @Param event_id path int true "Event ID"
+// @Param body body dto.EventCheckinRequest true "Location"
+// @Success 200 {object} dto.EventRSVPResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /events/:event_id/checkin [post]
+func (c *EventController) CheckIn() {
+	eventID, ok := c.getEventIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	var request dto.EventCheckinRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	// 醸造所へのチェックインと同じ許可範囲・GPS精度の設定を使用する
+	maxDistance, err := beego.AppConfig.Float("gps.checkin_radius")
+	if err != nil || maxDistance == 0 {
+		maxDistance = 100.0 // デフォルト100m
+	}
+
+	rsvp, err := c.eventUsecase.CheckIn(usecase.EventCheckInInput{
+		EventID:       eventID,
+		UserProfileID: userProfileID,
+		Latitude:      request.Latitude,
+		Longitude:     request.Longitude,
+		AccuracyM:     request.AccuracyM,
+
+		MaxDistance:     maxDistance,
+		MaxAccuracyM:    beego.AppConfig.DefaultFloat("gps.max_accuracy", 50.0),
+		RequireAccuracy: beego.AppConfig.DefaultBool("gps.require_accuracy", false),
+		EarlyCheckin:    time.Duration(beego.AppConfig.DefaultInt("event.early_checkin_minutes", 30)) * time.Minute,
+	})
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponseWithMessage(mapper.EventRSVPEntityToResponse(rsvp), "Attendance confirmed")
+}
+
+// requireUserProfileID 認証済みユーザーのプロファイルIDを取得する（失敗時はエラーレスポンスを返す）
+func (c *EventController) requireUserProfileID() (int, bool) {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return 0, false
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return 0, false
+	}
+
+	return userProfile.ID(), true
+}
+
+// getEventIDPathParam パスパラメータからイベントIDを取得する
+func (c *EventController) getEventIDPathParam() (int, bool) {
+	eventID := c.GetIntPathParam("event_id")
+	if eventID <= 0 {
+		c.HandleValidationError("event_id", "Invalid event ID", c.Ctx.Input.Param(":event_id"))
+		return 0, false
+	}
+	return eventID, true
+}
+
+// eventInputFromRequest リクエストをユースケースの入力に変換する
+func eventInputFromRequest(request *dto.EventRequest) usecase.EventInput {
+	return usecase.EventInput{
+		Title:       request.Title,
+		Description: request.Description,
+		Location:    request.Location,
+		StartAt:     request.StartAt,
+		EndAt:       request.EndAt,
+		TimeZone:    request.TimeZone,
+		Capacity:    request.Capacity,
+	}
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+)
+
+// FeedController フィードに関するHTTPリクエストを処理するコントローラー
+type FeedController struct {
+	BaseController
+	feedUsecase        usecase.FeedUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewFeedController 新しいフィードコントローラーを作成する
+func NewFeedController() *FeedController {
+	activityRepo := repository.NewBreweryActivityRepository()
+	breweryRepo := repository.NewBreweryRepository()
+	visitRepo := repository.NewVisitRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &FeedController{
+		feedUsecase:        usecase.NewFeedUsecase(activityRepo, breweryRepo, visitRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetFeed フォローしている醸造所のアクティビティを新しい順に取得する
+// @Title Get Feed
+// @Description Get activity of followed breweries, newest first. Pass next_cursor of the previous page as cursor to get older items
+// @Param cursor query string false "Cursor returned as next_cursor"
+// @Param limit query int false "Limit (default: 20, max: 100)"
+// @Success 200 {object} dto.FeedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /feed [get]
+func (c *FeedController) GetFeed() {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	limit, ok := c.GetIntQuery("limit", 20)
+	if !ok {
+		return
+	}
+
+	page, err := c.feedUsecase.GetFeed(userProfile.ID(), c.GetString("cursor"), limit)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.FeedResponse{
+		Items:      mapper.BreweryActivityEntitiesToResponses(page.Activities),
+		NextCursor: page.NextCursor,
+	}
+	c.JSONResponse(response)
+}
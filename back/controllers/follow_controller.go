@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+)
+
+// FollowController 醸造所のフォローに関するHTTPリクエストを処理するコントローラー
+type FollowController struct {
+	BaseController
+	followUsecase      usecase.FollowUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewFollowController 新しいフォローコントローラーを作成する
+func NewFollowController() *FollowController {
+	followRepo := repository.NewBreweryFollowRepository()
+	breweryRepo := repository.NewBreweryRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &FollowController{
+		followUsecase:      usecase.NewFollowUsecase(followRepo, breweryRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetFollowedBreweries 認証されたユーザーがフォローしている醸造所の一覧を取得する
+// @Title Get Followed Breweries
+// @Description Get breweries followed by the authenticated user, most recently followed first
+// @Param limit query int false "Limit (default: 20, max: 100)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} dto.BreweriesResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/follows [get]
+func (c *FollowController) GetFollowedBreweries() {
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	limit, offset, ok := c.getLimitOffset()
+	if !ok {
+		return
+	}
+
+	breweries, total, err := c.followUsecase.GetFollowedBreweries(userProfileID, limit, offset)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.BreweriesResponse{
+		Breweries: mapper.BreweryEntitiesToResponses(breweries),
+		Total:     &total,
+	}
+	c.JSONResponse(response)
+}
+
+// FollowBrewery 醸造所をフォローする（フォロー済みの場合は 200 を返す）
+// @Title Follow Brewery
+// @Description Follow a brewery to see its activity in the feed
+// @Param brewery_id path int true "Brewery ID"
+// @Success 201 {object} dto.BreweryFollowResponse
+// @Success 200 {object} dto.BreweryFollowResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/follow [post]
+func (c *FollowController) FollowBrewery() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	created, err := c.followUsecase.FollowBrewery(userProfileID, breweryID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.BreweryFollowResponse{
+		BreweryID: breweryID,
+		Following: true,
+	}
+	if !created {
+		c.JSONResponseWithMessage(response, "Already following this brewery")
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery followed", map[string]interface{}{
+		"brewery_id":      breweryID,
+		"user_profile_id": userProfileID,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(response, "Brewery followed")
+}
+
+// UnfollowBrewery 醸造所のフォローを解除する
+// @Title Unfollow Brewery
+// @Description Stop following a brewery
+// @Param brewery_id path int true "Brewery ID"
+// @Success 200 {object} dto.BreweryFollowResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /breweries/:brewery_id/follow [delete]
+func (c *FollowController) UnfollowBrewery() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	if err := c.followUsecase.UnfollowBrewery(userProfileID, breweryID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.BreweryFollowResponse{
+		BreweryID: breweryID,
+		Following: false,
+	}
+	c.JSONResponseWithMessage(response, "Brewery unfollowed")
+}
+
+// requireUserProfileID 認証済みユーザーのプロファイルIDを取得する（失敗時はエラーレスポンスを返す）
+func (c *FollowController) requireUserProfileID() (int, bool) {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return 0, false
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return 0, false
+	}
+
+	return userProfile.ID(), true
+}
+
+// getBreweryIDPathParam パスパラメータから醸造所IDを取得する
+func (c *FollowController) getBreweryIDPathParam() (int, bool) {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return 0, false
+	}
+	return breweryID, true
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"errors"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/repository"
+	"mybeerlog/utils"
+	"strconv"
+)
+
+// getPageQuery クエリパラメータ（limit / offset / cursor / include_total）からページ指定を組み立てる
+// cursor を指定した場合は offset より優先する。include_total を省略した場合、総数は cursor を指定していない場合のみ返す
+// scope はカーソルを発行した一覧を表し、他の一覧・ユーザーのカーソルは不正なカーソルとして扱う
+func (c *BaseController) getPageQuery(scope string) (repository.PageQuery, bool) {
+	var page repository.PageQuery
+	var ok bool
+	if page.Limit, page.Offset, ok = c.getLimitOffset(); !ok {
+		return page, false
+	}
+
+	cursorValue := c.GetString("cursor")
+	if cursorValue != "" {
+		cursor, err := utils.DecodePageCursor(cursorValue, scope)
+		if errors.Is(err, utils.ErrInvalidCursor) {
+			c.HandleDomainError(domainerr.ErrInvalidCursor)
+			return page, false
+		}
+		if err != nil {
+			c.HandleInternalError(err)
+			return page, false
+		}
+		key := &repository.PageKey{Time: cursor.At(), ID: cursor.ID}
+		if cursor.Backward {
+			page.Before = key
+		} else {
+			page.After = key
+		}
+	}
+
+	page.IncludeTotal = cursorValue == ""
+	if value := c.GetString("include_total"); value != "" {
+		includeTotal, err := strconv.ParseBool(value)
+		if err != nil {
+			c.HandleValidationError("include_total", "Invalid boolean value", value)
+			return page, false
+		}
+		page.IncludeTotal = includeTotal
+	}
+
+	return page, true
+}
+
+// getLimitOffset クエリパラメータ（limit / offset）からオフセットによるページ指定を取得する
+// 不正な値の場合はバリデーションエラーのレスポンスを書き込み、false を返す
+func (c *BaseController) getLimitOffset() (int, int, bool) {
+	limit, ok := c.GetIntQuery("limit", 20)
+	if !ok {
+		return 0, 0, false
+	}
+	offset, ok := c.GetIntQuery("offset", 0)
+	if !ok {
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
+
+// pageCursors 取得したページの先頭・末尾の行から、前・次のページのカーソルを作成する（ページがない場合は空）
+func pageCursors(scope string, result *repository.PageResult, first, last *repository.PageKey) (string, string, error) {
+	if result == nil || first == nil || last == nil {
+		return "", "", nil
+	}
+
+	var nextCursor, prevCursor string
+	var err error
+	if result.HasNext {
+		if nextCursor, err = utils.EncodePageCursor(utils.NewPageCursor(scope, false, last.Time, last.ID)); err != nil {
+			return "", "", err
+		}
+	}
+	if result.HasPrev {
+		if prevCursor, err = utils.EncodePageCursor(utils.NewPageCursor(scope, true, first.Time, first.ID)); err != nil {
+			return "", "", err
+		}
+	}
+	return nextCursor, prevCursor, nil
+}
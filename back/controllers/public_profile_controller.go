@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/mapper"
+	"time"
+)
+
+// PublicProfileController 公開プロフィールに関するHTTPリクエストを処理するコントローラー
+type PublicProfileController struct {
+	BaseController
+	publicProfileUsecase usecase.PublicProfileUsecase
+}
+
+// NewPublicProfileController 新しい公開プロフィールのコントローラーを作成する
+func NewPublicProfileController() *PublicProfileController {
+	userProfileRepo := repository.NewUserProfileRepository()
+	visitRepo := repository.NewVisitRepository()
+
+	return &PublicProfileController{
+		publicProfileUsecase: usecase.NewPublicProfileUsecase(userProfileRepo, visitRepo),
+	}
+}
+
+// GetPublicProfile ハンドルで公開プロフィールを取得する（認証不要）
+// 非公開のプロフィールは本人以外には 404 を返す。本人は非公開でも他のユーザーからの見え方を確認できる
+// @Title Get Public Profile
+// @Description Get a user's public profile by handle, filtered by the user's privacy settings
+// @Param handle path string true "Profile handle"
+// @Success 200 {object} dto.PublicProfileResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/:handle [get]
+func (c *PublicProfileController) GetPublicProfile() {
+	// 認証は任意（トークンが無い・無効な場合は未認証の閲覧者として扱う）
+	viewerCognitoSub, err := c.GetCognitoSub()
+	if err != nil {
+		viewerCognitoSub = ""
+	}
+
+	profile, err := c.publicProfileUsecase.GetPublicProfile(c.Ctx.Input.Param(":handle"), viewerCognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.PublicProfileEntityToResponse(profile, time.Now()))
+}
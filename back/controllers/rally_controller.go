@@ -0,0 +1,377 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+)
+
+// RallyController スタンプラリーに関するHTTPリクエストを処理するコントローラー
+type RallyController struct {
+	BaseController
+	rallyUsecase       usecase.RallyUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewRallyController 新しいスタンプラリーコントローラーを作成する
+func NewRallyController() *RallyController {
+	rallyRepo := repository.NewRallyRepository()
+	participantRepo := repository.NewRallyParticipantRepository()
+	breweryRepo := repository.NewBreweryRepository()
+	breweryManagerRepo := repository.NewBreweryManagerRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &RallyController{
+		rallyUsecase:       usecase.NewRallyUsecase(rallyRepo, participantRepo, breweryRepo, breweryManagerRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetRallies 開催予定・開催中のスタンプラリー一覧を取得する
+// @Title Get Rallies
+// @Description Get upcoming and ongoing stamp rallies, earliest first
+// @Param limit query int false "Limit (default: 20, max: 100)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} dto.RalliesResponse
+// @router /rallies [get]
+func (c *RallyController) GetRallies() {
+	limit, offset, ok := c.getLimitOffset()
+	if !ok {
+		return
+	}
+
+	rallies, total, err := c.rallyUsecase.GetOpenRallies(limit, offset)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.RalliesResponse{
+		Rallies: mapper.RallyEntitiesToResponses(rallies),
+		Total:   total,
+	}
+	c.JSONResponse(response)
+}
+
+// CreateRally スタンプラリーを登録する（管理者、または対象の醸造所を全て管理する醸造所管理者のみ）
+// @Title Create Rally
+// @Description Register a stamp rally across breweries (admin, or a manager of every brewery in the rally)
+// @Param body body dto.RallyRequest true "Rally data"
+// @Success 201 {object} dto.RallyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /rallies [post]
+func (c *RallyController) CreateRally() {
+	organizer, ok := c.requireOrganizer()
+	if !ok {
+		return
+	}
+
+	var request dto.RallyRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	rally, err := c.rallyUsecase.CreateRally(organizer, rallyInputFromRequest(&request))
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Rally created", map[string]interface{}{
+		"rally_id":        rally.ID(),
+		"brewery_ids":     rally.BreweryIDs(),
+		"user_profile_id": organizer.UserProfileID,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(mapper.RallyEntityToResponse(rally), "Rally created successfully")
+}
+
+// GetRally IDでスタンプラリーを取得する
+// @Title Get Rally
+// @Description Get stamp rally by ID with its breweries
+// @Param rally_id path int true "Rally ID"
+// @Success 200 {object} dto.RallyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /rallies/:rally_id [get]
+func (c *RallyController) GetRally() {
+	rallyID, ok := c.getRallyIDPathParam()
+	if !ok {
+		return
+	}
+
+	rally, err := c.rallyUsecase.GetRally(rallyID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.RallyEntityToResponse(rally))
+}
+
+// UpdateRally スタンプラリーを更新する（管理者、または変更前後の対象醸造所を全て管理する醸造所管理者のみ）
+// @Title Update Rally
+// @Description Replace stamp rally data. Participants who already meet a lowered requirement complete the rally immediately
+// @Param rally_id path int true "Rally ID"
+// @Param body body dto.RallyRequest true "Rally data"
+// @Success 200 {object} dto.RallyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /rallies/:rally_id [put]
+func (c *RallyController) UpdateRally() {
+	rallyID, ok := c.getRallyIDPathParam()
+	if !ok {
+		return
+	}
+
+	organizer, ok := c.requireOrganizer()
+	if !ok {
+		return
+	}
+
+	var request dto.RallyRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	rally, err := c.rallyUsecase.UpdateRally(organizer, rallyID, rallyInputFromRequest(&request))
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Rally updated", map[string]interface{}{
+		"rally_id":        rallyID,
+		"user_profile_id": organizer.UserProfileID,
+	})
+
+	c.JSONResponseWithMessage(mapper.RallyEntityToResponse(rally), "Rally updated successfully")
+}
+
+// DeleteRally スタンプラリーを削除する（管理者、または対象の醸造所を全て管理する醸造所管理者のみ）
+// @Title Delete Rally
+// @Description Delete stamp rally with its participants and stamps
+// @Param rally_id path int true "Rally ID"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /rallies/:rally_id [delete]
+func (c *RallyController) DeleteRally() {
+	rallyID, ok := c.getRallyIDPathParam()
+	if !ok {
+		return
+	}
+
+	organizer, ok := c.requireOrganizer()
+	if !ok {
+		return
+	}
+
+	if err := c.rallyUsecase.DeleteRally(organizer, rallyID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Rally deleted", map[string]interface{}{
+		"rally_id":        rallyID,
+		"user_profile_id": organizer.UserProfileID,
+	})
+
+	c.JSONResponseWithMessage(map[string]int{"rally_id": rallyID}, "Rally deleted successfully")
+}
+
+// JoinRally スタンプラリーに参加する（参加済みの場合は 200 を返す）
+// @Title Join Rally
+// @Description Join the stamp rally. Check-ins at its breweries during the rally are stamped automatically from now on
+// @Param rally_id path int true "Rally ID"
+// @Success 201 {object} dto.RallyProgressResponse
+// @Success 200 {object} dto.RallyProgressResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /rallies/:rally_id/join [post]
+func (c *RallyController) JoinRally() {
+	rallyID, ok := c.getRallyIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	participant, created, err := c.rallyUsecase.JoinRally(rallyID, userProfileID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := mapper.RallyParticipantEntityToProgressResponse(participant)
+	if !created {
+		c.JSONResponseWithMessage(response, "Already joined this rally")
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Rally joined", map[string]interface{}{
+		"rally_id":        rallyID,
+		"user_profile_id": userProfileID,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(response, "Joined the rally")
+}
+
+// GetProgress 認証されたユーザーのスタンプラリーの進捗を取得する
+// @Title Get Rally Progress
+// @Description Get the authenticated user's stamps, completion and reward code for the rally
+// @Param rally_id path int true "Rally ID"
+// @Success 200 {object} dto.RallyProgressResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /rallies/:rally_id/progress [get]
+func (c *RallyController) GetProgress() {
+	rallyID, ok := c.getRallyIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	participant, err := c.rallyUsecase.GetProgress(rallyID, userProfileID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.RallyParticipantEntityToProgressResponse(participant))
+}
+
+// RedeemReward 達成特典の引換コードを引き換え済みにする（管理者、または対象の醸造所のいずれかを管理する醸造所管理者のみ）
+// @Title Redeem Rally Reward
+// @Description Mark the completion reward as redeemed by its reward code (admin, or a manager of any brewery in the rally)
+// @Param rally_id path int true "Rally ID"
+// @Param body body dto.RallyRewardRedeemRequest true "Reward code"
+// @Success 200 {object} dto.RallyProgressResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @router /rallies/:rally_id/rewards/redeem [post]
+func (c *RallyController) RedeemReward() {
+	rallyID, ok := c.getRallyIDPathParam()
+	if !ok {
+		return
+	}
+
+	organizer, ok := c.requireOrganizer()
+	if !ok {
+		return
+	}
+
+	var request dto.RallyRewardRedeemRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	participant, err := c.rallyUsecase.RedeemReward(organizer, rallyID, request.RewardCode)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Rally reward redeemed", map[string]interface{}{
+		"rally_id":       rallyID,
+		"participant_id": participant.UserProfileID(),
+		"redeemed_by":    organizer.UserProfileID,
+	})
+
+	c.JSONResponseWithMessage(mapper.RallyParticipantEntityToProgressResponse(participant), "Reward redeemed")
+}
+
+// requireOrganizer 認証済みユーザーをスタンプラリーの編集者として取得する（失敗時はエラーレスポンスを返す）
+// 対象の醸造所を管理しているかどうかはユースケースで確認する。PF管理者はプロファイルが無くてもよい
+func (c *RallyController) requireOrganizer() (usecase.RallyOrganizer, bool) {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return usecase.RallyOrganizer{}, false
+	}
+
+	organizer := usecase.RallyOrganizer{
+		IsAdmin:          c.IsAdmin(),
+		IsBreweryManager: c.IsBreweryManager(),
+	}
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		if !organizer.IsAdmin || !errors.Is(err, domainerr.ErrUserProfileNotFound) {
+			c.HandleDomainError(err)
+			return usecase.RallyOrganizer{}, false
+		}
+	} else {
+		organizer.UserProfileID = userProfile.ID()
+	}
+
+	return organizer, true
+}
+
+// requireUserProfileID 認証済みユーザーのプロファイルIDを取得する（失敗時はエラーレスポンスを返す）
+func (c *RallyController) requireUserProfileID() (int, bool) {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return 0, false
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return 0, false
+	}
+
+	return userProfile.ID(), true
+}
+
+// getRallyIDPathParam パスパラメータからスタンプラリーIDを取得する
+func (c *RallyController) getRallyIDPathParam() (int, bool) {
+	rallyID := c.GetIntPathParam("rally_id")
+	if rallyID <= 0 {
+		c.HandleValidationError("rally_id", "Invalid rally ID", c.Ctx.Input.Param(":rally_id"))
+		return 0, false
+	}
+	return rallyID, true
+}
+
+// rallyInputFromRequest リクエストをユースケースの入力に変換する
+func rallyInputFromRequest(request *dto.RallyRequest) usecase.RallyInput {
+	return usecase.RallyInput{
+		Title:             request.Title,
+		Description:       request.Description,
+		BreweryIDs:        request.BreweryIDs,
+		StartAt:           request.StartAt,
+		EndAt:             request.EndAt,
+		RequiredCount:     request.RequiredCount,
+		RewardDescription: request.RewardDescription,
+	}
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/mapper"
+	"time"
+)
+
+// RecapController 年間の振り返りに関するHTTPリクエストを処理するコントローラー
+type RecapController struct {
+	BaseController
+	recapUsecase       usecase.RecapUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewRecapController 新しい年間の振り返りのコントローラーを作成する
+func NewRecapController() *RecapController {
+	recapRepo := repository.NewUserRecapRepository()
+	visitStatsRepo := repository.NewVisitStatsRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &RecapController{
+		recapUsecase:       usecase.NewRecapUsecase(recapRepo, visitStatsRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetMyRecap 認証されたユーザーの年間の振り返りを取得する
+// 年の区切りはバッチでの集計と同じ visit.default_time_zone のタイムゾーンで判定する
+// @Title Get My Year in Review
+// @Description Get the authenticated user's yearly recap. Past years are precomputed by a batch job; the current year is computed on demand
+// @Param year path int true "Year"
+// @Success 200 {object} dto.UserRecapResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/recap/:year [get]
+func (c *RecapController) GetMyRecap() {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return
+	}
+
+	year := c.GetIntPathParam("year")
+	if year <= 0 {
+		c.HandleValidationError("year", "Invalid year", c.Ctx.Input.Param(":year"))
+		return
+	}
+
+	loc, err := time.LoadLocation(defaultTimeZoneName())
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	recap, err := c.recapUsecase.GetRecap(userProfile.ID(), year, loc)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.UserRecapEntityToResponse(recap, loc))
+}
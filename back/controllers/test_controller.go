@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"mybeerlog/utils"
+	"strings"
 
 	"github.com/astaxie/beego"
 )
@@ -23,6 +24,7 @@ func NewTestController() *TestController {
 // @Title Generate Test Token
 // @Description Generate a test authentication token for local development
 // @Param cognito_sub query string false "Custom Cognito SUB (optional)"
+// @Param groups query string false "Comma separated Cognito groups (optional, e.g. admin)"
 // @Success 200 {object} utils.TestAuthToken
 // @Failure 403 {object} map[string]string
 // @router /generate-token [get]
@@ -39,8 +41,16 @@ func (c *TestController) GenerateToken() {
 		cognitoSub = c.authManager.GetDefaultTestCognitoSub()
 	}
 
+	// グループをクエリパラメータから取得（管理者権限のテスト用）
+	var groups []string
+	for _, group := range strings.Split(c.GetString("groups"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+
 	// テストトークン生成
-	token := c.authManager.GenerateToken(cognitoSub)
+	token := c.authManager.GenerateToken(cognitoSub, groups...)
 
 	c.JSONResponse(token)
 }
@@ -49,51 +59,25 @@ func (c *TestController) GenerateToken() {
 // @Title Test Authentication
 // @Description Test authentication with a token
 // @Param Authorization header string true "Bearer token"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} map[string]interface{}
 // @Failure 401 {object} map[string]string
 // @router /test-auth [get]
 func (c *TestController) TestAuth() {
-	cognitoSub := c.getCognitoSub()
-	if cognitoSub == "" {
-		c.ErrorResponse(401, "Authentication required", "UNAUTHORIZED")
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
 		return
 	}
 
-	response := map[string]string{
+	response := map[string]interface{}{
 		"message":     "Authentication successful",
 		"cognito_sub": cognitoSub,
+		"groups":      c.GetAuthClaims().Groups,
+		"is_admin":    c.IsAdmin(),
 	}
 
 	c.JSONResponse(response)
 }
 
-// getCognitoSub リクエストからCognito SUBを抽出する（テストトークン含む）
-func (c *TestController) getCognitoSub() string {
-	authHeader := c.Ctx.Input.Header("Authorization")
-	if authHeader == "" {
-		return ""
-	}
-
-	// Bearer tokenの形式チェック
-	if len(authHeader) < 7 || authHeader[:7] != "Bearer " {
-		return ""
-	}
-
-	token := authHeader[7:]
-
-	// 開発環境ではテストトークンも受け入れる
-	if beego.BConfig.RunMode == "dev" {
-		if cognitoSub, err := c.authManager.ValidateToken(token); err == nil {
-			return cognitoSub
-		}
-	}
-
-	// 本番環境では実際のCognito検証を行う
-	// TODO: 実際のCognito JWT検証を実装
-
-	return ""
-}
-
 // RevokeToken テスト認証トークンを無効化する
 // @Title Revoke Test Token
 // @Description Revoke a test authentication token
@@ -9,7 +9,6 @@ import (
 	"mybeerlog/interfaces/mapper"
 	"mybeerlog/utils"
 	"net/http"
-	"strings"
 )
 
 // UserController ユーザー関連のHTTPリクエストを処理するコントローラー
@@ -43,7 +42,7 @@ func (c *UserController) GetProfile() {
 
 	profile, err := c.userProfileUsecase.GetProfile(cognitoSub)
 	if err != nil {
-		c.HandleNotFound("User profile")
+		c.HandleDomainError(err)
 		return
 	}
 
@@ -77,13 +76,9 @@ func (c *UserController) CreateProfile() {
 		return // バリデーションエラーは関数内で処理済み
 	}
 
-	profile, err := c.userProfileUsecase.CreateProfile(cognitoSub, request.DisplayName, request.IconURL)
+	profile, err := c.userProfileUsecase.CreateProfile(cognitoSub, request.DisplayName, request.IconURL, request.Handle)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			c.ErrorResponseDetailed(http.StatusConflict, "Profile already exists", err.Error(), dto.ErrorCodeProfileExists, nil)
-			return
-		}
-		c.HandleInternalError(err)
+		c.HandleDomainError(err)
 		return
 	}
 
@@ -122,13 +117,9 @@ func (c *UserController) UpdateProfile() {
 		return // バリデーションエラーは関数内で処理済み
 	}
 
-	profile, err := c.userProfileUsecase.UpdateProfile(cognitoSub, request.DisplayName, request.IconURL)
+	profile, err := c.userProfileUsecase.UpdateProfile(cognitoSub, request.DisplayName, request.IconURL, request.Handle)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.HandleNotFound("User profile")
-			return
-		}
-		c.HandleInternalError(err)
+		c.HandleDomainError(err)
 		return
 	}
 
@@ -140,6 +131,42 @@ func (c *UserController) UpdateProfile() {
 	c.JSONResponseWithMessage(response, "Profile updated successfully")
 }
 
+// UpdatePrivacy 認証されたユーザーの公開プロフィールのプライバシー設定を更新する
+// @Title Update Profile Privacy
+// @Description Replace the privacy settings of the authenticated user's public profile
+// @Param body body dto.ProfilePrivacyRequest true "Privacy settings"
+// @Success 200 {object} dto.UserProfileResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/privacy [put]
+func (c *UserController) UpdatePrivacy() {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return
+	}
+
+	var request dto.ProfilePrivacyRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.HandleError(err, "Invalid request body", dto.ErrorCodeInvalidRequest, http.StatusBadRequest)
+		return
+	}
+
+	profile, err := c.userProfileUsecase.UpdatePrivacy(cognitoSub, request.Public, request.HideVisitTimes, request.VisitPublishDelayHours)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "User profile privacy updated successfully", map[string]interface{}{
+		"cognito_sub": cognitoSub,
+		"public":      request.Public,
+	})
+
+	response := mapper.UserProfileEntityToResponse(profile)
+	c.JSONResponseWithMessage(response, "Privacy settings updated successfully")
+}
+
 // validateUserProfileRequest ユーザープロファイルリクエストのバリデーション
 func (c *UserController) validateUserProfileRequest(request *dto.UserProfileRequest) error {
 	// DisplayName のバリデーション
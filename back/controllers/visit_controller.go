@@ -2,19 +2,27 @@ package controllers
 
 import (
 	"encoding/json"
+	"mybeerlog/domain/entity"
 	"mybeerlog/domain/repository"
 	"mybeerlog/domain/usecase"
 	"mybeerlog/interfaces/dto"
 	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/astaxie/beego"
 )
 
+// visitDateQueryFormat 訪問履歴の from / to に日付のみを指定する場合の形式
+const visitDateQueryFormat = "2006-01-02"
+
 // VisitController 訪問関連のHTTPリクエストを処理するコントローラー
 type VisitController struct {
 	BaseController
 	visitUsecase       usecase.VisitUsecase
+	beerLogUsecase     usecase.BeerLogUsecase
 	userProfileUsecase usecase.UserProfileUsecase
 }
 
@@ -24,11 +32,23 @@ func NewVisitController() *VisitController {
 	breweryRepo := repository.NewBreweryRepository()
 	userProfileRepo := repository.NewUserProfileRepository()
 
-	visitUsecase := usecase.NewVisitUsecase(visitRepo, breweryRepo)
+	badgeRepo := repository.NewBadgeRepository()
+	beerLogRepo := repository.NewBeerLogRepository()
+	beerRepo := repository.NewBeerRepository()
+
+	wishlistRepo := repository.NewWishlistRepository()
+	activityRepo := repository.NewBreweryActivityRepository()
+	rallyParticipantRepo := repository.NewRallyParticipantRepository()
+	checkinSecretRepo := repository.NewCheckinSecretRepository()
+
+	feedUsecase := usecase.NewFeedUsecase(activityRepo, breweryRepo, visitRepo)
+	visitUsecase := usecase.NewVisitUsecase(visitRepo, breweryRepo, wishlistRepo, rallyParticipantRepo, checkinSecretRepo, usecase.NewBadgeUsecase(badgeRepo), feedUsecase)
+	beerLogUsecase := usecase.NewBeerLogUsecase(beerLogRepo, visitRepo, beerRepo)
 	userProfileUsecase := usecase.NewUserProfileUsecase(userProfileRepo)
 
 	return &VisitController{
 		visitUsecase:       visitUsecase,
+		beerLogUsecase:     beerLogUsecase,
 		userProfileUsecase: userProfileUsecase,
 	}
 }
@@ -52,7 +72,7 @@ func (c *VisitController) CheckIn() {
 	// ユーザープロファイル取得
 	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
 	if err != nil {
-		c.ErrorResponse(404, "User profile not found", "PROFILE_NOT_FOUND")
+		c.HandleDomainError(err)
 		return
 	}
 
@@ -68,30 +88,114 @@ func (c *VisitController) CheckIn() {
 		maxDistance = 100.0 // デフォルト100m
 	}
 
-	visit, err := c.visitUsecase.CheckIn(
-		userProfile.ID(),
-		request.BreweryID,
-		request.Latitude,
-		request.Longitude,
-		maxDistance,
-	)
+	// クライアントバージョンはリクエストボディを優先し、未指定の場合はヘッダーから取得する
+	clientVersion := strings.TrimSpace(request.ClientVersion)
+	if clientVersion == "" {
+		clientVersion = strings.TrimSpace(c.Ctx.Input.Header("X-Client-Version"))
+	}
+
+	result, err := c.visitUsecase.CheckIn(usecase.CheckInInput{
+		UserProfileID: userProfile.ID(),
+		BreweryID:     request.BreweryID,
+		Latitude:      request.Latitude,
+		Longitude:     request.Longitude,
+		AccuracyM:     request.AccuracyM,
+		ClientVersion: clientVersion,
+
+		MaxDistance:       maxDistance,
+		MaxAccuracyM:      beego.AppConfig.DefaultFloat("gps.max_accuracy", 50.0),
+		RequireAccuracy:   beego.AppConfig.DefaultBool("gps.require_accuracy", false),
+		MaxTravelSpeedKmh: beego.AppConfig.DefaultFloat("gps.max_travel_speed", 300.0),
+		Cooldown:          time.Duration(beego.AppConfig.DefaultInt("checkin.cooldown_minutes", 60)) * time.Minute,
+	})
 	if err != nil {
-		switch err.Error() {
-		case "brewery not found":
-			c.ErrorResponse(404, "Brewery not found", "BREWERY_NOT_FOUND")
-		case "too far from brewery for check-in":
-			c.ErrorResponse(400, "Too far from brewery for check-in", "LOCATION_TOO_FAR")
-		case "already checked in within the last hour":
-			c.ErrorResponse(400, "Already checked in within the last hour", "DUPLICATE_CHECKIN")
-		default:
-			c.ErrorResponse(400, err.Error(), "CHECKIN_FAILED")
-		}
+		c.HandleDomainError(err)
 		return
 	}
 
+	c.checkInResponse(result)
+}
+
+// CheckInWithQR 醸造所に掲示されたQRコードを使用して醸造所にチェックインする
+// GPSが不安定な店内（地下のタップルームなど）向けの代替手段で、重複チェックインの防止やバッジの付与はGPSによるチェックインと同じ
+// @Title Check In With QR Code
+// @Description Check in to brewery by scanning the rotating QR code displayed at the brewery
+// @Param body body dto.QRCheckinRequest true "Scanned QR code"
+// @Success 201 {object} dto.CheckinResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /checkin/qr [post]
+func (c *VisitController) CheckInWithQR() {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return
+	}
+
+	// ユーザープロファイル取得
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	var request dto.QRCheckinRequest
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &request); err != nil {
+		c.ErrorResponse(400, "Invalid request body", "INVALID_REQUEST")
+		return
+	}
+	if strings.TrimSpace(request.Token) == "" {
+		c.HandleValidationError("token", "Token is required", request.Token)
+		return
+	}
+
+	result, err := c.visitUsecase.CheckInWithQR(usecase.QRCheckInInput{
+		UserProfileID: userProfile.ID(),
+		Token:         request.Token,
+
+		Period:            checkinCodePeriod(),
+		AllowedSkew:       beego.AppConfig.DefaultInt("checkin.qr_allowed_skew", 1),
+		MaxTravelSpeedKmh: beego.AppConfig.DefaultFloat("gps.max_travel_speed", 300.0),
+		Cooldown:          time.Duration(beego.AppConfig.DefaultInt("checkin.cooldown_minutes", 60)) * time.Minute,
+	})
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.checkInResponse(result)
+}
+
+// checkInResponse チェックイン結果のレスポンスを返す（訪問の作成後の反映に失敗した場合はログに記録する）
+func (c *VisitController) checkInResponse(result *usecase.CheckInResult) {
+	if result.BadgeErr != nil {
+		utils.LogError(c.Ctx.Request.Context(), result.BadgeErr, "Failed to award badges", map[string]interface{}{
+			"visit_id": result.Visit.ID(),
+		})
+	}
+	if result.WishlistErr != nil {
+		utils.LogError(c.Ctx.Request.Context(), result.WishlistErr, "Failed to mark wishlist brewery as visited", map[string]interface{}{
+			"visit_id": result.Visit.ID(),
+		})
+	}
+	if result.RallyErr != nil {
+		utils.LogError(c.Ctx.Request.Context(), result.RallyErr, "Failed to stamp rallies", map[string]interface{}{
+			"visit_id": result.Visit.ID(),
+		})
+	}
+
+	if result.MilestoneErr != nil {
+		utils.LogError(c.Ctx.Request.Context(), result.MilestoneErr, "Failed to record visit milestone activity", map[string]interface{}{
+			"visit_id": result.Visit.ID(),
+		})
+	}
+
 	response := dto.CheckinResponse{
-		Visit:   mapper.VisitEntityToResponse(visit),
-		Message: "Check-in successful!",
+		Visit:           mapper.VisitEntityToOwnerResponse(result.Visit),
+		NewBadges:       mapper.UserBadgeEntitiesToResponses(result.NewBadges),
+		WishlistVisited: result.WishlistVisited,
+		RallyStamps:     mapper.RallyParticipantEntitiesToProgressResponses(result.RallyStamps),
+		Message:         "Check-in successful!",
 	}
 
 	c.Ctx.ResponseWriter.WriteHeader(201)
@@ -103,9 +207,18 @@ func (c *VisitController) CheckIn() {
 // @Description Get authenticated user's visit history
 // @Param brewery_id query int false "Filter by brewery ID"
 // @Param limit query int false "Limit (default: 20, max: 100)"
-// @Param offset query int false "Offset (default: 0)"
+// @Param offset query int false "Offset (default: 0, ignored when cursor is given)"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page"
+// @Param include_total query bool false "Include total (default: true without cursor, false with cursor)"
 // @Param sort query string false "Sort order: asc or desc (default: desc)"
+// @Param from query string false "Visits at or after this time (RFC3339, or YYYY-MM-DD in tz)"
+// @Param to query string false "Visits before this time (RFC3339, or YYYY-MM-DD in tz, inclusive of the day)"
+// @Param tz query string false "IANA time zone for date-only from/to (default: visit.default_time_zone)"
+// @Param brewery_name query string false "Filter by brewery name (case-insensitive partial match)"
+// @Param prefecture query string false "Filter by brewery prefecture"
+// @Param distinct query string false "brewery: only the latest matching visit per brewery"
 // @Success 200 {object} dto.VisitsResponse
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @router /visits [get]
 func (c *VisitController) GetVisits() {
@@ -118,31 +231,140 @@ func (c *VisitController) GetVisits() {
 	// ユーザープロファイル取得
 	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
 	if err != nil {
-		c.ErrorResponse(404, "User profile not found", "PROFILE_NOT_FOUND")
+		c.HandleDomainError(err)
 		return
 	}
 
-	breweryID := c.GetIntQuery("brewery_id", 0)
-	limit := c.GetIntQuery("limit", 20)
-	offset := c.GetIntQuery("offset", 0)
+	filter, ok := c.getVisitFilter()
+	if !ok {
+		return
+	}
+
+	// カーソルは発行したユーザー・並び順の訪問履歴でのみ有効とする
+	scope := "visits:" + strconv.Itoa(userProfile.ID())
+	if filter.Ascending {
+		scope += ":asc"
+	}
+	page, ok := c.getPageQuery(scope)
+	if !ok {
+		return
+	}
 
-	visits, total, err := c.visitUsecase.GetVisitHistory(userProfile.ID(), &breweryID, limit, offset)
+	visits, result, err := c.visitUsecase.GetVisitHistory(userProfile.ID(), filter, page)
 	if err != nil {
-		c.ErrorResponse(400, err.Error(), "FETCH_FAILED")
+		c.HandleDomainError(err)
 		return
 	}
 
 	response := dto.VisitsResponse{
-		Visits: mapper.VisitEntitiesToResponses(visits),
-		Total:  total,
+		Visits: mapper.VisitEntitiesToOwnerResponses(visits),
+		Total:  result.Total,
+	}
+	if len(visits) > 0 {
+		first, last := visits[0], visits[len(visits)-1]
+		response.NextCursor, response.PrevCursor, err = pageCursors(scope, result,
+			&repository.PageKey{Time: first.VisitedAt(), ID: first.ID()},
+			&repository.PageKey{Time: last.VisitedAt(), ID: last.ID()})
+		if err != nil {
+			c.HandleInternalError(err)
+			return
+		}
 	}
 
 	c.JSONResponse(response)
 }
 
-// GetVisit IDで訪問の詳細を取得する
+// getVisitFilter クエリパラメータから訪問履歴の絞り込み・並び順の条件を組み立てる
+func (c *VisitController) getVisitFilter() (repository.VisitFilter, bool) {
+	breweryID, ok := c.GetIntQuery("brewery_id", 0)
+	if !ok {
+		return repository.VisitFilter{}, false
+	}
+
+	filter := repository.VisitFilter{
+		BreweryID:   breweryID,
+		BreweryName: c.GetString("brewery_name"),
+		Prefecture:  c.GetString("prefecture"),
+	}
+
+	switch sort := c.GetString("sort"); sort {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		c.HandleValidationError("sort", "sort must be asc or desc", sort)
+		return filter, false
+	}
+
+	switch distinct := c.GetString("distinct"); distinct {
+	case "":
+	case "brewery":
+		filter.LatestPerBrewery = true
+	default:
+		c.HandleValidationError("distinct", "distinct must be brewery", distinct)
+		return filter, false
+	}
+
+	// 日付のみの from / to は tz のタイムゾーンの日付として解釈する
+	loc, ok := c.getTimeZoneQuery()
+	if !ok {
+		return filter, false
+	}
+	if filter.From, ok = c.getVisitTimeQuery("from", loc, false); !ok {
+		return filter, false
+	}
+	if filter.To, ok = c.getVisitTimeQuery("to", loc, true); !ok {
+		return filter, false
+	}
+	return filter, true
+}
+
+// getTimeZoneQuery クエリパラメータ tz からタイムゾーンを取得する（省略時は visit.default_time_zone）
+func (c *BaseController) getTimeZoneQuery() (*time.Location, bool) {
+	tz := c.GetString("tz")
+	if tz == "" {
+		tz = defaultTimeZoneName()
+	}
+	// "Local" は実行環境に依存するため受け付けない
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		c.HandleValidationError("tz", "tz must be an IANA time zone name", tz)
+		return nil, false
+	}
+	return loc, true
+}
+
+// defaultTimeZoneName 設定から訪問日時の集計・解釈に使用するデフォルトのタイムゾーン名を取得する
+func defaultTimeZoneName() string {
+	return beego.AppConfig.DefaultString("visit.default_time_zone", "Asia/Tokyo")
+}
+
+// getVisitTimeQuery クエリパラメータから日時を取得する（未指定の場合は nil）
+// RFC3339 の日時に加え、YYYY-MM-DD の日付を loc の日付の0時として受け付ける。endOfDay の場合は翌日の0時とし、指定した日を含める
+func (c *VisitController) getVisitTimeQuery(name string, loc *time.Location, endOfDay bool) (*time.Time, bool) {
+	value := c.GetString(name)
+	if value == "" {
+		return nil, true
+	}
+
+	if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return &parsed, true
+	}
+	date, err := time.ParseInLocation(visitDateQueryFormat, value, loc)
+	if err != nil {
+		c.HandleValidationError(name, name+" must be RFC3339 date-time or YYYY-MM-DD date", value)
+		return nil, false
+	}
+	if endOfDay {
+		date = date.AddDate(0, 0, 1)
+	}
+	return &date, true
+}
+
+// GetVisit IDで訪問の詳細を飲んだビールの記録とともに取得する
+// 訪問の所有者に加え、管理者はチェックインの監査のため全ての訪問を参照できる
 // @Title Get Visit Details
-// @Description Get visit details by ID
+// @Description Get visit details by ID (owner, or admin for auditing)
 // @Param visit_id path int true "Visit ID"
 // @Success 200 {object} dto.VisitResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -156,31 +378,49 @@ func (c *VisitController) GetVisit() {
 		return
 	}
 
+	visitIDstr := c.Ctx.Input.Param(":visit_id")
+	visitID, err := strconv.Atoi(visitIDstr)
+	if err != nil {
+		c.ErrorResponse(400, "Invalid visit ID", "INVALID_VISIT_ID")
+		return
+	}
+
+	// 管理者: 所有者を問わず参照可能
+	if c.IsAdmin() {
+		visit, err := c.visitUsecase.GetVisitForAudit(visitID)
+		if err != nil {
+			c.HandleDomainError(err)
+			return
+		}
+		c.visitDetailResponse(visit)
+		return
+	}
+
 	// ユーザープロファイル取得
 	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
 	if err != nil {
-		c.ErrorResponse(404, "User profile not found", "PROFILE_NOT_FOUND")
+		c.HandleDomainError(err)
 		return
 	}
 
-	visitIDstr := c.Ctx.Input.Param(":visit_id")
-	visitID, err := strconv.Atoi(visitIDstr)
+	visit, err := c.visitUsecase.GetVisit(visitID, (userProfile.ID()))
 	if err != nil {
-		c.ErrorResponse(400, "Invalid visit ID", "INVALID_VISIT_ID")
+		c.HandleDomainError(err)
 		return
 	}
 
-	visit, err := c.visitUsecase.GetVisit(visitID, (userProfile.ID()))
+	c.visitDetailResponse(visit)
+}
+
+// visitDetailResponse 訪問の詳細を飲んだビールの記録とともに返す
+func (c *VisitController) visitDetailResponse(visit *entity.Visit) {
+	beerLogs, err := c.beerLogUsecase.GetVisitBeerLogs(visit.ID())
 	if err != nil {
-		switch err.Error() {
-		case "access denied":
-			c.ErrorResponse(403, "Access denied", "FORBIDDEN")
-		default:
-			c.ErrorResponse(404, "Visit not found", "VISIT_NOT_FOUND")
-		}
+		c.HandleDomainError(err)
 		return
 	}
 
-	response := mapper.VisitEntityToResponse(visit)
+	response := mapper.VisitEntityToOwnerResponse(visit)
+	response.Beers = mapper.BeerLogEntitiesToResponses(beerLogs)
 	c.JSONResponse(response)
 }
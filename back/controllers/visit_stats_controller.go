@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/mapper"
+)
+
+// VisitStatsController 訪問履歴の集計に関するHTTPリクエストを処理するコントローラー
+type VisitStatsController struct {
+	BaseController
+	visitStatsUsecase  usecase.VisitStatsUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewVisitStatsController 新しい訪問履歴の集計のコントローラーを作成する
+func NewVisitStatsController() *VisitStatsController {
+	visitStatsRepo := repository.NewVisitStatsRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &VisitStatsController{
+		visitStatsUsecase:  usecase.NewVisitStatsUsecase(visitStatsRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetMyStats 認証されたユーザーの訪問履歴の集計を取得する
+// 月・曜日・時間帯ごとの訪問数は tz（省略時は visit.default_time_zone）のタイムゾーンで集計する
+// @Title Get My Visit Stats
+// @Description Get aggregated statistics of the authenticated user's visit history for the dashboard
+// @Param tz query string false "IANA time zone for month/weekday/hour histograms (default: visit.default_time_zone)"
+// @Success 200 {object} dto.VisitStatsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/stats [get]
+func (c *VisitStatsController) GetMyStats() {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return
+	}
+
+	loc, ok := c.getTimeZoneQuery()
+	if !ok {
+		return
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	stats, err := c.visitStatsUsecase.GetStats(userProfile.ID(), loc)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponse(mapper.VisitStatsEntityToResponse(stats, loc))
+}
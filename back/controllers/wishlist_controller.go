@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"mybeerlog/domain/repository"
+	"mybeerlog/domain/usecase"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/interfaces/mapper"
+	"mybeerlog/utils"
+	"net/http"
+)
+
+// WishlistController 行きたいリストに関するHTTPリクエストを処理するコントローラー
+type WishlistController struct {
+	BaseController
+	wishlistUsecase    usecase.WishlistUsecase
+	userProfileUsecase usecase.UserProfileUsecase
+}
+
+// NewWishlistController 新しい行きたいリストコントローラーを作成する
+func NewWishlistController() *WishlistController {
+	wishlistRepo := repository.NewWishlistRepository()
+	breweryRepo := repository.NewBreweryRepository()
+	userProfileRepo := repository.NewUserProfileRepository()
+
+	return &WishlistController{
+		wishlistUsecase:    usecase.NewWishlistUsecase(wishlistRepo, breweryRepo),
+		userProfileUsecase: usecase.NewUserProfileUsecase(userProfileRepo),
+	}
+}
+
+// GetWishlist 認証されたユーザーの行きたいリストを取得する
+// lat と lng を指定した場合は各醸造所までの距離を付け、距離の近い順に返す
+// @Title Get Wishlist
+// @Description Get the authenticated user's want-to-visit list. When lat and lng are given, items include distance and are ordered nearest first
+// @Param lat query float64 false "Latitude of the current location"
+// @Param lng query float64 false "Longitude of the current location"
+// @Success 200 {object} dto.WishlistResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/wishlist [get]
+func (c *WishlistController) GetWishlist() {
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	var lat, lng *float64
+	if c.GetString("lat") != "" && c.GetString("lng") != "" {
+		latValue, ok := c.GetFloatQuery("lat", 0)
+		if !ok {
+			return
+		}
+		lngValue, ok := c.GetFloatQuery("lng", 0)
+		if !ok {
+			return
+		}
+		lat, lng = &latValue, &lngValue
+	}
+
+	items, err := c.wishlistUsecase.GetWishlist(userProfileID, lat, lng)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	response := dto.WishlistResponse{
+		Items: mapper.NearbyWishlistItemsToResponses(items),
+		Total: len(items),
+	}
+	c.JSONResponse(response)
+}
+
+// AddToWishlist 醸造所を行きたいリストに登録する（登録済みの場合は 200 で既存の登録を返す）
+// @Title Add To Wishlist
+// @Description Add a brewery to the authenticated user's want-to-visit list
+// @Param brewery_id path int true "Brewery ID"
+// @Success 201 {object} dto.WishlistItemResponse
+// @Success 200 {object} dto.WishlistItemResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/wishlist/:brewery_id [post]
+func (c *WishlistController) AddToWishlist() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	item, created, err := c.wishlistUsecase.AddToWishlist(userProfileID, breweryID)
+	if err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	if !created {
+		c.JSONResponseWithMessage(mapper.WishlistItemEntityToResponse(item), "Brewery is already in the wishlist")
+		return
+	}
+
+	utils.LogInfo(c.Ctx.Request.Context(), "Brewery added to wishlist", map[string]interface{}{
+		"brewery_id":      breweryID,
+		"user_profile_id": userProfileID,
+	})
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+	c.JSONResponseWithMessage(mapper.WishlistItemEntityToResponse(item), "Brewery added to wishlist")
+}
+
+// RemoveFromWishlist 醸造所を行きたいリストから削除する
+// @Title Remove From Wishlist
+// @Description Remove a brewery from the authenticated user's want-to-visit list
+// @Param brewery_id path int true "Brewery ID"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @router /users/profile/wishlist/:brewery_id [delete]
+func (c *WishlistController) RemoveFromWishlist() {
+	breweryID, ok := c.getBreweryIDPathParam()
+	if !ok {
+		return
+	}
+
+	userProfileID, ok := c.requireUserProfileID()
+	if !ok {
+		return
+	}
+
+	if err := c.wishlistUsecase.RemoveFromWishlist(userProfileID, breweryID); err != nil {
+		c.HandleDomainError(err)
+		return
+	}
+
+	c.JSONResponseWithMessage(map[string]int{"brewery_id": breweryID}, "Brewery removed from wishlist")
+}
+
+// requireUserProfileID 認証済みユーザーのプロファイルIDを取得する（失敗時はエラーレスポンスを返す）
+func (c *WishlistController) requireUserProfileID() (int, bool) {
+	cognitoSub, ok := c.RequireAuth()
+	if !ok {
+		return 0, false
+	}
+
+	userProfile, err := c.userProfileUsecase.GetProfile(cognitoSub)
+	if err != nil {
+		c.HandleDomainError(err)
+		return 0, false
+	}
+
+	return userProfile.ID(), true
+}
+
+// getBreweryIDPathParam パスパラメータから醸造所IDを取得する
+func (c *WishlistController) getBreweryIDPathParam() (int, bool) {
+	breweryID := c.GetIntPathParam("brewery_id")
+	if breweryID <= 0 {
+		c.HandleValidationError("brewery_id", "Invalid brewery ID", c.Ctx.Input.Param(":brewery_id"))
+		return 0, false
+	}
+	return breweryID, true
+}
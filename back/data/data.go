@@ -0,0 +1,14 @@
+// Package data アプリケーションに同梱する初期データを提供する
+package data
+
+import _ "embed"
+
+// BeerStylesJSON ビアスタイルの分類（ファミリー → スタイル → サブスタイル）の初期データ
+//
+//go:embed beer_styles.json
+var BeerStylesJSON []byte
+
+// BadgesJSON バッジ定義（獲得条件）の初期データ。表示順は配列の順序とする
+//
+//go:embed badges.json
+var BadgesJSON []byte
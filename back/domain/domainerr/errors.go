@@ -0,0 +1,164 @@
+// Package domainerr ユースケース・リポジトリが返すドメインエラーを定義する
+// コントローラーはエラーメッセージの文字列ではなく、ここで定義したエラーの種別で HTTP レスポンスを決定する
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind ドメインエラーの種別
+type Kind int
+
+const (
+	// KindInternal 予期しないエラー（DB障害など）
+	KindInternal Kind = iota
+	// KindInvalid 入力値やビジネスルールの検証エラー
+	KindInvalid
+	// KindForbidden 操作が許可されていない
+	KindForbidden
+	// KindNotFound リソースが存在しない
+	KindNotFound
+	// KindConflict 既存のデータと競合する
+	KindConflict
+)
+
+// Error 種別付きのドメインエラー
+type Error struct {
+	kind    Kind
+	message string
+}
+
+// New 新しいドメインエラーを作成する
+func New(kind Kind, message string) *Error {
+	return &Error{
+		kind:    kind,
+		message: message,
+	}
+}
+
+// Invalid 入力値の検証エラーを作成する
+func Invalid(message string) error {
+	return New(KindInvalid, message)
+}
+
+// Error エラーメッセージを返す
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Kind エラーの種別を返す
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+// Wrap 原因となったエラーをドメインエラーで包む
+// errors.Is(err, sentinel) と errors.Is(err, cause) の両方が成立する
+func Wrap(sentinel *Error, cause error) error {
+	if cause == nil {
+		return sentinel
+	}
+	return fmt.Errorf("%w: %w", sentinel, cause)
+}
+
+// KindOf エラーの種別を返す（ドメインエラーでない場合は KindInternal）
+func KindOf(err error) Kind {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.kind
+	}
+	return KindInternal
+}
+
+// 汎用エラー
+var (
+	ErrNotFound = New(KindNotFound, "resource not found")
+	ErrConflict = New(KindConflict, "resource already exists")
+)
+
+// ユーザープロファイル関連のエラー
+var (
+	ErrUserProfileNotFound      = New(KindNotFound, "user profile not found")
+	ErrUserProfileAlreadyExists = New(KindConflict, "profile already exists")
+	ErrHandleAlreadyTaken       = New(KindConflict, "handle is already taken")
+)
+
+// 醸造所関連のエラー
+var (
+	ErrBreweryNotFound    = New(KindNotFound, "brewery not found")
+	ErrBreweryModified    = New(KindConflict, "brewery has been modified by another request")
+	ErrUpdatedAtRequired  = New(KindInvalid, "updated_at is required")
+	ErrInvalidSearchRange = New(KindInvalid, "invalid location parameters")
+)
+
+// ビール関連のエラー
+var (
+	ErrBeerNotFound    = New(KindNotFound, "beer not found")
+	ErrBeerModified    = New(KindConflict, "beer has been modified by another request")
+	ErrBeerLogNotFound = New(KindNotFound, "beer log not found")
+)
+
+// 行きたいリスト関連のエラー
+var (
+	ErrWishlistItemNotFound = New(KindNotFound, "brewery is not in the wishlist")
+)
+
+// 醸造所の投稿関連のエラー
+var (
+	ErrBreweryPostNotFound = New(KindNotFound, "brewery post not found")
+)
+
+// イベント関連のエラー
+var (
+	ErrEventNotFound      = New(KindNotFound, "event not found")
+	ErrEventRSVPNotFound  = New(KindNotFound, "rsvp not found")
+	ErrEventClosed        = New(KindConflict, "event has already ended")
+	ErrEventNotInProgress = New(KindInvalid, "event is not in progress")
+	ErrNotGoingToEvent    = New(KindForbidden, "rsvp is not confirmed")
+)
+
+// スタンプラリー関連のエラー
+var (
+	ErrRallyNotFound       = New(KindNotFound, "rally not found")
+	ErrRallyNotJoined      = New(KindNotFound, "not participating in this rally")
+	ErrRallyClosed         = New(KindConflict, "rally has already ended")
+	ErrNotRallyOrganizer   = New(KindForbidden, "not an organizer of this rally")
+	ErrRallyRewardNotFound = New(KindNotFound, "reward code not found")
+	ErrRallyRewardRedeemed = New(KindConflict, "reward has already been redeemed")
+)
+
+// カレンダー購読関連のエラー
+var (
+	ErrCalendarFeedNotFound = New(KindNotFound, "calendar feed not found")
+)
+
+// フォロー・フィード関連のエラー
+var (
+	ErrNotFollowingBrewery = New(KindNotFound, "not following this brewery")
+	ErrInvalidCursor       = New(KindInvalid, "invalid cursor")
+)
+
+// ビアスタイル関連のエラー
+var (
+	ErrUnknownStyle = New(KindInvalid, "unknown beer style")
+)
+
+// 醸造所管理者関連のエラー
+var (
+	ErrBreweryManagerNotFound      = New(KindNotFound, "brewery manager not found")
+	ErrBreweryManagerAlreadyExists = New(KindConflict, "brewery manager already exists")
+)
+
+// 訪問・チェックイン関連のエラー
+var (
+	ErrVisitNotFound            = New(KindNotFound, "visit not found")
+	ErrVisitAccessDenied        = New(KindForbidden, "access denied")
+	ErrTooFarFromBrewery        = New(KindInvalid, "too far from brewery for check-in")
+	ErrLocationAccuracyRequired = New(KindInvalid, "location accuracy is required")
+	ErrLocationAccuracyInvalid  = New(KindInvalid, "location accuracy is invalid")
+	ErrLocationAccuracyTooLow   = New(KindInvalid, "location accuracy is too low")
+	ErrImpossibleTravel         = New(KindInvalid, "impossible travel detected")
+	ErrDuplicateCheckin         = New(KindInvalid, "already checked in recently")
+	ErrInvalidCheckinCode       = New(KindInvalid, "invalid check-in code")
+	ErrCheckinCodeExpired       = New(KindInvalid, "check-in code has expired")
+)
@@ -0,0 +1,281 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"strings"
+	"time"
+)
+
+// バッジの獲得条件の種類
+const (
+	// BadgeRuleTotalVisits 累計チェックイン回数が閾値以上
+	BadgeRuleTotalVisits = "total_visits"
+	// BadgeRuleDistinctBreweries 訪問した醸造所の数が閾値以上
+	BadgeRuleDistinctBreweries = "distinct_breweries"
+	// BadgeRuleSameBreweryVisits 同一醸造所への訪問回数が閾値以上
+	BadgeRuleSameBreweryVisits = "same_brewery_visits"
+	// BadgeRulePrefectureComplete 都道府県内の全醸造所を訪問済み（醸造所数が閾値以上の都道府県が対象）
+	BadgeRulePrefectureComplete = "prefecture_complete"
+)
+
+// Badge はバッジの定義（獲得条件を含む）を表す
+type Badge struct {
+	id          int
+	code        string
+	name        string
+	description string
+	ruleType    string
+	threshold   int
+	prefecture  string
+	iconURL     string
+	sortOrder   int
+	createdAt   time.Time
+}
+
+// BadgeStats はバッジ獲得条件の判定に使用するユーザーの訪問実績を表す
+type BadgeStats struct {
+	TotalVisits            int
+	DistinctBreweries      int
+	MaxVisitsToSameBrewery int
+	CompletedPrefectures   map[string]int // 全醸造所を訪問済みの都道府県と、その醸造所数
+}
+
+// BadgeBuilder はBadgeインスタンスの作成を支援する
+type BadgeBuilder struct {
+	badge *Badge
+}
+
+// NewBadgeBuilder 新しいBadgeBuilderを作成する
+func NewBadgeBuilder() *BadgeBuilder {
+	return &BadgeBuilder{
+		badge: &Badge{
+			threshold: 1,
+			createdAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *BadgeBuilder) WithID(id int) *BadgeBuilder {
+	b.badge.id = id
+	return b
+}
+
+// WithCode コードを設定する
+func (b *BadgeBuilder) WithCode(code string) *BadgeBuilder {
+	b.badge.code = strings.TrimSpace(code)
+	return b
+}
+
+// WithName 名前を設定する
+func (b *BadgeBuilder) WithName(name string) *BadgeBuilder {
+	b.badge.name = strings.TrimSpace(name)
+	return b
+}
+
+// WithDescription 説明を設定する
+func (b *BadgeBuilder) WithDescription(description string) *BadgeBuilder {
+	b.badge.description = strings.TrimSpace(description)
+	return b
+}
+
+// WithRule 獲得条件の種類と閾値を設定する
+func (b *BadgeBuilder) WithRule(ruleType string, threshold int) *BadgeBuilder {
+	b.badge.ruleType = ruleType
+	b.badge.threshold = threshold
+	return b
+}
+
+// WithPrefecture 対象の都道府県を設定する（prefecture_complete で特定の都道府県に限定する場合）
+func (b *BadgeBuilder) WithPrefecture(prefecture string) *BadgeBuilder {
+	b.badge.prefecture = prefecture
+	return b
+}
+
+// WithIconURL アイコンURLを設定する
+func (b *BadgeBuilder) WithIconURL(iconURL string) *BadgeBuilder {
+	b.badge.iconURL = strings.TrimSpace(iconURL)
+	return b
+}
+
+// WithSortOrder 表示順を設定する
+func (b *BadgeBuilder) WithSortOrder(sortOrder int) *BadgeBuilder {
+	b.badge.sortOrder = sortOrder
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *BadgeBuilder) WithCreatedAt(createdAt time.Time) *BadgeBuilder {
+	b.badge.createdAt = createdAt
+	return b
+}
+
+// Build Badgeインスタンスを作成する
+func (b *BadgeBuilder) Build() (*Badge, error) {
+	if err := b.badge.validate(); err != nil {
+		return nil, err
+	}
+	return b.badge, nil
+}
+
+// ID IDを取得する
+func (b *Badge) ID() int {
+	return b.id
+}
+
+// Code コードを取得する
+func (b *Badge) Code() string {
+	return b.code
+}
+
+// Name 名前を取得する
+func (b *Badge) Name() string {
+	return b.name
+}
+
+// Description 説明を取得する
+func (b *Badge) Description() string {
+	return b.description
+}
+
+// RuleType 獲得条件の種類を取得する
+func (b *Badge) RuleType() string {
+	return b.ruleType
+}
+
+// Threshold 獲得条件の閾値を取得する
+func (b *Badge) Threshold() int {
+	return b.threshold
+}
+
+// Prefecture 対象の都道府県を取得する
+func (b *Badge) Prefecture() string {
+	return b.prefecture
+}
+
+// IconURL アイコンURLを取得する
+func (b *Badge) IconURL() string {
+	return b.iconURL
+}
+
+// SortOrder 表示順を取得する
+func (b *Badge) SortOrder() int {
+	return b.sortOrder
+}
+
+// CreatedAt 作成日時を取得する
+func (b *Badge) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// IsSatisfiedBy 訪問実績がバッジの獲得条件を満たすかどうかを判定する
+func (b *Badge) IsSatisfiedBy(stats *BadgeStats) bool {
+	if stats == nil {
+		return false
+	}
+
+	switch b.ruleType {
+	case BadgeRuleTotalVisits:
+		return stats.TotalVisits >= b.threshold
+	case BadgeRuleDistinctBreweries:
+		return stats.DistinctBreweries >= b.threshold
+	case BadgeRuleSameBreweryVisits:
+		return stats.MaxVisitsToSameBrewery >= b.threshold
+	case BadgeRulePrefectureComplete:
+		for prefecture, breweryCount := range stats.CompletedPrefectures {
+			if b.prefecture != "" && b.prefecture != prefecture {
+				continue
+			}
+			if breweryCount >= b.threshold {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// validate バッジのバリデーションを実行する
+func (b *Badge) validate() error {
+	if b.code == "" {
+		return domainerr.Invalid("badge code is required")
+	}
+	if len(b.code) > 50 {
+		return domainerr.Invalid("badge code must be 50 characters or less")
+	}
+	if b.name == "" {
+		return domainerr.Invalid("badge name is required")
+	}
+	switch b.ruleType {
+	case BadgeRuleTotalVisits, BadgeRuleDistinctBreweries, BadgeRuleSameBreweryVisits, BadgeRulePrefectureComplete:
+	default:
+		return domainerr.Invalid("invalid badge rule type")
+	}
+	if b.threshold <= 0 {
+		return domainerr.Invalid("badge threshold must be positive")
+	}
+	if b.prefecture != "" && !IsValidPrefecture(b.prefecture) {
+		return domainerr.Invalid("invalid prefecture")
+	}
+	return nil
+}
+
+// UserBadge はユーザーが獲得したバッジを表す
+type UserBadge struct {
+	id            int
+	userProfileID int
+	badgeID       int
+	badge         *Badge
+	visitID       *int
+	awardedAt     time.Time
+}
+
+// NewUserBadge 新しい獲得バッジを作成する
+func NewUserBadge(id, userProfileID int, badge *Badge, visitID *int, awardedAt time.Time) (*UserBadge, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("user profile ID must be positive")
+	}
+	if badge == nil || badge.ID() <= 0 {
+		return nil, domainerr.Invalid("badge is required")
+	}
+
+	return &UserBadge{
+		id:            id,
+		userProfileID: userProfileID,
+		badgeID:       badge.ID(),
+		badge:         badge,
+		visitID:       visitID,
+		awardedAt:     awardedAt,
+	}, nil
+}
+
+// ID IDを取得する
+func (u *UserBadge) ID() int {
+	return u.id
+}
+
+// UserProfileID ユーザープロファイルIDを取得する
+func (u *UserBadge) UserProfileID() int {
+	return u.userProfileID
+}
+
+// BadgeID バッジIDを取得する
+func (u *UserBadge) BadgeID() int {
+	return u.badgeID
+}
+
+// Badge バッジ定義を取得する
+func (u *UserBadge) Badge() *Badge {
+	return u.badge
+}
+
+// VisitID 獲得のきっかけとなった訪問のIDを取得する
+func (u *UserBadge) VisitID() *int {
+	return u.visitID
+}
+
+// AwardedAt 獲得日時を取得する
+func (u *UserBadge) AwardedAt() time.Time {
+	return u.awardedAt
+}
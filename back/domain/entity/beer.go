@@ -0,0 +1,258 @@
+package entity
+
+import (
+	"math"
+	"mybeerlog/domain/domainerr"
+	"strings"
+	"time"
+)
+
+// Beer は醸造所が醸造するビールを表す
+type Beer struct {
+	id             int
+	breweryID      int
+	brewery        *Brewery
+	name           string
+	style          string
+	abv            *float64 // アルコール度数（%）
+	ibu            *int     // 国際苦味単位
+	description    string
+	isSeasonal     bool
+	isLimited      bool
+	availableFrom  *time.Time
+	availableUntil *time.Time
+	averageRating  *float64 // 訪問記録の評価の平均（評価がない場合は nil）
+	ratingCount    int
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// BeerBuilder はBeerインスタンスの作成を支援する
+type BeerBuilder struct {
+	beer *Beer
+}
+
+// NewBeerBuilder 新しいBeerBuilderを作成する
+func NewBeerBuilder() *BeerBuilder {
+	return &BeerBuilder{
+		beer: &Beer{
+			createdAt: time.Now(),
+			updatedAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *BeerBuilder) WithID(id int) *BeerBuilder {
+	b.beer.id = id
+	return b
+}
+
+// WithBreweryID 醸造所IDを設定する
+func (b *BeerBuilder) WithBreweryID(breweryID int) *BeerBuilder {
+	b.beer.breweryID = breweryID
+	return b
+}
+
+// WithBrewery 醸造所を設定する
+func (b *BeerBuilder) WithBrewery(brewery *Brewery) *BeerBuilder {
+	b.beer.brewery = brewery
+	if brewery != nil {
+		b.beer.breweryID = brewery.ID()
+	}
+	return b
+}
+
+// WithName 名前を設定する
+func (b *BeerBuilder) WithName(name string) *BeerBuilder {
+	b.beer.name = strings.TrimSpace(name)
+	return b
+}
+
+// WithStyle スタイルを設定する
+func (b *BeerBuilder) WithStyle(style string) *BeerBuilder {
+	b.beer.style = strings.TrimSpace(style)
+	return b
+}
+
+// WithABV アルコール度数（%）を設定する
+func (b *BeerBuilder) WithABV(abv *float64) *BeerBuilder {
+	b.beer.abv = abv
+	return b
+}
+
+// WithIBU 国際苦味単位を設定する
+func (b *BeerBuilder) WithIBU(ibu *int) *BeerBuilder {
+	b.beer.ibu = ibu
+	return b
+}
+
+// WithDescription 説明を設定する
+func (b *BeerBuilder) WithDescription(description string) *BeerBuilder {
+	b.beer.description = strings.TrimSpace(description)
+	return b
+}
+
+// WithSeasonal 季節限定かどうかを設定する
+func (b *BeerBuilder) WithSeasonal(isSeasonal bool) *BeerBuilder {
+	b.beer.isSeasonal = isSeasonal
+	return b
+}
+
+// WithLimited 数量限定かどうかを設定する
+func (b *BeerBuilder) WithLimited(isLimited bool) *BeerBuilder {
+	b.beer.isLimited = isLimited
+	return b
+}
+
+// WithAvailability 提供期間を設定する（nil の場合は期限なし）
+func (b *BeerBuilder) WithAvailability(from, until *time.Time) *BeerBuilder {
+	b.beer.availableFrom = from
+	b.beer.availableUntil = until
+	return b
+}
+
+// WithRatingSummary 訪問記録の評価の集計を設定する
+func (b *BeerBuilder) WithRatingSummary(averageRating *float64, ratingCount int) *BeerBuilder {
+	b.beer.averageRating = averageRating
+	b.beer.ratingCount = ratingCount
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *BeerBuilder) WithCreatedAt(createdAt time.Time) *BeerBuilder {
+	b.beer.createdAt = createdAt
+	return b
+}
+
+// WithUpdatedAt 更新日時を設定する
+func (b *BeerBuilder) WithUpdatedAt(updatedAt time.Time) *BeerBuilder {
+	b.beer.updatedAt = updatedAt
+	return b
+}
+
+// Build Beerインスタンスを作成する
+func (b *BeerBuilder) Build() (*Beer, error) {
+	if err := b.beer.validate(); err != nil {
+		return nil, err
+	}
+	return b.beer, nil
+}
+
+// ID IDを取得する
+func (b *Beer) ID() int {
+	return b.id
+}
+
+// BreweryID 醸造所IDを取得する
+func (b *Beer) BreweryID() int {
+	return b.breweryID
+}
+
+// Brewery 醸造所を取得する
+func (b *Beer) Brewery() *Brewery {
+	return b.brewery
+}
+
+// Name 名前を取得する
+func (b *Beer) Name() string {
+	return b.name
+}
+
+// Style スタイルを取得する
+func (b *Beer) Style() string {
+	return b.style
+}
+
+// ABV アルコール度数（%）を取得する
+func (b *Beer) ABV() *float64 {
+	return b.abv
+}
+
+// IBU 国際苦味単位を取得する
+func (b *Beer) IBU() *int {
+	return b.ibu
+}
+
+// Description 説明を取得する
+func (b *Beer) Description() string {
+	return b.description
+}
+
+// IsSeasonal 季節限定かどうかを取得する
+func (b *Beer) IsSeasonal() bool {
+	return b.isSeasonal
+}
+
+// IsLimited 数量限定かどうかを取得する
+func (b *Beer) IsLimited() bool {
+	return b.isLimited
+}
+
+// AvailableFrom 提供開始日を取得する
+func (b *Beer) AvailableFrom() *time.Time {
+	return b.availableFrom
+}
+
+// AvailableUntil 提供終了日を取得する
+func (b *Beer) AvailableUntil() *time.Time {
+	return b.availableUntil
+}
+
+// AverageRating 訪問記録の評価の平均を取得する
+func (b *Beer) AverageRating() *float64 {
+	return b.averageRating
+}
+
+// RatingCount 評価の件数を取得する
+func (b *Beer) RatingCount() int {
+	return b.ratingCount
+}
+
+// CreatedAt 作成日時を取得する
+func (b *Beer) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// UpdatedAt 更新日時を取得する
+func (b *Beer) UpdatedAt() time.Time {
+	return b.updatedAt
+}
+
+// IsAvailableOn 指定日に提供期間内かどうかを判定する（提供期間は日付単位で両端を含む）
+func (b *Beer) IsAvailableOn(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if b.availableFrom != nil && day.Before(*b.availableFrom) {
+		return false
+	}
+	if b.availableUntil != nil && day.After(*b.availableUntil) {
+		return false
+	}
+	return true
+}
+
+// validate ビールのバリデーションを実行する
+func (b *Beer) validate() error {
+	if b.breweryID <= 0 {
+		return domainerr.Invalid("brewery ID must be positive")
+	}
+	if b.name == "" {
+		return domainerr.Invalid("beer name is required")
+	}
+	if len(b.name) > 255 {
+		return domainerr.Invalid("beer name must be 255 characters or less")
+	}
+	if len(b.style) > 100 {
+		return domainerr.Invalid("beer style must be 100 characters or less")
+	}
+	if b.abv != nil && (*b.abv < 0 || *b.abv > 100 || math.IsNaN(*b.abv)) {
+		return domainerr.Invalid("invalid ABV: must be between 0 and 100")
+	}
+	if b.ibu != nil && (*b.ibu < 0 || *b.ibu > 1000) {
+		return domainerr.Invalid("invalid IBU: must be between 0 and 1000")
+	}
+	if b.availableFrom != nil && b.availableUntil != nil && b.availableUntil.Before(*b.availableFrom) {
+		return domainerr.Invalid("available_until must not be before available_from")
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package entity
+
+import (
+	"math"
+	"mybeerlog/domain/domainerr"
+	"strings"
+	"time"
+)
+
+// 提供形態
+const (
+	ServingTypeDraft  = "draft"
+	ServingTypeBottle = "bottle"
+	ServingTypeCan    = "can"
+	ServingTypeCask   = "cask"
+	ServingTypeFlight = "flight"
+	ServingTypeOther  = "other"
+)
+
+// 評価の範囲と刻み
+const (
+	BeerRatingMin  = 0.0
+	BeerRatingMax  = 5.0
+	BeerRatingStep = 0.25
+)
+
+// フレーバータグの制限
+const (
+	MaxFlavorTags      = 10
+	MaxFlavorTagLength = 30
+)
+
+// IsValidServingType 有効な提供形態かどうかを判定する
+func IsValidServingType(servingType string) bool {
+	switch servingType {
+	case ServingTypeDraft, ServingTypeBottle, ServingTypeCan, ServingTypeCask, ServingTypeFlight, ServingTypeOther:
+		return true
+	}
+	return false
+}
+
+// BeerLog は訪問中に飲んだビールの記録を表す
+// カタログのビールを参照するか、カタログにないビールは名前のみを記録する
+type BeerLog struct {
+	id          int
+	visitID     int
+	beerID      *int
+	beerName    string
+	servingType string
+	rating      *float64
+	notes       string
+	flavorTags  []string
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// BeerLogBuilder はBeerLogインスタンスの作成を支援する
+type BeerLogBuilder struct {
+	beerLog *BeerLog
+}
+
+// NewBeerLogBuilder 新しいBeerLogBuilderを作成する
+func NewBeerLogBuilder() *BeerLogBuilder {
+	return &BeerLogBuilder{
+		beerLog: &BeerLog{
+			flavorTags: []string{},
+			createdAt:  time.Now(),
+			updatedAt:  time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *BeerLogBuilder) WithID(id int) *BeerLogBuilder {
+	b.beerLog.id = id
+	return b
+}
+
+// WithVisitID 訪問IDを設定する
+func (b *BeerLogBuilder) WithVisitID(visitID int) *BeerLogBuilder {
+	b.beerLog.visitID = visitID
+	return b
+}
+
+// WithBeerID カタログのビールIDを設定する
+func (b *BeerLogBuilder) WithBeerID(beerID *int) *BeerLogBuilder {
+	b.beerLog.beerID = beerID
+	return b
+}
+
+// WithBeerName ビール名を設定する
+func (b *BeerLogBuilder) WithBeerName(beerName string) *BeerLogBuilder {
+	b.beerLog.beerName = strings.TrimSpace(beerName)
+	return b
+}
+
+// WithServingType 提供形態を設定する
+func (b *BeerLogBuilder) WithServingType(servingType string) *BeerLogBuilder {
+	b.beerLog.servingType = strings.ToLower(strings.TrimSpace(servingType))
+	return b
+}
+
+// WithRating 評価を設定する
+func (b *BeerLogBuilder) WithRating(rating *float64) *BeerLogBuilder {
+	b.beerLog.rating = rating
+	return b
+}
+
+// WithNotes テイスティングノートを設定する
+func (b *BeerLogBuilder) WithNotes(notes string) *BeerLogBuilder {
+	b.beerLog.notes = strings.TrimSpace(notes)
+	return b
+}
+
+// WithFlavorTags フレーバータグを設定する（小文字に正規化し、重複と空のタグを除く）
+func (b *BeerLogBuilder) WithFlavorTags(tags []string) *BeerLogBuilder {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	b.beerLog.flavorTags = normalized
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *BeerLogBuilder) WithCreatedAt(createdAt time.Time) *BeerLogBuilder {
+	b.beerLog.createdAt = createdAt
+	return b
+}
+
+// WithUpdatedAt 更新日時を設定する
+func (b *BeerLogBuilder) WithUpdatedAt(updatedAt time.Time) *BeerLogBuilder {
+	b.beerLog.updatedAt = updatedAt
+	return b
+}
+
+// Build BeerLogインスタンスを作成する
+func (b *BeerLogBuilder) Build() (*BeerLog, error) {
+	if err := b.beerLog.validate(); err != nil {
+		return nil, err
+	}
+	return b.beerLog, nil
+}
+
+// ID IDを取得する
+func (l *BeerLog) ID() int {
+	return l.id
+}
+
+// VisitID 訪問IDを取得する
+func (l *BeerLog) VisitID() int {
+	return l.visitID
+}
+
+// BeerID カタログのビールIDを取得する（カタログにないビールの場合は nil）
+func (l *BeerLog) BeerID() *int {
+	return l.beerID
+}
+
+// BeerName ビール名を取得する
+func (l *BeerLog) BeerName() string {
+	return l.beerName
+}
+
+// ServingType 提供形態を取得する
+func (l *BeerLog) ServingType() string {
+	return l.servingType
+}
+
+// Rating 評価を取得する（未評価の場合は nil）
+func (l *BeerLog) Rating() *float64 {
+	return l.rating
+}
+
+// Notes テイスティングノートを取得する
+func (l *BeerLog) Notes() string {
+	return l.notes
+}
+
+// FlavorTags フレーバータグを取得する
+func (l *BeerLog) FlavorTags() []string {
+	return l.flavorTags
+}
+
+// CreatedAt 作成日時を取得する
+func (l *BeerLog) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// UpdatedAt 更新日時を取得する
+func (l *BeerLog) UpdatedAt() time.Time {
+	return l.updatedAt
+}
+
+// validate ビールの記録のバリデーションを実行する
+func (l *BeerLog) validate() error {
+	if l.visitID <= 0 {
+		return domainerr.Invalid("visit ID must be positive")
+	}
+	if l.beerID != nil && *l.beerID <= 0 {
+		return domainerr.Invalid("beer ID must be positive")
+	}
+	if l.beerID == nil && l.beerName == "" {
+		return domainerr.Invalid("beer ID or beer name is required")
+	}
+	if len(l.beerName) > 255 {
+		return domainerr.Invalid("beer name must be 255 characters or less")
+	}
+	if l.servingType != "" && !IsValidServingType(l.servingType) {
+		return domainerr.Invalid("invalid serving type")
+	}
+	if l.rating != nil && !isValidBeerRating(*l.rating) {
+		return domainerr.Invalid("invalid rating: must be between 0 and 5 in steps of 0.25")
+	}
+	if len(l.notes) > 2000 {
+		return domainerr.Invalid("notes must be 2000 characters or less")
+	}
+	if len(l.flavorTags) > MaxFlavorTags {
+		return domainerr.Invalid("too many flavor tags")
+	}
+	for _, tag := range l.flavorTags {
+		if len(tag) > MaxFlavorTagLength || strings.Contains(tag, ",") {
+			return domainerr.Invalid("invalid flavor tag: " + tag)
+		}
+	}
+	return nil
+}
+
+// isValidBeerRating 評価が範囲内かつ 0.25 刻みかどうかを判定する
+func isValidBeerRating(rating float64) bool {
+	if math.IsNaN(rating) || rating < BeerRatingMin || rating > BeerRatingMax {
+		return false
+	}
+	steps := rating / BeerRatingStep
+	return math.Abs(steps-math.Round(steps)) < 1e-9
+}
@@ -0,0 +1,212 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"regexp"
+	"strings"
+)
+
+// MaxBeerStyleDepth スタイルの階層の深さの上限（ファミリー → スタイル → サブスタイル）
+const MaxBeerStyleDepth = 3
+
+// スタイルの階層
+const (
+	BeerStyleLevelFamily   = "family"
+	BeerStyleLevelStyle    = "style"
+	BeerStyleLevelSubstyle = "substyle"
+)
+
+// beerStyleCodePattern スタイルコードの形式（英小文字・数字・アンダースコア）
+var beerStyleCodePattern = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// BeerStyle はビアスタイルの分類を表す（例: IPA → ヘイジーIPA）
+type BeerStyle struct {
+	id        int
+	code      string
+	parentID  *int
+	nameJa    string
+	nameEn    string
+	sortOrder int
+	depth     int
+	children  []*BeerStyle
+}
+
+// BeerStyleBuilder はBeerStyleインスタンスの作成を支援する
+type BeerStyleBuilder struct {
+	style *BeerStyle
+}
+
+// NewBeerStyleBuilder 新しいBeerStyleBuilderを作成する
+func NewBeerStyleBuilder() *BeerStyleBuilder {
+	return &BeerStyleBuilder{
+		style: &BeerStyle{
+			children: []*BeerStyle{},
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *BeerStyleBuilder) WithID(id int) *BeerStyleBuilder {
+	b.style.id = id
+	return b
+}
+
+// WithCode コードを設定する
+func (b *BeerStyleBuilder) WithCode(code string) *BeerStyleBuilder {
+	b.style.code = strings.ToLower(strings.TrimSpace(code))
+	return b
+}
+
+// WithParentID 親スタイルのIDを設定する（ファミリーの場合は nil）
+func (b *BeerStyleBuilder) WithParentID(parentID *int) *BeerStyleBuilder {
+	b.style.parentID = parentID
+	return b
+}
+
+// WithNames 日本語名と英語名を設定する
+func (b *BeerStyleBuilder) WithNames(nameJa, nameEn string) *BeerStyleBuilder {
+	b.style.nameJa = strings.TrimSpace(nameJa)
+	b.style.nameEn = strings.TrimSpace(nameEn)
+	return b
+}
+
+// WithSortOrder 表示順を設定する
+func (b *BeerStyleBuilder) WithSortOrder(sortOrder int) *BeerStyleBuilder {
+	b.style.sortOrder = sortOrder
+	return b
+}
+
+// Build BeerStyleインスタンスを作成する
+func (b *BeerStyleBuilder) Build() (*BeerStyle, error) {
+	if err := b.style.validate(); err != nil {
+		return nil, err
+	}
+	return b.style, nil
+}
+
+// ID IDを取得する
+func (s *BeerStyle) ID() int {
+	return s.id
+}
+
+// Code コードを取得する
+func (s *BeerStyle) Code() string {
+	return s.code
+}
+
+// ParentID 親スタイルのIDを取得する
+func (s *BeerStyle) ParentID() *int {
+	return s.parentID
+}
+
+// NameJa 日本語名を取得する
+func (s *BeerStyle) NameJa() string {
+	return s.nameJa
+}
+
+// NameEn 英語名を取得する
+func (s *BeerStyle) NameEn() string {
+	return s.nameEn
+}
+
+// SortOrder 表示順を取得する
+func (s *BeerStyle) SortOrder() int {
+	return s.sortOrder
+}
+
+// Level 階層（family / style / substyle）を取得する（BeerStyleTree に含まれる場合のみ有効）
+func (s *BeerStyle) Level() string {
+	switch s.depth {
+	case 0:
+		return BeerStyleLevelFamily
+	case 1:
+		return BeerStyleLevelStyle
+	default:
+		return BeerStyleLevelSubstyle
+	}
+}
+
+// Children 子スタイルを取得する（BeerStyleTree に含まれる場合のみ設定される）
+func (s *BeerStyle) Children() []*BeerStyle {
+	return s.children
+}
+
+// validate ビアスタイルのバリデーションを実行する
+func (s *BeerStyle) validate() error {
+	if !beerStyleCodePattern.MatchString(s.code) {
+		return domainerr.Invalid("invalid style code: " + s.code)
+	}
+	if s.parentID != nil && *s.parentID <= 0 {
+		return domainerr.Invalid("parent style ID must be positive")
+	}
+	if s.nameJa == "" || s.nameEn == "" {
+		return domainerr.Invalid("style names in Japanese and English are required")
+	}
+	if len(s.nameJa) > 100 || len(s.nameEn) > 100 {
+		return domainerr.Invalid("style name must be 100 characters or less")
+	}
+	return nil
+}
+
+// BeerStyleTree はビアスタイルの木構造を表す
+type BeerStyleTree struct {
+	roots  []*BeerStyle
+	byCode map[string]*BeerStyle
+}
+
+// NewBeerStyleTree 表示順に並んだスタイルの一覧から木構造を組み立てる
+// 親が見つからないスタイル・深さの上限を超えるスタイルは木に含めない
+func NewBeerStyleTree(styles []*BeerStyle) *BeerStyleTree {
+	tree := &BeerStyleTree{
+		roots:  []*BeerStyle{},
+		byCode: make(map[string]*BeerStyle, len(styles)),
+	}
+
+	childrenByParent := make(map[int][]*BeerStyle, len(styles))
+	for _, style := range styles {
+		style.children = []*BeerStyle{}
+		if style.parentID == nil {
+			tree.roots = append(tree.roots, style)
+		} else {
+			childrenByParent[*style.parentID] = append(childrenByParent[*style.parentID], style)
+		}
+	}
+
+	var attach func(style *BeerStyle, depth int)
+	attach = func(style *BeerStyle, depth int) {
+		style.depth = depth
+		tree.byCode[style.code] = style
+		if depth+1 >= MaxBeerStyleDepth {
+			return
+		}
+		for _, child := range childrenByParent[style.id] {
+			style.children = append(style.children, child)
+			attach(child, depth+1)
+		}
+	}
+	for _, root := range tree.roots {
+		attach(root, 0)
+	}
+
+	return tree
+}
+
+// Roots 最上位のスタイル（ファミリー）を取得する
+func (t *BeerStyleTree) Roots() []*BeerStyle {
+	return t.roots
+}
+
+// FindByCode コードでスタイルを取得する
+func (t *BeerStyleTree) FindByCode(code string) (*BeerStyle, bool) {
+	style, ok := t.byCode[strings.ToLower(strings.TrimSpace(code))]
+	return style, ok
+}
+
+// SubtreeIDs 指定したスタイルと、その子孫のスタイルのIDを取得する
+func (t *BeerStyleTree) SubtreeIDs(style *BeerStyle) []int {
+	ids := []int{style.id}
+	for _, child := range style.children {
+		ids = append(ids, t.SubtreeIDs(child)...)
+	}
+	return ids
+}
@@ -3,20 +3,32 @@ package entity
 import (
 	"errors"
 	"math"
+	"mybeerlog/domain/domainerr"
 	"strings"
 	"time"
 )
 
+// 醸造所のステータス
+const (
+	BreweryStatusActive   = "active"
+	BreweryStatusArchived = "archived"
+)
+
 // Brewery はドメイン内の醇造所を表す
 type Brewery struct {
 	id          int
 	name        string
 	address     string
+	prefecture  string
 	description string
 	latitude    float64
 	longitude   float64
+	status      string
+	deletedAt   *time.Time
 	createdAt   time.Time
 	updatedAt   time.Time
+
+	wantToGoCount *int // 行きたいリストに登録しているユーザー数（集計していない場合は nil）
 }
 
 // BreweryBuilder はBreweryインスタンスの作成を支援する
@@ -28,6 +40,7 @@ type BreweryBuilder struct {
 func NewBreweryBuilder() *BreweryBuilder {
 	return &BreweryBuilder{
 		brewery: &Brewery{
+			status:    BreweryStatusActive,
 			createdAt: time.Now(),
 			updatedAt: time.Now(),
 		},
@@ -49,6 +62,15 @@ func (b *BreweryBuilder) WithName(name string) *BreweryBuilder {
 // WithAddress 住所を設定する
 func (b *BreweryBuilder) WithAddress(address string) *BreweryBuilder {
 	b.brewery.address = strings.TrimSpace(address)
+	b.brewery.prefecture = PrefectureFromAddress(b.brewery.address)
+	return b
+}
+
+// WithPrefecture 都道府県を設定する（空の場合は住所から抽出した値を維持する）
+func (b *BreweryBuilder) WithPrefecture(prefecture string) *BreweryBuilder {
+	if prefecture != "" {
+		b.brewery.prefecture = prefecture
+	}
 	return b
 }
 
@@ -65,6 +87,20 @@ func (b *BreweryBuilder) WithLocation(latitude, longitude float64) *BreweryBuild
 	return b
 }
 
+// WithStatus ステータスを設定する
+func (b *BreweryBuilder) WithStatus(status string) *BreweryBuilder {
+	if status != "" {
+		b.brewery.status = status
+	}
+	return b
+}
+
+// WithDeletedAt 削除（アーカイブ）日時を設定する
+func (b *BreweryBuilder) WithDeletedAt(deletedAt *time.Time) *BreweryBuilder {
+	b.brewery.deletedAt = deletedAt
+	return b
+}
+
 // WithCreatedAt 作成日時を設定する
 func (b *BreweryBuilder) WithCreatedAt(createdAt time.Time) *BreweryBuilder {
 	b.brewery.createdAt = createdAt
@@ -77,6 +113,12 @@ func (b *BreweryBuilder) WithUpdatedAt(updatedAt time.Time) *BreweryBuilder {
 	return b
 }
 
+// WithWantToGoCount 行きたいリストに登録しているユーザー数を設定する
+func (b *BreweryBuilder) WithWantToGoCount(count int) *BreweryBuilder {
+	b.brewery.wantToGoCount = &count
+	return b
+}
+
 // Build Breweryインスタンスを作成する
 func (b *BreweryBuilder) Build() (*Brewery, error) {
 	if err := b.brewery.validate(); err != nil {
@@ -100,6 +142,11 @@ func (b *Brewery) Address() string {
 	return b.address
 }
 
+// Prefecture 都道府県を取得する
+func (b *Brewery) Prefecture() string {
+	return b.prefecture
+}
+
 // Description 説明を取得する
 func (b *Brewery) Description() string {
 	return b.description
@@ -115,6 +162,21 @@ func (b *Brewery) Longitude() float64 {
 	return b.longitude
 }
 
+// Status ステータスを取得する
+func (b *Brewery) Status() string {
+	return b.status
+}
+
+// DeletedAt 削除（アーカイブ）日時を取得する
+func (b *Brewery) DeletedAt() *time.Time {
+	return b.deletedAt
+}
+
+// IsArchived 醸造所がアーカイブ（論理削除）されているかどうかを判定する
+func (b *Brewery) IsArchived() bool {
+	return b.status == BreweryStatusArchived || b.deletedAt != nil
+}
+
 // CreatedAt 作成日時を取得する
 func (b *Brewery) CreatedAt() time.Time {
 	return b.createdAt
@@ -125,6 +187,11 @@ func (b *Brewery) UpdatedAt() time.Time {
 	return b.updatedAt
 }
 
+// WantToGoCount 行きたいリストに登録しているユーザー数を取得する（集計していない場合は nil）
+func (b *Brewery) WantToGoCount() *int {
+	return b.wantToGoCount
+}
+
 // IsValid 醇造所が有効かどうかを判定する
 func (b *Brewery) IsValid() bool {
 	return b.validate() == nil
@@ -133,19 +200,25 @@ func (b *Brewery) IsValid() bool {
 // validate 醇造所のバリデーションを実行する
 func (b *Brewery) validate() error {
 	if strings.TrimSpace(b.name) == "" {
-		return errors.New("brewery name is required")
+		return domainerr.Invalid("brewery name is required")
 	}
 	if len(b.name) > 255 {
-		return errors.New("brewery name must be 255 characters or less")
+		return domainerr.Invalid("brewery name must be 255 characters or less")
 	}
 	if len(b.address) > 512 {
-		return errors.New("brewery address must be 512 characters or less")
+		return domainerr.Invalid("brewery address must be 512 characters or less")
+	}
+	if b.prefecture != "" && !IsValidPrefecture(b.prefecture) {
+		return domainerr.Invalid("invalid prefecture")
 	}
 	if !b.isValidLatitude(b.latitude) {
-		return errors.New("invalid latitude: must be between -90 and 90")
+		return domainerr.Invalid("invalid latitude: must be between -90 and 90")
 	}
 	if !b.isValidLongitude(b.longitude) {
-		return errors.New("invalid longitude: must be between -180 and 180")
+		return domainerr.Invalid("invalid longitude: must be between -180 and 180")
+	}
+	if b.status != BreweryStatusActive && b.status != BreweryStatusArchived {
+		return domainerr.Invalid("invalid brewery status")
 	}
 	return nil
 }
@@ -163,22 +236,28 @@ func (b *Brewery) isValidLongitude(lng float64) bool {
 // DistanceFrom 2点間の距離を計算する（ハーバサイン公式）
 func (b *Brewery) DistanceFrom(lat, lng float64) (float64, error) {
 	if !b.isValidLatitude(lat) || !b.isValidLongitude(lng) {
-		return 0, errors.New("invalid coordinates provided")
+		return 0, domainerr.Invalid("invalid coordinates provided")
 	}
 
-	const earthRadius = 6371000 // 地球の半径（メートル）
+	return GreatCircleDistance(b.latitude, b.longitude, lat, lng), nil
+}
 
-	lat1 := b.latitude * math.Pi / 180
-	lat2 := lat * math.Pi / 180
-	deltaLat := (lat - b.latitude) * math.Pi / 180
-	deltaLng := (lng - b.longitude) * math.Pi / 180
+// EarthRadiusMeters 距離計算に使用する地球の半径（メートル）
+const EarthRadiusMeters = 6371000.0
+
+// GreatCircleDistance 2点間の大円距離をメートル単位で計算する（ハーバサイン公式）
+func GreatCircleDistance(lat1, lng1, lat2, lng2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	deltaLat := (lat2 - lat1) * math.Pi / 180
+	deltaLng := (lng2 - lng1) * math.Pi / 180
 
 	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
-		math.Cos(lat1)*math.Cos(lat2)*
+		math.Cos(phi1)*math.Cos(phi2)*
 			math.Sin(deltaLng/2)*math.Sin(deltaLng/2)
 	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
 
-	return earthRadius * c, nil
+	return EarthRadiusMeters * c
 }
 
 // IsWithinCheckinRange チェックイン可能な距離内かどうかを判定する
@@ -195,3 +274,26 @@ func (b *Brewery) IsWithinCheckinRange(lat, lng float64, maxDistance float64) (b
 	return distance <= maxDistance, nil
 }
 
+// NearbyBrewery は検索地点からの距離付きの醸造所を表す
+type NearbyBrewery struct {
+	brewery   *Brewery
+	distanceM float64
+}
+
+// NewNearbyBrewery 距離付きの醸造所を作成する
+func NewNearbyBrewery(brewery *Brewery, distanceM float64) *NearbyBrewery {
+	return &NearbyBrewery{
+		brewery:   brewery,
+		distanceM: distanceM,
+	}
+}
+
+// Brewery 醸造所を返す
+func (n *NearbyBrewery) Brewery() *Brewery {
+	return n.brewery
+}
+
+// DistanceM 検索地点からの距離（メートル）を返す
+func (n *NearbyBrewery) DistanceM() float64 {
+	return n.distanceM
+}
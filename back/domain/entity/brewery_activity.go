@@ -0,0 +1,199 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"sort"
+	"time"
+)
+
+// アクティビティの種類
+const (
+	// ActivityTypeBreweryUpdated 醸造所のプロフィールが更新された
+	ActivityTypeBreweryUpdated = "brewery_updated"
+	// ActivityTypeVisitMilestone 醸造所の訪問数が節目の数に達した
+	ActivityTypeVisitMilestone = "visit_milestone"
+	// ActivityTypeNearbyBreweryAdded 醸造所の近くに新しい醸造所が登録された
+	ActivityTypeNearbyBreweryAdded = "nearby_brewery_added"
+)
+
+// VisitMilestones フィードに掲載する醸造所の訪問数の節目（昇順）
+var VisitMilestones = []int{10, 50, 100, 500, 1000, 5000, 10000}
+
+// ReachedVisitMilestone 訪問数が到達している最大の節目を返す（未到達の場合は 0）
+func ReachedVisitMilestone(visitCount int) int {
+	i := sort.SearchInts(VisitMilestones, visitCount+1)
+	if i == 0 {
+		return 0
+	}
+	return VisitMilestones[i-1]
+}
+
+// IsValidActivityType 有効なアクティビティの種類かどうかを判定する
+func IsValidActivityType(activityType string) bool {
+	switch activityType {
+	case ActivityTypeBreweryUpdated, ActivityTypeVisitMilestone, ActivityTypeNearbyBreweryAdded:
+		return true
+	}
+	return false
+}
+
+// BreweryActivity はフォローしている醸造所のフィードに掲載するアクティビティを表す
+type BreweryActivity struct {
+	id               int
+	breweryID        int
+	brewery          *Brewery
+	activityType     string
+	relatedBreweryID *int // nearby_brewery_added の場合の新しい醸造所
+	relatedBrewery   *Brewery
+	milestone        *int   // visit_milestone の場合の訪問数
+	dedupeKey        string // 同じ出来事を二重に掲載しないためのキー（空の場合は重複を許可する）
+	createdAt        time.Time
+}
+
+// BreweryActivityBuilder はBreweryActivityインスタンスの作成を支援する
+type BreweryActivityBuilder struct {
+	activity *BreweryActivity
+}
+
+// NewBreweryActivityBuilder 新しいBreweryActivityBuilderを作成する
+func NewBreweryActivityBuilder() *BreweryActivityBuilder {
+	return &BreweryActivityBuilder{
+		activity: &BreweryActivity{
+			createdAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *BreweryActivityBuilder) WithID(id int) *BreweryActivityBuilder {
+	b.activity.id = id
+	return b
+}
+
+// WithBreweryID 醸造所IDを設定する
+func (b *BreweryActivityBuilder) WithBreweryID(breweryID int) *BreweryActivityBuilder {
+	b.activity.breweryID = breweryID
+	return b
+}
+
+// WithBrewery 醸造所を設定する
+func (b *BreweryActivityBuilder) WithBrewery(brewery *Brewery) *BreweryActivityBuilder {
+	b.activity.brewery = brewery
+	if brewery != nil {
+		b.activity.breweryID = brewery.ID()
+	}
+	return b
+}
+
+// WithType アクティビティの種類を設定する
+func (b *BreweryActivityBuilder) WithType(activityType string) *BreweryActivityBuilder {
+	b.activity.activityType = activityType
+	return b
+}
+
+// WithRelatedBreweryID 関連する醸造所のIDを設定する
+func (b *BreweryActivityBuilder) WithRelatedBreweryID(relatedBreweryID *int) *BreweryActivityBuilder {
+	b.activity.relatedBreweryID = relatedBreweryID
+	return b
+}
+
+// WithRelatedBrewery 関連する醸造所を設定する
+func (b *BreweryActivityBuilder) WithRelatedBrewery(relatedBrewery *Brewery) *BreweryActivityBuilder {
+	b.activity.relatedBrewery = relatedBrewery
+	if relatedBrewery != nil {
+		id := relatedBrewery.ID()
+		b.activity.relatedBreweryID = &id
+	}
+	return b
+}
+
+// WithMilestone 訪問数の節目を設定する
+func (b *BreweryActivityBuilder) WithMilestone(milestone *int) *BreweryActivityBuilder {
+	b.activity.milestone = milestone
+	return b
+}
+
+// WithDedupeKey 重複防止キーを設定する
+func (b *BreweryActivityBuilder) WithDedupeKey(dedupeKey string) *BreweryActivityBuilder {
+	b.activity.dedupeKey = dedupeKey
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *BreweryActivityBuilder) WithCreatedAt(createdAt time.Time) *BreweryActivityBuilder {
+	b.activity.createdAt = createdAt
+	return b
+}
+
+// Build BreweryActivityインスタンスを作成する
+func (b *BreweryActivityBuilder) Build() (*BreweryActivity, error) {
+	if err := b.activity.validate(); err != nil {
+		return nil, err
+	}
+	return b.activity, nil
+}
+
+// ID IDを取得する
+func (a *BreweryActivity) ID() int {
+	return a.id
+}
+
+// BreweryID 醸造所IDを取得する
+func (a *BreweryActivity) BreweryID() int {
+	return a.breweryID
+}
+
+// Brewery 醸造所を取得する
+func (a *BreweryActivity) Brewery() *Brewery {
+	return a.brewery
+}
+
+// Type アクティビティの種類を取得する
+func (a *BreweryActivity) Type() string {
+	return a.activityType
+}
+
+// RelatedBreweryID 関連する醸造所のIDを取得する
+func (a *BreweryActivity) RelatedBreweryID() *int {
+	return a.relatedBreweryID
+}
+
+// RelatedBrewery 関連する醸造所を取得する
+func (a *BreweryActivity) RelatedBrewery() *Brewery {
+	return a.relatedBrewery
+}
+
+// Milestone 訪問数の節目を取得する
+func (a *BreweryActivity) Milestone() *int {
+	return a.milestone
+}
+
+// DedupeKey 重複防止キーを取得する
+func (a *BreweryActivity) DedupeKey() string {
+	return a.dedupeKey
+}
+
+// CreatedAt 作成日時を取得する
+func (a *BreweryActivity) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// validate アクティビティのバリデーションを実行する
+func (a *BreweryActivity) validate() error {
+	if a.breweryID <= 0 {
+		return domainerr.Invalid("brewery ID must be positive")
+	}
+	if !IsValidActivityType(a.activityType) {
+		return domainerr.Invalid("invalid activity type")
+	}
+	if a.activityType == ActivityTypeVisitMilestone && (a.milestone == nil || *a.milestone <= 0) {
+		return domainerr.Invalid("milestone is required for visit milestone activity")
+	}
+	if a.activityType == ActivityTypeNearbyBreweryAdded && (a.relatedBreweryID == nil || *a.relatedBreweryID <= 0) {
+		return domainerr.Invalid("related brewery is required for nearby brewery activity")
+	}
+	if len(a.dedupeKey) > 100 {
+		return domainerr.Invalid("dedupe key must be 100 characters or less")
+	}
+	return nil
+}
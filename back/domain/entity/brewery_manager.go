@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"time"
+)
+
+// BreweryManager はユーザーと管理対象の醸造所の紐付けを表す
+type BreweryManager struct {
+	id            int
+	userProfileID int
+	userProfile   *UserProfile
+	breweryID     int
+	brewery       *Brewery
+	createdAt     time.Time
+}
+
+// BreweryManagerBuilder はBreweryManagerインスタンスの作成を支援する
+type BreweryManagerBuilder struct {
+	breweryManager *BreweryManager
+}
+
+// NewBreweryManagerBuilder 新しいBreweryManagerBuilderを作成する
+func NewBreweryManagerBuilder() *BreweryManagerBuilder {
+	return &BreweryManagerBuilder{
+		breweryManager: &BreweryManager{
+			createdAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *BreweryManagerBuilder) WithID(id int) *BreweryManagerBuilder {
+	b.breweryManager.id = id
+	return b
+}
+
+// WithUserProfileID ユーザープロファイルIDを設定する
+func (b *BreweryManagerBuilder) WithUserProfileID(userProfileID int) *BreweryManagerBuilder {
+	b.breweryManager.userProfileID = userProfileID
+	return b
+}
+
+// WithUserProfile ユーザープロファイルを設定する
+func (b *BreweryManagerBuilder) WithUserProfile(userProfile *UserProfile) *BreweryManagerBuilder {
+	b.breweryManager.userProfile = userProfile
+	if userProfile != nil {
+		b.breweryManager.userProfileID = userProfile.ID()
+	}
+	return b
+}
+
+// WithBreweryID 醸造所IDを設定する
+func (b *BreweryManagerBuilder) WithBreweryID(breweryID int) *BreweryManagerBuilder {
+	b.breweryManager.breweryID = breweryID
+	return b
+}
+
+// WithBrewery 醸造所を設定する
+func (b *BreweryManagerBuilder) WithBrewery(brewery *Brewery) *BreweryManagerBuilder {
+	b.breweryManager.brewery = brewery
+	if brewery != nil {
+		b.breweryManager.breweryID = brewery.ID()
+	}
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *BreweryManagerBuilder) WithCreatedAt(createdAt time.Time) *BreweryManagerBuilder {
+	b.breweryManager.createdAt = createdAt
+	return b
+}
+
+// Build BreweryManagerインスタンスを作成する
+func (b *BreweryManagerBuilder) Build() (*BreweryManager, error) {
+	if err := b.breweryManager.validate(); err != nil {
+		return nil, err
+	}
+	return b.breweryManager, nil
+}
+
+// ID IDを取得する
+func (m *BreweryManager) ID() int {
+	return m.id
+}
+
+// UserProfileID ユーザープロファイルIDを取得する
+func (m *BreweryManager) UserProfileID() int {
+	return m.userProfileID
+}
+
+// UserProfile ユーザープロファイルを取得する
+func (m *BreweryManager) UserProfile() *UserProfile {
+	return m.userProfile
+}
+
+// BreweryID 醸造所IDを取得する
+func (m *BreweryManager) BreweryID() int {
+	return m.breweryID
+}
+
+// Brewery 醸造所を取得する
+func (m *BreweryManager) Brewery() *Brewery {
+	return m.brewery
+}
+
+// CreatedAt 作成日時を取得する
+func (m *BreweryManager) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// validate 醸造所管理者のバリデーションを実行する
+func (m *BreweryManager) validate() error {
+	if m.userProfileID <= 0 {
+		return domainerr.Invalid("user profile ID must be positive")
+	}
+	if m.breweryID <= 0 {
+		return domainerr.Invalid("brewery ID must be positive")
+	}
+	return nil
+}
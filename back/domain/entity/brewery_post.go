@@ -0,0 +1,265 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 投稿の公開範囲
+const (
+	PostVisibilityPublic    = "public"
+	PostVisibilityFollowers = "followers"
+	PostVisibilityFanRank   = "fan_rank"
+)
+
+// 投稿の制限
+const (
+	MaxPostTitleLength    = 200
+	MaxPostBodyLength     = 20000
+	MaxPostImages         = 4
+	MaxPostImageURLLength = 512
+)
+
+// IsValidPostVisibility 有効な公開範囲かどうかを判定する
+func IsValidPostVisibility(visibility string) bool {
+	switch visibility {
+	case PostVisibilityPublic, PostVisibilityFollowers, PostVisibilityFanRank:
+		return true
+	}
+	return false
+}
+
+// BreweryPost は醸造所管理者がファンに向けて発信する投稿を表す
+type BreweryPost struct {
+	id                  int
+	breweryID           int
+	authorUserProfileID *int // 投稿者（退会した場合は nil）
+	title               string
+	body                string // Markdown
+	imageURLs           []string
+	publishAt           time.Time // 公開日時（未来の場合は予約投稿）
+	isPinned            bool
+	visibility          string
+	minFanRank          string // 公開範囲が fan_rank の場合に必要なファンランク
+	createdAt           time.Time
+	updatedAt           time.Time
+}
+
+// BreweryPostBuilder はBreweryPostインスタンスの作成を支援する
+type BreweryPostBuilder struct {
+	post *BreweryPost
+}
+
+// NewBreweryPostBuilder 新しいBreweryPostBuilderを作成する
+func NewBreweryPostBuilder() *BreweryPostBuilder {
+	now := time.Now()
+	return &BreweryPostBuilder{
+		post: &BreweryPost{
+			imageURLs:  []string{},
+			publishAt:  now,
+			visibility: PostVisibilityPublic,
+			createdAt:  now,
+			updatedAt:  now,
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *BreweryPostBuilder) WithID(id int) *BreweryPostBuilder {
+	b.post.id = id
+	return b
+}
+
+// WithBreweryID 醸造所IDを設定する
+func (b *BreweryPostBuilder) WithBreweryID(breweryID int) *BreweryPostBuilder {
+	b.post.breweryID = breweryID
+	return b
+}
+
+// WithAuthorUserProfileID 投稿者のユーザープロファイルIDを設定する
+func (b *BreweryPostBuilder) WithAuthorUserProfileID(authorUserProfileID *int) *BreweryPostBuilder {
+	b.post.authorUserProfileID = authorUserProfileID
+	return b
+}
+
+// WithTitle タイトルを設定する
+func (b *BreweryPostBuilder) WithTitle(title string) *BreweryPostBuilder {
+	b.post.title = strings.TrimSpace(title)
+	return b
+}
+
+// WithBody 本文（Markdown）を設定する
+func (b *BreweryPostBuilder) WithBody(body string) *BreweryPostBuilder {
+	b.post.body = strings.TrimSpace(body)
+	return b
+}
+
+// WithImageURLs 画像のURLを設定する（空のURLを除く）
+func (b *BreweryPostBuilder) WithImageURLs(imageURLs []string) *BreweryPostBuilder {
+	normalized := make([]string, 0, len(imageURLs))
+	for _, imageURL := range imageURLs {
+		if imageURL = strings.TrimSpace(imageURL); imageURL != "" {
+			normalized = append(normalized, imageURL)
+		}
+	}
+	b.post.imageURLs = normalized
+	return b
+}
+
+// WithPublishAt 公開日時を設定する
+func (b *BreweryPostBuilder) WithPublishAt(publishAt time.Time) *BreweryPostBuilder {
+	b.post.publishAt = publishAt
+	return b
+}
+
+// WithPinned 固定表示するかどうかを設定する
+func (b *BreweryPostBuilder) WithPinned(isPinned bool) *BreweryPostBuilder {
+	b.post.isPinned = isPinned
+	return b
+}
+
+// WithVisibility 公開範囲を設定する（fan_rank 以外の場合は必要なファンランクを設定しない）
+func (b *BreweryPostBuilder) WithVisibility(visibility, minFanRank string) *BreweryPostBuilder {
+	b.post.visibility = strings.ToLower(strings.TrimSpace(visibility))
+	b.post.minFanRank = ""
+	if b.post.visibility == PostVisibilityFanRank {
+		b.post.minFanRank = strings.ToLower(strings.TrimSpace(minFanRank))
+	}
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *BreweryPostBuilder) WithCreatedAt(createdAt time.Time) *BreweryPostBuilder {
+	b.post.createdAt = createdAt
+	return b
+}
+
+// WithUpdatedAt 更新日時を設定する
+func (b *BreweryPostBuilder) WithUpdatedAt(updatedAt time.Time) *BreweryPostBuilder {
+	b.post.updatedAt = updatedAt
+	return b
+}
+
+// Build BreweryPostインスタンスを作成する
+func (b *BreweryPostBuilder) Build() (*BreweryPost, error) {
+	if err := b.post.validate(); err != nil {
+		return nil, err
+	}
+	return b.post, nil
+}
+
+// ID IDを取得する
+func (p *BreweryPost) ID() int {
+	return p.id
+}
+
+// BreweryID 醸造所IDを取得する
+func (p *BreweryPost) BreweryID() int {
+	return p.breweryID
+}
+
+// AuthorUserProfileID 投稿者のユーザープロファイルIDを取得する
+func (p *BreweryPost) AuthorUserProfileID() *int {
+	return p.authorUserProfileID
+}
+
+// Title タイトルを取得する
+func (p *BreweryPost) Title() string {
+	return p.title
+}
+
+// Body 本文（Markdown）を取得する
+func (p *BreweryPost) Body() string {
+	return p.body
+}
+
+// ImageURLs 画像のURLを取得する
+func (p *BreweryPost) ImageURLs() []string {
+	return p.imageURLs
+}
+
+// PublishAt 公開日時を取得する
+func (p *BreweryPost) PublishAt() time.Time {
+	return p.publishAt
+}
+
+// IsPinned 固定表示するかどうかを取得する
+func (p *BreweryPost) IsPinned() bool {
+	return p.isPinned
+}
+
+// Visibility 公開範囲を取得する
+func (p *BreweryPost) Visibility() string {
+	return p.visibility
+}
+
+// MinFanRank 閲覧に必要なファンランクを取得する（公開範囲が fan_rank 以外の場合は空）
+func (p *BreweryPost) MinFanRank() string {
+	return p.minFanRank
+}
+
+// CreatedAt 作成日時を取得する
+func (p *BreweryPost) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// UpdatedAt 更新日時を取得する
+func (p *BreweryPost) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// IsPublishedAt 指定日時に公開済みかどうかを判定する
+func (p *BreweryPost) IsPublishedAt(t time.Time) bool {
+	return !p.publishAt.After(t)
+}
+
+// validate 投稿のバリデーションを実行する
+func (p *BreweryPost) validate() error {
+	if p.breweryID <= 0 {
+		return domainerr.Invalid("brewery ID must be positive")
+	}
+	if p.title == "" {
+		return domainerr.Invalid("title is required")
+	}
+	if len([]rune(p.title)) > MaxPostTitleLength {
+		return domainerr.Invalid("title must be 200 characters or less")
+	}
+	if p.body == "" {
+		return domainerr.Invalid("body is required")
+	}
+	if len([]rune(p.body)) > MaxPostBodyLength {
+		return domainerr.Invalid("body must be 20000 characters or less")
+	}
+	if len(p.imageURLs) > MaxPostImages {
+		return domainerr.Invalid("too many images: up to 4 images are allowed")
+	}
+	for _, imageURL := range p.imageURLs {
+		if !isValidImageURL(imageURL) {
+			return domainerr.Invalid("invalid image URL: must be an https URL of 512 characters or less")
+		}
+	}
+	if p.publishAt.IsZero() {
+		return domainerr.Invalid("publish_at is required")
+	}
+	if !IsValidPostVisibility(p.visibility) {
+		return domainerr.Invalid("invalid visibility")
+	}
+	if p.visibility == PostVisibilityFanRank && !IsValidFanRank(p.minFanRank) {
+		return domainerr.Invalid("invalid min_fan_rank")
+	}
+	return nil
+}
+
+// isValidImageURL 画像のURLが https の絶対URLかどうかを判定する
+func isValidImageURL(imageURL string) bool {
+	if len(imageURL) > MaxPostImageURLLength {
+		return false
+	}
+	parsed, err := url.Parse(imageURL)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == "https" && parsed.Host != ""
+}
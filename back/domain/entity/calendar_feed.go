@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"mybeerlog/domain/domainerr"
+	"time"
+)
+
+// calendarTokenBytes 購読URLのトークンの乱数のバイト数
+const calendarTokenBytes = 32
+
+// GenerateCalendarToken 購読URL用の新しいトークンを生成する（URLにそのまま使える形式）
+func GenerateCalendarToken() (string, error) {
+	buf := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashCalendarToken トークンを保存・照合用のハッシュに変換する（トークン自体は保存しない）
+func HashCalendarToken(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(digest[:])
+}
+
+// IsValidCalendarTokenFormat トークンの形式が正しいかどうかを判定する
+func IsValidCalendarTokenFormat(token string) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil && len(decoded) == calendarTokenBytes
+}
+
+// CalendarFeed はユーザーの訪問履歴のカレンダー購読設定を表す
+type CalendarFeed struct {
+	id            int
+	userProfileID int
+	tokenHash     string // 購読URLのトークンの SHA-256
+	rotatedAt     time.Time
+}
+
+// CalendarFeedBuilder はCalendarFeedインスタンスの作成を支援する
+type CalendarFeedBuilder struct {
+	feed *CalendarFeed
+}
+
+// NewCalendarFeedBuilder 新しいCalendarFeedBuilderを作成する
+func NewCalendarFeedBuilder() *CalendarFeedBuilder {
+	return &CalendarFeedBuilder{
+		feed: &CalendarFeed{
+			rotatedAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *CalendarFeedBuilder) WithID(id int) *CalendarFeedBuilder {
+	b.feed.id = id
+	return b
+}
+
+// WithUserProfileID ユーザープロファイルIDを設定する
+func (b *CalendarFeedBuilder) WithUserProfileID(userProfileID int) *CalendarFeedBuilder {
+	b.feed.userProfileID = userProfileID
+	return b
+}
+
+// WithTokenHash トークンのハッシュを設定する
+func (b *CalendarFeedBuilder) WithTokenHash(tokenHash string) *CalendarFeedBuilder {
+	b.feed.tokenHash = tokenHash
+	return b
+}
+
+// WithRotatedAt トークンの発行日時を設定する
+func (b *CalendarFeedBuilder) WithRotatedAt(rotatedAt time.Time) *CalendarFeedBuilder {
+	b.feed.rotatedAt = rotatedAt
+	return b
+}
+
+// Build CalendarFeedインスタンスを作成する
+func (b *CalendarFeedBuilder) Build() (*CalendarFeed, error) {
+	if err := b.feed.validate(); err != nil {
+		return nil, err
+	}
+	return b.feed, nil
+}
+
+// ID IDを取得する
+func (f *CalendarFeed) ID() int {
+	return f.id
+}
+
+// UserProfileID ユーザープロファイルIDを取得する
+func (f *CalendarFeed) UserProfileID() int {
+	return f.userProfileID
+}
+
+// TokenHash トークンのハッシュを取得する
+func (f *CalendarFeed) TokenHash() string {
+	return f.tokenHash
+}
+
+// RotatedAt トークンの発行日時を取得する
+func (f *CalendarFeed) RotatedAt() time.Time {
+	return f.rotatedAt
+}
+
+// validate カレンダー購読設定のバリデーションを実行する
+func (f *CalendarFeed) validate() error {
+	if f.userProfileID <= 0 {
+		return domainerr.Invalid("user profile ID must be positive")
+	}
+	if len(f.tokenHash) != sha256.Size*2 {
+		return domainerr.Invalid("invalid token hash")
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"mybeerlog/domain/domainerr"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// checkinSecretBytes 醸造所ごとのQRチェックイン用シークレットの乱数のバイト数
+	checkinSecretBytes = 32
+	// checkinCodeBytes QRコードに埋め込むコードに使用するHMACの先頭バイト数
+	checkinCodeBytes = 10
+)
+
+// checkinCodeEncoding コードの符号化方式（QRコードの英数字モードで表せるよう大文字・パディングなしとする）
+var checkinCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateCheckinSecret QRチェックイン用の新しいシークレットを生成する（16進文字列）
+func GenerateCheckinSecret() (string, error) {
+	buf := make([]byte, checkinSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// ParseCheckinToken QRコードから読み取ったトークン（"<醸造所ID>.<コード>"）を醸造所IDとコードに分解する
+func ParseCheckinToken(token string) (int, string, error) {
+	breweryPart, code, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return 0, "", domainerr.ErrInvalidCheckinCode
+	}
+	breweryID, err := strconv.Atoi(breweryPart)
+	if err != nil || breweryID <= 0 {
+		return 0, "", domainerr.ErrInvalidCheckinCode
+	}
+	code = strings.ToUpper(code)
+	if decoded, err := checkinCodeEncoding.DecodeString(code); err != nil || len(decoded) != checkinCodeBytes {
+		return 0, "", domainerr.ErrInvalidCheckinCode
+	}
+	return breweryID, code, nil
+}
+
+// CheckinCode は醸造所に掲示する、一定時間ごとに切り替わるQRチェックイン用のコードを表す
+type CheckinCode struct {
+	breweryID int
+	token     string
+	issuedAt  time.Time
+	expiresAt time.Time
+}
+
+// BreweryID 醸造所IDを取得する
+func (c *CheckinCode) BreweryID() int {
+	return c.breweryID
+}
+
+// Token QRコードに埋め込むトークンを取得する
+func (c *CheckinCode) Token() string {
+	return c.token
+}
+
+// IssuedAt コードの有効期間の開始日時を取得する
+func (c *CheckinCode) IssuedAt() time.Time {
+	return c.issuedAt
+}
+
+// ExpiresAt コードが切り替わる日時を取得する
+func (c *CheckinCode) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+// CheckinSecret は醸造所ごとのQRチェックイン用シークレットを表す
+// コードは TOTP と同様に、シークレットと period ごとの時刻ステップから HMAC-SHA256 で算出する
+type CheckinSecret struct {
+	id        int
+	breweryID int
+	secret    string // 16進文字列
+	rotatedAt time.Time
+}
+
+// CheckinSecretBuilder はCheckinSecretインスタンスの作成を支援する
+type CheckinSecretBuilder struct {
+	secret *CheckinSecret
+}
+
+// NewCheckinSecretBuilder 新しいCheckinSecretBuilderを作成する
+func NewCheckinSecretBuilder() *CheckinSecretBuilder {
+	return &CheckinSecretBuilder{
+		secret: &CheckinSecret{
+			rotatedAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *CheckinSecretBuilder) WithID(id int) *CheckinSecretBuilder {
+	b.secret.id = id
+	return b
+}
+
+// WithBreweryID 醸造所IDを設定する
+func (b *CheckinSecretBuilder) WithBreweryID(breweryID int) *CheckinSecretBuilder {
+	b.secret.breweryID = breweryID
+	return b
+}
+
+// WithSecret シークレットを設定する
+func (b *CheckinSecretBuilder) WithSecret(secret string) *CheckinSecretBuilder {
+	b.secret.secret = secret
+	return b
+}
+
+// WithRotatedAt シークレットの発行日時を設定する
+func (b *CheckinSecretBuilder) WithRotatedAt(rotatedAt time.Time) *CheckinSecretBuilder {
+	b.secret.rotatedAt = rotatedAt
+	return b
+}
+
+// Build CheckinSecretインスタンスを作成する
+func (b *CheckinSecretBuilder) Build() (*CheckinSecret, error) {
+	if err := b.secret.validate(); err != nil {
+		return nil, err
+	}
+	return b.secret, nil
+}
+
+// ID IDを取得する
+func (s *CheckinSecret) ID() int {
+	return s.id
+}
+
+// BreweryID 醸造所IDを取得する
+func (s *CheckinSecret) BreweryID() int {
+	return s.breweryID
+}
+
+// Secret シークレットを取得する
+func (s *CheckinSecret) Secret() string {
+	return s.secret
+}
+
+// RotatedAt シークレットの発行日時を取得する
+func (s *CheckinSecret) RotatedAt() time.Time {
+	return s.rotatedAt
+}
+
+// CodeAt 指定日時に有効なコードを発行する
+func (s *CheckinSecret) CodeAt(at time.Time, period time.Duration) *CheckinCode {
+	seconds := checkinPeriodSeconds(period)
+	step := at.Unix() / seconds
+	issuedAt := time.Unix(step*seconds, 0)
+	return &CheckinCode{
+		breweryID: s.breweryID,
+		token:     fmt.Sprintf("%d.%s", s.breweryID, s.codeForStep(step)),
+		issuedAt:  issuedAt,
+		expiresAt: issuedAt.Add(time.Duration(seconds) * time.Second),
+	}
+}
+
+// MatchStep コードが何ステップ前に発行されたものかを判定する
+// 現在から lookback ステップ前までのいずれとも一致しない場合は false を返す
+func (s *CheckinSecret) MatchStep(code string, at time.Time, period time.Duration, lookback int) (int, bool) {
+	current := at.Unix() / checkinPeriodSeconds(period)
+	for offset := 0; offset <= lookback; offset++ {
+		expected := s.codeForStep(current - int64(offset))
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// codeForStep 時刻ステップに対応するコードを算出する
+func (s *CheckinSecret) codeForStep(step int64) string {
+	key, _ := hex.DecodeString(s.secret)
+	mac := hmac.New(sha256.New, key)
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac.Write(counter[:])
+	return checkinCodeEncoding.EncodeToString(mac.Sum(nil)[:checkinCodeBytes])
+}
+
+// checkinPeriodSeconds コードの切り替え間隔を秒単位で返す（1秒未満は1秒とする）
+func checkinPeriodSeconds(period time.Duration) int64 {
+	seconds := int64(period / time.Second)
+	if seconds <= 0 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// validate シークレットのバリデーションを実行する
+func (s *CheckinSecret) validate() error {
+	if s.breweryID <= 0 {
+		return domainerr.Invalid("brewery ID must be positive")
+	}
+	if decoded, err := hex.DecodeString(s.secret); err != nil || len(decoded) != checkinSecretBytes {
+		return domainerr.Invalid("invalid check-in secret")
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"errors"
+	"mybeerlog/domain/domainerr"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testCheckinSecret = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+// newTestCheckinSecret テスト用の固定のシークレットを作成する
+func newTestCheckinSecret(t *testing.T, breweryID int, secret string) *CheckinSecret {
+	t.Helper()
+	checkinSecret, err := NewCheckinSecretBuilder().
+		WithBreweryID(breweryID).
+		WithSecret(secret).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return checkinSecret
+}
+
+// checkinCodeOf トークンからコードの部分を取り出す
+func checkinCodeOf(t *testing.T, code *CheckinCode) string {
+	t.Helper()
+	_, value, err := ParseCheckinToken(code.Token())
+	if err != nil {
+		t.Fatalf("ParseCheckinToken(%q) error = %v", code.Token(), err)
+	}
+	return value
+}
+
+func TestCheckinSecretCodeAt(t *testing.T) {
+	secret := newTestCheckinSecret(t, 7, testCheckinSecret)
+	period := time.Minute
+	at := time.Date(2024, 5, 1, 12, 0, 30, 0, time.UTC)
+
+	code := secret.CodeAt(at, period)
+	if !strings.HasPrefix(code.Token(), "7.") {
+		t.Errorf("Token() = %q, want prefix %q", code.Token(), "7.")
+	}
+	if want := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC); !code.IssuedAt().Equal(want) {
+		t.Errorf("IssuedAt() = %v, want %v", code.IssuedAt(), want)
+	}
+	if want := time.Date(2024, 5, 1, 12, 1, 0, 0, time.UTC); !code.ExpiresAt().Equal(want) {
+		t.Errorf("ExpiresAt() = %v, want %v", code.ExpiresAt(), want)
+	}
+
+	if same := secret.CodeAt(at.Add(29*time.Second), period); same.Token() != code.Token() {
+		t.Errorf("code changed within the same period: %q -> %q", code.Token(), same.Token())
+	}
+	if next := secret.CodeAt(code.ExpiresAt(), period); next.Token() == code.Token() {
+		t.Errorf("code did not change at ExpiresAt(): %q", next.Token())
+	}
+}
+
+func TestCheckinSecretMatchStep(t *testing.T) {
+	secret := newTestCheckinSecret(t, 7, testCheckinSecret)
+	otherSecret := newTestCheckinSecret(t, 7, strings.Repeat("ab", checkinSecretBytes))
+	period := time.Minute
+	lookback := 1
+	now := time.Date(2024, 5, 1, 12, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		code       string
+		wantOffset int
+		wantOK     bool
+	}{
+		{name: "current code", code: checkinCodeOf(t, secret.CodeAt(now, period)), wantOffset: 0, wantOK: true},
+		{name: "code issued at the start of the current period", code: checkinCodeOf(t, secret.CodeAt(now.Add(-30*time.Second), period)), wantOffset: 0, wantOK: true},
+		{name: "previous code within lookback", code: checkinCodeOf(t, secret.CodeAt(now.Add(-period), period)), wantOffset: 1, wantOK: true},
+		{name: "expired code beyond lookback", code: checkinCodeOf(t, secret.CodeAt(now.Add(-2*period), period)), wantOK: false},
+		{name: "code from a clock running ahead", code: checkinCodeOf(t, secret.CodeAt(now.Add(period), period)), wantOK: false},
+		{name: "code from a clock running far behind", code: checkinCodeOf(t, secret.CodeAt(now.Add(-24*time.Hour), period)), wantOK: false},
+		{name: "code of another secret", code: checkinCodeOf(t, otherSecret.CodeAt(now, period)), wantOK: false},
+		{name: "lower case code", code: strings.ToLower(checkinCodeOf(t, secret.CodeAt(now, period))), wantOK: false},
+		{name: "empty code", code: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, ok := secret.MatchStep(tt.code, now, period, lookback)
+			if ok != tt.wantOK || (ok && offset != tt.wantOffset) {
+				t.Errorf("MatchStep() = %d, %v, want %d, %v", offset, ok, tt.wantOffset, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseCheckinToken(t *testing.T) {
+	secret := newTestCheckinSecret(t, 7, testCheckinSecret)
+	token := secret.CodeAt(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), time.Minute).Token()
+	_, code, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name          string
+		token         string
+		wantBreweryID int
+		wantCode      string
+		wantErr       bool
+	}{
+		{name: "valid token", token: token, wantBreweryID: 7, wantCode: code},
+		{name: "surrounding spaces and lower case", token: "  " + strings.ToLower(token) + "\n", wantBreweryID: 7, wantCode: code},
+		{name: "missing separator", token: "7" + code, wantErr: true},
+		{name: "non-numeric brewery id", token: "abc." + code, wantErr: true},
+		{name: "zero brewery id", token: "0." + code, wantErr: true},
+		{name: "negative brewery id", token: "-7." + code, wantErr: true},
+		{name: "truncated code", token: "7." + code[:len(code)-2], wantErr: true},
+		{name: "code is not base32", token: "7.!!!!!!!!!!!!!!!!", wantErr: true},
+		{name: "empty", token: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breweryID, code, err := ParseCheckinToken(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, domainerr.ErrInvalidCheckinCode) {
+					t.Errorf("ParseCheckinToken(%q) error = %v, want ErrInvalidCheckinCode", tt.token, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCheckinToken(%q) error = %v", tt.token, err)
+			}
+			if breweryID != tt.wantBreweryID || code != tt.wantCode {
+				t.Errorf("ParseCheckinToken(%q) = %d, %q, want %d, %q", tt.token, breweryID, code, tt.wantBreweryID, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCheckinSecretValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		breweryID int
+		secret    string
+		wantErr   bool
+	}{
+		{name: "valid", breweryID: 1, secret: testCheckinSecret},
+		{name: "missing brewery", breweryID: 0, secret: testCheckinSecret, wantErr: true},
+		{name: "secret is not hex", breweryID: 1, secret: strings.Repeat("zz", checkinSecretBytes), wantErr: true},
+		{name: "secret too short", breweryID: 1, secret: testCheckinSecret[:32], wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCheckinSecretBuilder().WithBreweryID(tt.breweryID).WithSecret(tt.secret).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
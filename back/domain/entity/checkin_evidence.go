@@ -0,0 +1,80 @@
+package entity
+
+import "mybeerlog/domain/domainerr"
+
+// CheckinEvidence はチェックイン時に送信・算出されたGPSの証跡を表す
+// 不正なチェックインの調査や、チェックイン許可半径の調整に使用する
+type CheckinEvidence struct {
+	latitude      float64
+	longitude     float64
+	accuracyM     *float64
+	distanceM     float64
+	radiusM       float64
+	clientVersion string
+}
+
+// NewCheckinEvidence 新しいチェックイン証跡を作成する
+func NewCheckinEvidence(latitude, longitude float64, accuracyM *float64, distanceM, radiusM float64, clientVersion string) (*CheckinEvidence, error) {
+	evidence := &CheckinEvidence{
+		latitude:      latitude,
+		longitude:     longitude,
+		accuracyM:     accuracyM,
+		distanceM:     distanceM,
+		radiusM:       radiusM,
+		clientVersion: clientVersion,
+	}
+	if err := evidence.validate(); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+// Latitude 送信された緯度を取得する
+func (e *CheckinEvidence) Latitude() float64 {
+	return e.latitude
+}
+
+// Longitude 送信された経度を取得する
+func (e *CheckinEvidence) Longitude() float64 {
+	return e.longitude
+}
+
+// AccuracyM 端末が報告したGPSの精度（メートル）を取得する（未報告の場合は nil）
+func (e *CheckinEvidence) AccuracyM() *float64 {
+	return e.accuracyM
+}
+
+// DistanceM 醸造所までの距離（メートル）を取得する
+func (e *CheckinEvidence) DistanceM() float64 {
+	return e.distanceM
+}
+
+// RadiusM 適用されたチェックイン許可半径（メートル）を取得する
+func (e *CheckinEvidence) RadiusM() float64 {
+	return e.radiusM
+}
+
+// ClientVersion クライアント（アプリ）のバージョンを取得する
+func (e *CheckinEvidence) ClientVersion() string {
+	return e.clientVersion
+}
+
+// validate チェックイン証跡のバリデーションを実行する
+func (e *CheckinEvidence) validate() error {
+	if e.latitude < -90 || e.latitude > 90 {
+		return domainerr.Invalid("invalid latitude: must be between -90 and 90")
+	}
+	if e.longitude < -180 || e.longitude > 180 {
+		return domainerr.Invalid("invalid longitude: must be between -180 and 180")
+	}
+	if e.accuracyM != nil && *e.accuracyM < 0 {
+		return domainerr.Invalid("gps accuracy must not be negative")
+	}
+	if e.distanceM < 0 || e.radiusM < 0 {
+		return domainerr.Invalid("distance and radius must not be negative")
+	}
+	if len(e.clientVersion) > 100 {
+		return domainerr.Invalid("client version must be 100 characters or less")
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"strings"
+	"time"
+)
+
+// イベントの制限
+const (
+	MaxEventTitleLength       = 200
+	MaxEventDescriptionLength = 5000
+	MaxEventLocationLength    = 255
+	MaxEventCapacity          = 10000
+	MaxEventDuration          = 14 * 24 * time.Hour
+)
+
+// Event は醸造所が開催するイベント（タップテイクオーバー、醸造体験、リリースパーティーなど）を表す
+type Event struct {
+	id            int
+	breweryID     int
+	brewery       *Brewery
+	title         string
+	description   string
+	location      string // 会場の補足（例: 2F タップルーム）。空の場合は醸造所
+	startAt       time.Time
+	endAt         time.Time
+	timeZone      string // IANA タイムゾーン名（例: Asia/Tokyo）
+	capacity      int
+	goingCount    int // 参加確定の人数
+	waitlistCount int // キャンセル待ちの人数
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// EventBuilder はEventインスタンスの作成を支援する
+type EventBuilder struct {
+	event *Event
+}
+
+// NewEventBuilder 新しいEventBuilderを作成する
+func NewEventBuilder() *EventBuilder {
+	return &EventBuilder{
+		event: &Event{
+			createdAt: time.Now(),
+			updatedAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *EventBuilder) WithID(id int) *EventBuilder {
+	b.event.id = id
+	return b
+}
+
+// WithBreweryID 醸造所IDを設定する
+func (b *EventBuilder) WithBreweryID(breweryID int) *EventBuilder {
+	b.event.breweryID = breweryID
+	return b
+}
+
+// WithBrewery 醸造所を設定する
+func (b *EventBuilder) WithBrewery(brewery *Brewery) *EventBuilder {
+	b.event.brewery = brewery
+	if brewery != nil {
+		b.event.breweryID = brewery.ID()
+	}
+	return b
+}
+
+// WithTitle タイトルを設定する
+func (b *EventBuilder) WithTitle(title string) *EventBuilder {
+	b.event.title = strings.TrimSpace(title)
+	return b
+}
+
+// WithDescription 説明を設定する
+func (b *EventBuilder) WithDescription(description string) *EventBuilder {
+	b.event.description = strings.TrimSpace(description)
+	return b
+}
+
+// WithLocation 会場の補足を設定する
+func (b *EventBuilder) WithLocation(location string) *EventBuilder {
+	b.event.location = strings.TrimSpace(location)
+	return b
+}
+
+// WithSchedule 開催期間とタイムゾーンを設定する
+func (b *EventBuilder) WithSchedule(startAt, endAt time.Time, timeZone string) *EventBuilder {
+	b.event.startAt = startAt
+	b.event.endAt = endAt
+	b.event.timeZone = strings.TrimSpace(timeZone)
+	return b
+}
+
+// WithCapacity 定員を設定する
+func (b *EventBuilder) WithCapacity(capacity int) *EventBuilder {
+	b.event.capacity = capacity
+	return b
+}
+
+// WithRSVPCounts 参加確定・キャンセル待ちの人数を設定する
+func (b *EventBuilder) WithRSVPCounts(goingCount, waitlistCount int) *EventBuilder {
+	b.event.goingCount = goingCount
+	b.event.waitlistCount = waitlistCount
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *EventBuilder) WithCreatedAt(createdAt time.Time) *EventBuilder {
+	b.event.createdAt = createdAt
+	return b
+}
+
+// WithUpdatedAt 更新日時を設定する
+func (b *EventBuilder) WithUpdatedAt(updatedAt time.Time) *EventBuilder {
+	b.event.updatedAt = updatedAt
+	return b
+}
+
+// Build Eventインスタンスを作成する
+func (b *EventBuilder) Build() (*Event, error) {
+	if err := b.event.validate(); err != nil {
+		return nil, err
+	}
+	return b.event, nil
+}
+
+// ID IDを取得する
+func (e *Event) ID() int {
+	return e.id
+}
+
+// BreweryID 醸造所IDを取得する
+func (e *Event) BreweryID() int {
+	return e.breweryID
+}
+
+// Brewery 醸造所を取得する
+func (e *Event) Brewery() *Brewery {
+	return e.brewery
+}
+
+// Title タイトルを取得する
+func (e *Event) Title() string {
+	return e.title
+}
+
+// Description 説明を取得する
+func (e *Event) Description() string {
+	return e.description
+}
+
+// Location 会場の補足を取得する
+func (e *Event) Location() string {
+	return e.location
+}
+
+// StartAt 開始日時を取得する
+func (e *Event) StartAt() time.Time {
+	return e.startAt
+}
+
+// EndAt 終了日時を取得する
+func (e *Event) EndAt() time.Time {
+	return e.endAt
+}
+
+// TimeZone タイムゾーン名を取得する
+func (e *Event) TimeZone() string {
+	return e.timeZone
+}
+
+// TimeLocation 開催地のタイムゾーンを取得する（不正な場合は UTC）
+func (e *Event) TimeLocation() *time.Location {
+	loc, err := time.LoadLocation(e.timeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Capacity 定員を取得する
+func (e *Event) Capacity() int {
+	return e.capacity
+}
+
+// GoingCount 参加確定の人数を取得する
+func (e *Event) GoingCount() int {
+	return e.goingCount
+}
+
+// WaitlistCount キャンセル待ちの人数を取得する
+func (e *Event) WaitlistCount() int {
+	return e.waitlistCount
+}
+
+// RemainingSeats 残席数を取得する
+func (e *Event) RemainingSeats() int {
+	if e.goingCount >= e.capacity {
+		return 0
+	}
+	return e.capacity - e.goingCount
+}
+
+// CreatedAt 作成日時を取得する
+func (e *Event) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// UpdatedAt 更新日時を取得する
+func (e *Event) UpdatedAt() time.Time {
+	return e.updatedAt
+}
+
+// HasEndedAt 指定日時に終了しているかどうかを判定する
+func (e *Event) HasEndedAt(t time.Time) bool {
+	return !t.Before(e.endAt)
+}
+
+// IsCheckinOpenAt 指定日時に参加のチェックインを受け付けているかどうかを判定する（開始前の受付時間を含む）
+func (e *Event) IsCheckinOpenAt(t time.Time, earlyCheckin time.Duration) bool {
+	return !t.Before(e.startAt.Add(-earlyCheckin)) && t.Before(e.endAt)
+}
+
+// validate イベントのバリデーションを実行する
+func (e *Event) validate() error {
+	if e.breweryID <= 0 {
+		return domainerr.Invalid("brewery ID must be positive")
+	}
+	if e.title == "" {
+		return domainerr.Invalid("title is required")
+	}
+	if len([]rune(e.title)) > MaxEventTitleLength {
+		return domainerr.Invalid("title must be 200 characters or less")
+	}
+	if len([]rune(e.description)) > MaxEventDescriptionLength {
+		return domainerr.Invalid("description must be 5000 characters or less")
+	}
+	if len([]rune(e.location)) > MaxEventLocationLength {
+		return domainerr.Invalid("location must be 255 characters or less")
+	}
+	if e.startAt.IsZero() || e.endAt.IsZero() {
+		return domainerr.Invalid("start_at and end_at are required")
+	}
+	if !e.endAt.After(e.startAt) {
+		return domainerr.Invalid("end_at must be after start_at")
+	}
+	if e.endAt.Sub(e.startAt) > MaxEventDuration {
+		return domainerr.Invalid("event must not be longer than 14 days")
+	}
+	if e.timeZone == "" || e.timeZone == "Local" {
+		return domainerr.Invalid("time_zone is required")
+	}
+	if _, err := time.LoadLocation(e.timeZone); err != nil {
+		return domainerr.Invalid("invalid time_zone: must be an IANA time zone name")
+	}
+	if e.capacity < 1 || e.capacity > MaxEventCapacity {
+		return domainerr.Invalid("invalid capacity: must be between 1 and 10000")
+	}
+	return nil
+}
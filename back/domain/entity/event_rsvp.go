@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"time"
+)
+
+// イベントの参加申込の状態
+const (
+	// RSVPStatusGoing 参加確定
+	RSVPStatusGoing = "going"
+	// RSVPStatusWaitlisted キャンセル待ち（参加確定者のキャンセル時に申込順に繰り上がる）
+	RSVPStatusWaitlisted = "waitlisted"
+)
+
+// IsValidRSVPStatus 有効な参加申込の状態かどうかを判定する
+func IsValidRSVPStatus(status string) bool {
+	return status == RSVPStatusGoing || status == RSVPStatusWaitlisted
+}
+
+// EventRSVP はユーザーのイベントへの参加申込を表す
+type EventRSVP struct {
+	id               int
+	eventID          int
+	userProfileID    int
+	status           string
+	waitlistPosition int        // キャンセル待ちの順番（1始まり、参加確定の場合は 0）
+	attendedAt       *time.Time // GPS チェックインで参加が確認された日時
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+// EventRSVPBuilder はEventRSVPインスタンスの作成を支援する
+type EventRSVPBuilder struct {
+	rsvp *EventRSVP
+}
+
+// NewEventRSVPBuilder 新しいEventRSVPBuilderを作成する
+func NewEventRSVPBuilder() *EventRSVPBuilder {
+	return &EventRSVPBuilder{
+		rsvp: &EventRSVP{
+			createdAt: time.Now(),
+			updatedAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *EventRSVPBuilder) WithID(id int) *EventRSVPBuilder {
+	b.rsvp.id = id
+	return b
+}
+
+// WithEventID イベントIDを設定する
+func (b *EventRSVPBuilder) WithEventID(eventID int) *EventRSVPBuilder {
+	b.rsvp.eventID = eventID
+	return b
+}
+
+// WithUserProfileID ユーザープロファイルIDを設定する
+func (b *EventRSVPBuilder) WithUserProfileID(userProfileID int) *EventRSVPBuilder {
+	b.rsvp.userProfileID = userProfileID
+	return b
+}
+
+// WithStatus 状態とキャンセル待ちの順番を設定する
+func (b *EventRSVPBuilder) WithStatus(status string, waitlistPosition int) *EventRSVPBuilder {
+	b.rsvp.status = status
+	b.rsvp.waitlistPosition = 0
+	if status == RSVPStatusWaitlisted {
+		b.rsvp.waitlistPosition = waitlistPosition
+	}
+	return b
+}
+
+// WithAttendedAt 参加が確認された日時を設定する
+func (b *EventRSVPBuilder) WithAttendedAt(attendedAt *time.Time) *EventRSVPBuilder {
+	b.rsvp.attendedAt = attendedAt
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *EventRSVPBuilder) WithCreatedAt(createdAt time.Time) *EventRSVPBuilder {
+	b.rsvp.createdAt = createdAt
+	return b
+}
+
+// WithUpdatedAt 更新日時を設定する
+func (b *EventRSVPBuilder) WithUpdatedAt(updatedAt time.Time) *EventRSVPBuilder {
+	b.rsvp.updatedAt = updatedAt
+	return b
+}
+
+// Build EventRSVPインスタンスを作成する
+func (b *EventRSVPBuilder) Build() (*EventRSVP, error) {
+	if err := b.rsvp.validate(); err != nil {
+		return nil, err
+	}
+	return b.rsvp, nil
+}
+
+// ID IDを取得する
+func (r *EventRSVP) ID() int {
+	return r.id
+}
+
+// EventID イベントIDを取得する
+func (r *EventRSVP) EventID() int {
+	return r.eventID
+}
+
+// UserProfileID ユーザープロファイルIDを取得する
+func (r *EventRSVP) UserProfileID() int {
+	return r.userProfileID
+}
+
+// Status 状態を取得する
+func (r *EventRSVP) Status() string {
+	return r.status
+}
+
+// IsGoing 参加確定かどうかを判定する
+func (r *EventRSVP) IsGoing() bool {
+	return r.status == RSVPStatusGoing
+}
+
+// WaitlistPosition キャンセル待ちの順番を取得する
+func (r *EventRSVP) WaitlistPosition() int {
+	return r.waitlistPosition
+}
+
+// AttendedAt 参加が確認された日時を取得する
+func (r *EventRSVP) AttendedAt() *time.Time {
+	return r.attendedAt
+}
+
+// CreatedAt 作成日時を取得する
+func (r *EventRSVP) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt 更新日時を取得する
+func (r *EventRSVP) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// validate 参加申込のバリデーションを実行する
+func (r *EventRSVP) validate() error {
+	if r.eventID <= 0 || r.userProfileID <= 0 {
+		return domainerr.Invalid("event ID and user profile ID must be positive")
+	}
+	if !IsValidRSVPStatus(r.status) {
+		return domainerr.Invalid("invalid rsvp status")
+	}
+	return nil
+}
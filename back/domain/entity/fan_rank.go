@@ -0,0 +1,39 @@
+package entity
+
+// ファンランク（醸造所への訪問回数で決まる）
+const (
+	FanRankVisitor = "visitor"
+	FanRankRegular = "regular"
+	FanRankLoyal   = "loyal"
+)
+
+// fanRankThresholds ファンランクと必要な訪問回数（昇順）
+var fanRankThresholds = []struct {
+	rank      string
+	minVisits int
+}{
+	{FanRankVisitor, 1},
+	{FanRankRegular, 3},
+	{FanRankLoyal, 10},
+}
+
+// IsValidFanRank 有効なファンランクかどうかを判定する
+func IsValidFanRank(rank string) bool {
+	for _, threshold := range fanRankThresholds {
+		if threshold.rank == rank {
+			return true
+		}
+	}
+	return false
+}
+
+// FanRanksForVisits 訪問回数で到達しているファンランクを全て返す（未訪問の場合は空）
+func FanRanksForVisits(visitCount int) []string {
+	ranks := []string{}
+	for _, threshold := range fanRankThresholds {
+		if visitCount >= threshold.minVisits {
+			ranks = append(ranks, threshold.rank)
+		}
+	}
+	return ranks
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"fmt"
+	"mybeerlog/domain/domainerr"
+	"time"
+)
+
+// MaxVisitPublishDelayHours 訪問の公開を遅らせることができる最大の時間数（1週間）
+const MaxVisitPublishDelayHours = 24 * 7
+
+// ProfilePrivacy はユーザーの公開プロフィールのプライバシー設定を表す
+type ProfilePrivacy struct {
+	public                 bool
+	hideVisitTimes         bool
+	visitPublishDelayHours int
+}
+
+// NewProfilePrivacy 新しいプライバシー設定を作成する
+// visitPublishDelayHours は訪問を公開プロフィールに表示するまでの時間数（訪問中の居場所を知られないようにするため）
+func NewProfilePrivacy(public, hideVisitTimes bool, visitPublishDelayHours int) (*ProfilePrivacy, error) {
+	privacy := &ProfilePrivacy{
+		public:                 public,
+		hideVisitTimes:         hideVisitTimes,
+		visitPublishDelayHours: visitPublishDelayHours,
+	}
+	if err := privacy.validate(); err != nil {
+		return nil, err
+	}
+	return privacy, nil
+}
+
+// DefaultProfilePrivacy 既定のプライバシー設定（非公開）を取得する
+func DefaultProfilePrivacy() *ProfilePrivacy {
+	return &ProfilePrivacy{}
+}
+
+// IsPublic プロフィールを公開するかどうかを取得する
+func (p *ProfilePrivacy) IsPublic() bool {
+	return p.public
+}
+
+// HideVisitTimes 公開プロフィールで訪問日時を伏せるかどうかを取得する
+func (p *ProfilePrivacy) HideVisitTimes() bool {
+	return p.hideVisitTimes
+}
+
+// VisitPublishDelayHours 訪問を公開プロフィールに表示するまでの時間数を取得する
+func (p *ProfilePrivacy) VisitPublishDelayHours() int {
+	return p.visitPublishDelayHours
+}
+
+// VisitPublishCutoff now の時点で公開プロフィールに表示できる訪問の日時の上限を取得する（この日時より前の訪問のみ表示できる）
+func (p *ProfilePrivacy) VisitPublishCutoff(now time.Time) time.Time {
+	return now.Add(-time.Duration(p.visitPublishDelayHours) * time.Hour)
+}
+
+// IsVisitPublished visitedAt の訪問を now の時点で公開プロフィールに表示できるかどうかを判定する
+func (p *ProfilePrivacy) IsVisitPublished(visitedAt, now time.Time) bool {
+	return visitedAt.Before(p.VisitPublishCutoff(now))
+}
+
+// validate プライバシー設定のバリデーションを実行する
+func (p *ProfilePrivacy) validate() error {
+	if p.visitPublishDelayHours < 0 || p.visitPublishDelayHours > MaxVisitPublishDelayHours {
+		return domainerr.Invalid(fmt.Sprintf("visit publish delay must be between 0 and %d hours", MaxVisitPublishDelayHours))
+	}
+	return nil
+}
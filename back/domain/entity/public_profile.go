@@ -0,0 +1,9 @@
+package entity
+
+// PublicProfile はハンドルで閲覧する公開プロフィール（プロフィールと公開済みの訪問）を表す
+// 訪問の公開を遅らせる設定の場合、VisitCount・RecentVisits にはまだ公開していない訪問を含めない
+type PublicProfile struct {
+	Profile      *UserProfile
+	VisitCount   int
+	RecentVisits []*Visit // 新しい順
+}
@@ -0,0 +1,245 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"time"
+)
+
+// スタンプラリーの制限
+const (
+	MaxRallyTitleLength       = 200
+	MaxRallyDescriptionLength = 5000
+	MaxRallyRewardLength      = 1000
+	MinRallyBreweries         = 2
+	MaxRallyBreweries         = 100
+)
+
+// Rally は複数の醸造所を巡るスタンプラリーを表す
+// 開催期間中に対象の醸造所のうち requiredCount か所でチェックインすると達成となる（例: 6 か所中 4 か所）
+type Rally struct {
+	id                int
+	title             string
+	description       string
+	breweryIDs        []int
+	breweries         []*Brewery
+	startAt           time.Time
+	endAt             time.Time
+	requiredCount     int
+	rewardDescription string // 達成特典の内容（例: オリジナルグラスをプレゼント）
+	participantCount  int
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+// RallyBuilder はRallyインスタンスの作成を支援する
+type RallyBuilder struct {
+	rally *Rally
+}
+
+// NewRallyBuilder 新しいRallyBuilderを作成する
+func NewRallyBuilder() *RallyBuilder {
+	return &RallyBuilder{
+		rally: &Rally{
+			createdAt: time.Now(),
+			updatedAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *RallyBuilder) WithID(id int) *RallyBuilder {
+	b.rally.id = id
+	return b
+}
+
+// WithTitle タイトルを設定する
+func (b *RallyBuilder) WithTitle(title string) *RallyBuilder {
+	b.rally.title = title
+	return b
+}
+
+// WithDescription 説明を設定する
+func (b *RallyBuilder) WithDescription(description string) *RallyBuilder {
+	b.rally.description = description
+	return b
+}
+
+// WithBreweryIDs 対象醸造所のIDを設定する
+func (b *RallyBuilder) WithBreweryIDs(breweryIDs []int) *RallyBuilder {
+	b.rally.breweryIDs = breweryIDs
+	return b
+}
+
+// WithBreweries 対象醸造所を設定する（醸造所のIDも合わせて設定する）
+func (b *RallyBuilder) WithBreweries(breweries []*Brewery) *RallyBuilder {
+	b.rally.breweries = breweries
+	b.rally.breweryIDs = make([]int, len(breweries))
+	for i, brewery := range breweries {
+		b.rally.breweryIDs[i] = brewery.ID()
+	}
+	return b
+}
+
+// WithPeriod 開催期間を設定する
+func (b *RallyBuilder) WithPeriod(startAt, endAt time.Time) *RallyBuilder {
+	b.rally.startAt = startAt
+	b.rally.endAt = endAt
+	return b
+}
+
+// WithRequiredCount 達成に必要なスタンプ数を設定する
+func (b *RallyBuilder) WithRequiredCount(requiredCount int) *RallyBuilder {
+	b.rally.requiredCount = requiredCount
+	return b
+}
+
+// WithRewardDescription 達成特典の内容を設定する
+func (b *RallyBuilder) WithRewardDescription(rewardDescription string) *RallyBuilder {
+	b.rally.rewardDescription = rewardDescription
+	return b
+}
+
+// WithParticipantCount 参加者数を設定する
+func (b *RallyBuilder) WithParticipantCount(participantCount int) *RallyBuilder {
+	b.rally.participantCount = participantCount
+	return b
+}
+
+// WithCreatedAt 作成日時を設定する
+func (b *RallyBuilder) WithCreatedAt(createdAt time.Time) *RallyBuilder {
+	b.rally.createdAt = createdAt
+	return b
+}
+
+// WithUpdatedAt 更新日時を設定する
+func (b *RallyBuilder) WithUpdatedAt(updatedAt time.Time) *RallyBuilder {
+	b.rally.updatedAt = updatedAt
+	return b
+}
+
+// Build Rallyインスタンスを作成する
+func (b *RallyBuilder) Build() (*Rally, error) {
+	if err := b.rally.validate(); err != nil {
+		return nil, err
+	}
+	return b.rally, nil
+}
+
+// ID IDを取得する
+func (r *Rally) ID() int {
+	return r.id
+}
+
+// Title タイトルを取得する
+func (r *Rally) Title() string {
+	return r.title
+}
+
+// Description 説明を取得する
+func (r *Rally) Description() string {
+	return r.description
+}
+
+// BreweryIDs 対象醸造所のIDを取得する
+func (r *Rally) BreweryIDs() []int {
+	return r.breweryIDs
+}
+
+// Breweries 対象醸造所を取得する（読み込んでいない場合は空）
+func (r *Rally) Breweries() []*Brewery {
+	return r.breweries
+}
+
+// StartAt 開始日時を取得する
+func (r *Rally) StartAt() time.Time {
+	return r.startAt
+}
+
+// EndAt 終了日時を取得する
+func (r *Rally) EndAt() time.Time {
+	return r.endAt
+}
+
+// RequiredCount 達成に必要なスタンプ数を取得する
+func (r *Rally) RequiredCount() int {
+	return r.requiredCount
+}
+
+// RewardDescription 達成特典の内容を取得する
+func (r *Rally) RewardDescription() string {
+	return r.rewardDescription
+}
+
+// ParticipantCount 参加者数を取得する
+func (r *Rally) ParticipantCount() int {
+	return r.participantCount
+}
+
+// CreatedAt 作成日時を取得する
+func (r *Rally) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt 更新日時を取得する
+func (r *Rally) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// IncludesBrewery 醸造所がスタンプラリーの対象かどうかを判定する
+func (r *Rally) IncludesBrewery(breweryID int) bool {
+	for _, id := range r.breweryIDs {
+		if id == breweryID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActiveAt 指定日時が開催期間中かどうかを判定する
+func (r *Rally) IsActiveAt(t time.Time) bool {
+	return !t.Before(r.startAt) && t.Before(r.endAt)
+}
+
+// HasEndedAt 指定日時の時点で終了しているかどうかを判定する
+func (r *Rally) HasEndedAt(t time.Time) bool {
+	return !t.Before(r.endAt)
+}
+
+// validate スタンプラリーのバリデーションを実行する
+func (r *Rally) validate() error {
+	if r.title == "" {
+		return domainerr.Invalid("title is required")
+	}
+	if len([]rune(r.title)) > MaxRallyTitleLength {
+		return domainerr.Invalid("title must be 200 characters or less")
+	}
+	if len([]rune(r.description)) > MaxRallyDescriptionLength {
+		return domainerr.Invalid("description must be 5000 characters or less")
+	}
+	if len([]rune(r.rewardDescription)) > MaxRallyRewardLength {
+		return domainerr.Invalid("reward_description must be 1000 characters or less")
+	}
+	if len(r.breweryIDs) < MinRallyBreweries || len(r.breweryIDs) > MaxRallyBreweries {
+		return domainerr.Invalid("brewery_ids must contain between 2 and 100 breweries")
+	}
+	seen := make(map[int]bool, len(r.breweryIDs))
+	for _, id := range r.breweryIDs {
+		if id <= 0 {
+			return domainerr.Invalid("brewery ID must be positive")
+		}
+		if seen[id] {
+			return domainerr.Invalid("brewery_ids must not contain duplicates")
+		}
+		seen[id] = true
+	}
+	if r.startAt.IsZero() || r.endAt.IsZero() {
+		return domainerr.Invalid("start_at and end_at are required")
+	}
+	if !r.endAt.After(r.startAt) {
+		return domainerr.Invalid("end_at must be after start_at")
+	}
+	if r.requiredCount < 1 || r.requiredCount > len(r.breweryIDs) {
+		return domainerr.Invalid("required_count must be between 1 and the number of breweries")
+	}
+	return nil
+}
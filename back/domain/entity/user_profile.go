@@ -1,17 +1,29 @@
 package entity
 
 import (
-	"errors"
+	"mybeerlog/domain/domainerr"
+	"regexp"
 	"strings"
 	"time"
 )
 
+// handlePattern ハンドルに使用できる文字列（英小文字・数字・アンダースコアの3〜30文字）
+var handlePattern = regexp.MustCompile(`^[a-z0-9_]{3,30}$`)
+
+// reservedHandles /users/ 以下の固定のパスと衝突するため、ハンドルに使用できない文字列
+var reservedHandles = map[string]bool{
+	"profile":  true,
+	"calendar": true,
+}
+
 // UserProfile はドメイン内のユーザープロファイルを表す
 type UserProfile struct {
 	id          int
 	cognitoSub  string
 	displayName string
 	iconURL     string
+	handle      string
+	privacy     *ProfilePrivacy
 	createdAt   time.Time
 	updatedAt   time.Time
 }
@@ -25,6 +37,7 @@ type UserProfileBuilder struct {
 func NewUserProfileBuilder() *UserProfileBuilder {
 	return &UserProfileBuilder{
 		userProfile: &UserProfile{
+			privacy:   DefaultProfilePrivacy(),
 			createdAt: time.Now(),
 			updatedAt: time.Now(),
 		},
@@ -55,6 +68,21 @@ func (b *UserProfileBuilder) WithIconURL(iconURL string) *UserProfileBuilder {
 	return b
 }
 
+// WithHandle 公開プロフィールのハンドルを設定する（大文字は小文字に変換する）
+func (b *UserProfileBuilder) WithHandle(handle string) *UserProfileBuilder {
+	b.userProfile.handle = strings.ToLower(strings.TrimSpace(handle))
+	return b
+}
+
+// WithPrivacy プライバシー設定を設定する（nil の場合は既定の設定）
+func (b *UserProfileBuilder) WithPrivacy(privacy *ProfilePrivacy) *UserProfileBuilder {
+	if privacy == nil {
+		privacy = DefaultProfilePrivacy()
+	}
+	b.userProfile.privacy = privacy
+	return b
+}
+
 // WithCreatedAt 作成日時を設定する
 func (b *UserProfileBuilder) WithCreatedAt(createdAt time.Time) *UserProfileBuilder {
 	b.userProfile.createdAt = createdAt
@@ -95,6 +123,21 @@ func (u *UserProfile) IconURL() string {
 	return u.iconURL
 }
 
+// Handle 公開プロフィールのハンドルを取得する（未設定の場合は空文字列）
+func (u *UserProfile) Handle() string {
+	return u.handle
+}
+
+// Privacy プライバシー設定を取得する
+func (u *UserProfile) Privacy() *ProfilePrivacy {
+	return u.privacy
+}
+
+// IsPubliclyVisible ハンドルで公開プロフィールを閲覧できるかどうかを判定する
+func (u *UserProfile) IsPubliclyVisible() bool {
+	return u.handle != "" && u.privacy.IsPublic()
+}
+
 // CreatedAt 作成日時を取得する
 func (u *UserProfile) CreatedAt() time.Time {
 	return u.createdAt
@@ -113,16 +156,30 @@ func (u *UserProfile) IsValid() bool {
 // validate ユーザープロファイルのバリデーションを実行する
 func (u *UserProfile) validate() error {
 	if strings.TrimSpace(u.cognitoSub) == "" {
-		return errors.New("cognito sub is required")
+		return domainerr.Invalid("cognito sub is required")
 	}
 	if len(u.cognitoSub) > 255 {
-		return errors.New("cognito sub must be 255 characters or less")
+		return domainerr.Invalid("cognito sub must be 255 characters or less")
 	}
 	if len(u.displayName) > 255 {
-		return errors.New("display name must be 255 characters or less")
+		return domainerr.Invalid("display name must be 255 characters or less")
 	}
 	if len(u.iconURL) > 512 {
-		return errors.New("icon URL must be 512 characters or less")
+		return domainerr.Invalid("icon URL must be 512 characters or less")
+	}
+	if u.handle != "" {
+		if !handlePattern.MatchString(u.handle) {
+			return domainerr.Invalid("handle must be 3 to 30 characters of lowercase letters, digits or underscores")
+		}
+		if reservedHandles[u.handle] {
+			return domainerr.Invalid("handle is reserved")
+		}
+	}
+	if u.privacy == nil {
+		return domainerr.Invalid("privacy settings are required")
+	}
+	if u.privacy.IsPublic() && u.handle == "" {
+		return domainerr.Invalid("handle is required to make the profile public")
 	}
 	return nil
 }
@@ -0,0 +1,107 @@
+package entity
+
+import "time"
+
+// 年間の振り返りのハイライトの種類
+const (
+	// RecapHighlightNewBreweries その年に初めて訪問した醸造所がある
+	RecapHighlightNewBreweries = "new_breweries"
+	// RecapHighlightPrefectures 複数の都道府県の醸造所を訪問した
+	RecapHighlightPrefectures = "prefectures"
+	// RecapHighlightWeekStreak 訪問のある週が連続した
+	RecapHighlightWeekStreak = "week_streak"
+	// RecapHighlightRegular 同じ醸造所に何度も通った
+	RecapHighlightRegular = "regular"
+	// RecapHighlightLongTrip 醸造所間を長距離移動した
+	RecapHighlightLongTrip = "long_trip"
+	// RecapHighlightBadges バッジを獲得した
+	RecapHighlightBadges = "badges"
+)
+
+// ハイライトに含める閾値
+const (
+	recapPrefecturesThreshold   = 2
+	recapWeekStreakThreshold    = 3
+	recapRegularVisitsThreshold = 3
+	recapLongTripThresholdM     = 100000.0
+)
+
+// UserRecap はユーザーの1年間の訪問の振り返り（年間の集計結果）を表す
+// 年の区切りは集計時のタイムゾーンで判定する
+type UserRecap struct {
+	UserProfileID         int
+	Year                  int
+	TotalVisits           int
+	BreweriesVisited      int
+	NewBreweries          int // その年に初めて訪問した醸造所の数
+	PrefecturesVisited    int
+	BusiestMonth          *MonthlyVisitCount // 最も訪問の多かった月（訪問がない場合は nil）
+	FavoriteBrewery       *Brewery           // 最も訪問した醸造所（訪問がない場合は nil）
+	FavoriteBreweryVisits int
+	FarthestTrip          *BreweryTrip // 連続する訪問での醸造所間の最長の移動（移動がない場合は nil）
+	LongestWeekStreak     int
+	BadgesEarned          int
+	GeneratedAt           time.Time
+	Provisional           bool // 集計中の年のため、保存せずにその場で集計した結果かどうか
+}
+
+// BreweryTrip は連続する訪問での醸造所間の移動を表す
+type BreweryTrip struct {
+	From      *Brewery
+	To        *Brewery
+	DistanceM float64
+}
+
+// RecapHighlight は年間の振り返りのハイライト（バッジのように表示する実績）を表す
+type RecapHighlight struct {
+	Code  string
+	Value int // ハイライトの数値（醸造所数・週数・訪問数・km など）
+}
+
+// FarthestTrip 醸造所間の移動のうち最も長い移動を取得する（移動がない場合は nil）
+func FarthestTrip(transitions []*BreweryTransition) *BreweryTrip {
+	var farthest *BreweryTrip
+	for _, transition := range transitions {
+		if transition.From == nil || transition.To == nil {
+			continue
+		}
+		distance, err := transition.To.DistanceFrom(transition.From.Latitude(), transition.From.Longitude())
+		if err != nil {
+			continue
+		}
+		if farthest == nil || distance > farthest.DistanceM {
+			farthest = &BreweryTrip{From: transition.From, To: transition.To, DistanceM: distance}
+		}
+	}
+	return farthest
+}
+
+// Highlights 集計結果から振り返りのハイライトを導出する
+func (r *UserRecap) Highlights() []RecapHighlight {
+	highlights := []RecapHighlight{}
+	if r.NewBreweries > 0 {
+		highlights = append(highlights, RecapHighlight{Code: RecapHighlightNewBreweries, Value: r.NewBreweries})
+	}
+	if r.PrefecturesVisited >= recapPrefecturesThreshold {
+		highlights = append(highlights, RecapHighlight{Code: RecapHighlightPrefectures, Value: r.PrefecturesVisited})
+	}
+	if r.LongestWeekStreak >= recapWeekStreakThreshold {
+		highlights = append(highlights, RecapHighlight{Code: RecapHighlightWeekStreak, Value: r.LongestWeekStreak})
+	}
+	if r.FavoriteBrewery != nil && r.FavoriteBreweryVisits >= recapRegularVisitsThreshold {
+		highlights = append(highlights, RecapHighlight{Code: RecapHighlightRegular, Value: r.FavoriteBreweryVisits})
+	}
+	if r.FarthestTrip != nil && r.FarthestTrip.DistanceM >= recapLongTripThresholdM {
+		highlights = append(highlights, RecapHighlight{Code: RecapHighlightLongTrip, Value: int(r.FarthestTrip.DistanceM / 1000)})
+	}
+	if r.BadgesEarned > 0 {
+		highlights = append(highlights, RecapHighlight{Code: RecapHighlightBadges, Value: r.BadgesEarned})
+	}
+	return highlights
+}
+
+// RecapYearRange 年の開始日時と翌年の開始日時（loc のタイムゾーンの1月1日0時）を取得する
+func RecapYearRange(year int, loc *time.Location) (time.Time, time.Time) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(1, 0, 0)
+}
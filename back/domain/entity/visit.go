@@ -1,10 +1,16 @@
 package entity
 
 import (
-	"errors"
+	"mybeerlog/domain/domainerr"
 	"time"
 )
 
+// チェックイン方法
+const (
+	CheckinMethodGPS = "gps" // 端末の位置情報によるチェックイン
+	CheckinMethodQR  = "qr"  // 醸造所に掲示されたQRコードによるチェックイン
+)
+
 // Visit はドメイン内のユーザーの醇造所訪問を表す
 type Visit struct {
 	id            int
@@ -12,6 +18,8 @@ type Visit struct {
 	userProfile   *UserProfile
 	breweryID     int
 	brewery       *Brewery
+	checkinMethod string
+	evidence      *CheckinEvidence
 	visitedAt     time.Time
 }
 
@@ -24,7 +32,8 @@ type VisitBuilder struct {
 func NewVisitBuilder() *VisitBuilder {
 	return &VisitBuilder{
 		visit: &Visit{
-			visitedAt: time.Now(),
+			checkinMethod: CheckinMethodGPS,
+			visitedAt:     time.Now(),
 		},
 	}
 }
@@ -65,6 +74,18 @@ func (b *VisitBuilder) WithBrewery(brewery *Brewery) *VisitBuilder {
 	return b
 }
 
+// WithCheckinMethod チェックイン方法を設定する
+func (b *VisitBuilder) WithCheckinMethod(method string) *VisitBuilder {
+	b.visit.checkinMethod = method
+	return b
+}
+
+// WithCheckinEvidence チェックイン時のGPS証跡を設定する
+func (b *VisitBuilder) WithCheckinEvidence(evidence *CheckinEvidence) *VisitBuilder {
+	b.visit.evidence = evidence
+	return b
+}
+
 // WithVisitedAt 訪問日時を設定する
 func (b *VisitBuilder) WithVisitedAt(visitedAt time.Time) *VisitBuilder {
 	b.visit.visitedAt = visitedAt
@@ -112,6 +133,16 @@ func (v *Visit) Brewery() *Brewery {
 	return v.brewery
 }
 
+// CheckinMethod チェックイン方法を取得する
+func (v *Visit) CheckinMethod() string {
+	return v.checkinMethod
+}
+
+// CheckinEvidence チェックイン時のGPS証跡を取得する（記録前の訪問は nil）
+func (v *Visit) CheckinEvidence() *CheckinEvidence {
+	return v.evidence
+}
+
 // VisitedAt 訪問日時を取得する
 func (v *Visit) VisitedAt() time.Time {
 	return v.visitedAt
@@ -125,16 +156,19 @@ func (v *Visit) IsValid() bool {
 // validate 訪問のバリデーションを実行する
 func (v *Visit) validate() error {
 	if v.userProfileID <= 0 {
-		return errors.New("user profile ID must be positive")
+		return domainerr.Invalid("user profile ID must be positive")
 	}
 	if v.breweryID <= 0 {
-		return errors.New("brewery ID must be positive")
+		return domainerr.Invalid("brewery ID must be positive")
+	}
+	if v.checkinMethod != CheckinMethodGPS && v.checkinMethod != CheckinMethodQR {
+		return domainerr.Invalid("invalid check-in method")
 	}
 	if v.visitedAt.IsZero() {
-		return errors.New("visited at timestamp is required")
+		return domainerr.Invalid("visited at timestamp is required")
 	}
 	if v.visitedAt.After(time.Now()) {
-		return errors.New("visited at timestamp cannot be in the future")
+		return domainerr.Invalid("visited at timestamp cannot be in the future")
 	}
 	return nil
 }
@@ -0,0 +1,56 @@
+package entity
+
+import "time"
+
+// VisitStats はユーザーの訪問履歴の集計（訪問履歴の可視化に使用する）を表す
+// 月・曜日・時間帯の集計は集計時に指定したタイムゾーンで行う
+type VisitStats struct {
+	TotalVisits          int
+	DistinctBreweries    int
+	FirstVisitAt         *time.Time          // 訪問がない場合は nil
+	LastVisitAt          *time.Time          // 訪問がない場合は nil
+	VisitsByMonth        []MonthlyVisitCount // 訪問のある月のみ、古い順
+	VisitsByWeekday      [7]int              // 日曜日を0とする曜日ごとの訪問数
+	VisitsByHour         [24]int             // 時（0〜23）ごとの訪問数
+	LongestWeekStreak    int                 // 訪問のある週（月曜始まり）が連続した最長の週数
+	MostVisitedBreweries []*BreweryVisitCount
+	TotalDistanceM       float64 // 連続する訪問の醸造所間の大円距離の合計（メートル）
+}
+
+// MonthlyVisitCount は月ごとの訪問数を表す
+type MonthlyVisitCount struct {
+	Year  int
+	Month time.Month
+	Count int
+}
+
+// BreweryVisitCount は醸造所ごとの訪問数を表す
+type BreweryVisitCount struct {
+	Brewery       *Brewery
+	VisitCount    int
+	LastVisitedAt time.Time
+}
+
+// BreweryTransition は連続する訪問での醸造所間の移動と、その回数を表す
+type BreweryTransition struct {
+	From  *Brewery
+	To    *Brewery
+	Count int
+}
+
+// TotalTravelDistance 醸造所間の移動の大円距離の合計（メートル）を計算する
+// 位置情報が不正な醸造所を含む移動は距離に含めない
+func TotalTravelDistance(transitions []*BreweryTransition) float64 {
+	total := 0.0
+	for _, transition := range transitions {
+		if transition.From == nil || transition.To == nil {
+			continue
+		}
+		distance, err := transition.To.DistanceFrom(transition.From.Latitude(), transition.From.Longitude())
+		if err != nil {
+			continue
+		}
+		total += distance * float64(transition.Count)
+	}
+	return total
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"mybeerlog/domain/domainerr"
+	"time"
+)
+
+// WishlistItem は行きたいリストに登録した醸造所を表す
+type WishlistItem struct {
+	id            int
+	userProfileID int
+	breweryID     int
+	brewery       *Brewery
+	visitedAt     *time.Time // 登録後に初めてチェックインした日時（未訪問の場合は nil）
+	createdAt     time.Time
+}
+
+// WishlistItemBuilder はWishlistItemインスタンスの作成を支援する
+type WishlistItemBuilder struct {
+	item *WishlistItem
+}
+
+// NewWishlistItemBuilder 新しいWishlistItemBuilderを作成する
+func NewWishlistItemBuilder() *WishlistItemBuilder {
+	return &WishlistItemBuilder{
+		item: &WishlistItem{
+			createdAt: time.Now(),
+		},
+	}
+}
+
+// WithID IDを設定する
+func (b *WishlistItemBuilder) WithID(id int) *WishlistItemBuilder {
+	b.item.id = id
+	return b
+}
+
+// WithUserProfileID ユーザープロファイルIDを設定する
+func (b *WishlistItemBuilder) WithUserProfileID(userProfileID int) *WishlistItemBuilder {
+	b.item.userProfileID = userProfileID
+	return b
+}
+
+// WithBreweryID 醸造所IDを設定する
+func (b *WishlistItemBuilder) WithBreweryID(breweryID int) *WishlistItemBuilder {
+	b.item.breweryID = breweryID
+	return b
+}
+
+// WithBrewery 醸造所を設定する
+func (b *WishlistItemBuilder) WithBrewery(brewery *Brewery) *WishlistItemBuilder {
+	b.item.brewery = brewery
+	if brewery != nil {
+		b.item.breweryID = brewery.ID()
+	}
+	return b
+}
+
+// WithVisitedAt 訪問日時を設定する
+func (b *WishlistItemBuilder) WithVisitedAt(visitedAt *time.Time) *WishlistItemBuilder {
+	b.item.visitedAt = visitedAt
+	return b
+}
+
+// WithCreatedAt 登録日時を設定する
+func (b *WishlistItemBuilder) WithCreatedAt(createdAt time.Time) *WishlistItemBuilder {
+	b.item.createdAt = createdAt
+	return b
+}
+
+// Build WishlistItemインスタンスを作成する
+func (b *WishlistItemBuilder) Build() (*WishlistItem, error) {
+	if b.item.userProfileID <= 0 {
+		return nil, domainerr.Invalid("user profile ID must be positive")
+	}
+	if b.item.breweryID <= 0 {
+		return nil, domainerr.Invalid("brewery ID must be positive")
+	}
+	return b.item, nil
+}
+
+// ID IDを取得する
+func (w *WishlistItem) ID() int {
+	return w.id
+}
+
+// UserProfileID ユーザープロファイルIDを取得する
+func (w *WishlistItem) UserProfileID() int {
+	return w.userProfileID
+}
+
+// BreweryID 醸造所IDを取得する
+func (w *WishlistItem) BreweryID() int {
+	return w.breweryID
+}
+
+// Brewery 醸造所を取得する
+func (w *WishlistItem) Brewery() *Brewery {
+	return w.brewery
+}
+
+// VisitedAt 訪問日時を取得する
+func (w *WishlistItem) VisitedAt() *time.Time {
+	return w.visitedAt
+}
+
+// IsVisited 登録後に訪問済みかどうかを判定する
+func (w *WishlistItem) IsVisited() bool {
+	return w.visitedAt != nil
+}
+
+// CreatedAt 登録日時を取得する
+func (w *WishlistItem) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// NearbyWishlistItem は指定地点からの距離付きの行きたいリストの醸造所を表す
+type NearbyWishlistItem struct {
+	item      *WishlistItem
+	distanceM *float64
+}
+
+// NewNearbyWishlistItem 距離付きの行きたいリストの醸造所を作成する（distanceM が nil の場合は距離なし）
+func NewNearbyWishlistItem(item *WishlistItem, distanceM *float64) *NearbyWishlistItem {
+	return &NearbyWishlistItem{
+		item:      item,
+		distanceM: distanceM,
+	}
+}
+
+// Item 行きたいリストの醸造所を取得する
+func (n *NearbyWishlistItem) Item() *WishlistItem {
+	return n.item
+}
+
+// DistanceM 指定地点からの距離（メートル）を取得する
+func (n *NearbyWishlistItem) DistanceM() *float64 {
+	return n.distanceM
+}
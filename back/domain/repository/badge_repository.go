@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// BadgeRepository バッジのデータアクセスインターフェースを定義する
+type BadgeRepository interface {
+	GetAll() ([]*entity.Badge, error)
+	GetByUserProfile(userProfileID int) ([]*entity.UserBadge, error)
+	GetStats(userProfileID int) (*entity.BadgeStats, error)
+	Award(userProfileID int, badge *entity.Badge, visitID *int) (*entity.UserBadge, bool, error)
+	Upsert(badge *entity.Badge) (*entity.Badge, error)
+}
+
+// beegoBadgeRepository Beego ORMを使用してBadgeRepositoryを実装する
+type beegoBadgeRepository struct {
+	orm orm.Ormer
+}
+
+// NewBadgeRepository 新しいBadgeRepositoryインスタンスを作成する
+func NewBadgeRepository() BadgeRepository {
+	return &beegoBadgeRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetAll 全てのバッジ定義を表示順に取得する
+func (r *beegoBadgeRepository) GetAll() ([]*entity.Badge, error) {
+	var badgeModels []*models.Badge
+	_, err := r.orm.QueryTable("badge").OrderBy("sort_order", "id").All(&badgeModels)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.Badge, len(badgeModels))
+	for i, model := range badgeModels {
+		badge, err := badgeModelToEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = badge
+	}
+
+	return entities, nil
+}
+
+// GetByUserProfile ユーザーが獲得したバッジを獲得日時の新しい順に取得する
+func (r *beegoBadgeRepository) GetByUserProfile(userProfileID int) ([]*entity.UserBadge, error) {
+	var userBadgeModels []*models.UserBadge
+	_, err := r.orm.QueryTable("user_badge").
+		Filter("user_profile_id", userProfileID).
+		RelatedSel("badge").
+		OrderBy("-awarded_at", "-id").
+		All(&userBadgeModels)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.UserBadge, len(userBadgeModels))
+	for i, model := range userBadgeModels {
+		userBadge, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = userBadge
+	}
+
+	return entities, nil
+}
+
+// GetStats バッジ獲得条件の判定に使用する訪問実績を集計する
+func (r *beegoBadgeRepository) GetStats(userProfileID int) (*entity.BadgeStats, error) {
+	var counts struct {
+		TotalVisits            int
+		DistinctBreweries      int
+		MaxVisitsToSameBrewery int
+	}
+	countSQL := `SELECT
+				COUNT(*) AS total_visits,
+				COUNT(DISTINCT brewery_id) AS distinct_breweries,
+				COALESCE((
+					SELECT MAX(visit_count) FROM (
+						SELECT COUNT(*) AS visit_count FROM visit WHERE user_profile_id = ? GROUP BY brewery_id
+					) per_brewery
+				), 0) AS max_visits_to_same_brewery
+			FROM visit
+			WHERE user_profile_id = ?`
+	if err := r.orm.Raw(countSQL, userProfileID, userProfileID).QueryRow(&counts); err != nil {
+		return nil, err
+	}
+
+	// アーカイブされていない醸造所を全て訪問済みの都道府県
+	var prefectures []struct {
+		Prefecture   string
+		BreweryCount int
+	}
+	prefectureSQL := `SELECT b.prefecture, COUNT(*) AS brewery_count
+			FROM brewery b
+			LEFT JOIN (SELECT DISTINCT brewery_id FROM visit WHERE user_profile_id = ?) v ON v.brewery_id = b.id
+			WHERE b.deleted_at IS NULL AND b.prefecture IS NOT NULL AND b.prefecture <> ''
+			GROUP BY b.prefecture
+			HAVING COUNT(v.brewery_id) = COUNT(*)`
+	if _, err := r.orm.Raw(prefectureSQL, userProfileID).QueryRows(&prefectures); err != nil {
+		return nil, err
+	}
+
+	stats := &entity.BadgeStats{
+		TotalVisits:            counts.TotalVisits,
+		DistinctBreweries:      counts.DistinctBreweries,
+		MaxVisitsToSameBrewery: counts.MaxVisitsToSameBrewery,
+		CompletedPrefectures:   make(map[string]int, len(prefectures)),
+	}
+	for _, p := range prefectures {
+		stats.CompletedPrefectures[p.Prefecture] = p.BreweryCount
+	}
+
+	return stats, nil
+}
+
+// Award バッジを付与する
+// 既に獲得済みの場合は一意制約により何もせず、false を返す
+func (r *beegoBadgeRepository) Award(userProfileID int, badge *entity.Badge, visitID *int) (*entity.UserBadge, bool, error) {
+	var inserted struct {
+		Id        int
+		AwardedAt time.Time
+	}
+	sql := `INSERT INTO user_badge (user_profile_id, badge_id, visit_id, awarded_at)
+			VALUES (?, ?, ?, NOW())
+			ON CONFLICT (user_profile_id, badge_id) DO NOTHING
+			RETURNING id, awarded_at`
+	// Beego ORM は nil ポインタのパラメータを扱えないため interface{} の nil に変換する
+	var visitParam interface{}
+	if visitID != nil {
+		visitParam = *visitID
+	}
+	err := r.orm.Raw(sql, userProfileID, badge.ID(), visitParam).QueryRow(&inserted)
+	if err == orm.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	userBadge, err := entity.NewUserBadge(inserted.Id, userProfileID, badge, visitID, inserted.AwardedAt)
+	if err != nil {
+		return nil, false, err
+	}
+	return userBadge, true, nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoBadgeRepository) modelToEntity(model *models.UserBadge) (*entity.UserBadge, error) {
+	badge, err := badgeModelToEntity(model.Badge)
+	if err != nil {
+		return nil, err
+	}
+
+	var visitID *int
+	if model.Visit != nil && model.Visit.Id > 0 {
+		id := model.Visit.Id
+		visitID = &id
+	}
+
+	return entity.NewUserBadge(model.Id, model.UserProfile.Id, badge, visitID, model.AwardedAt)
+}
+
+// Upsert コードをキーにバッジ定義を登録・更新する（初期データの投入に使用する）
+// アイコンの URL は初期データに含まないため更新せず、戻り値にも含まない
+func (r *beegoBadgeRepository) Upsert(badge *entity.Badge) (*entity.Badge, error) {
+	sql := `INSERT INTO badge (code, name, description, rule_type, threshold, prefecture, sort_order, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, NOW())
+			ON CONFLICT (code) DO UPDATE
+			SET name = EXCLUDED.name, description = EXCLUDED.description, rule_type = EXCLUDED.rule_type,
+				threshold = EXCLUDED.threshold, prefecture = EXCLUDED.prefecture, sort_order = EXCLUDED.sort_order
+			RETURNING id, created_at`
+
+	// 都道府県を限定しないバッジは NULL で保存する
+	var prefectureParam interface{}
+	if badge.Prefecture() != "" {
+		prefectureParam = badge.Prefecture()
+	}
+
+	var saved struct {
+		Id        int
+		CreatedAt time.Time
+	}
+	err := r.orm.Raw(sql,
+		badge.Code(), badge.Name(), badge.Description(), badge.RuleType(), badge.Threshold(),
+		prefectureParam, badge.SortOrder()).QueryRow(&saved)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewBadgeBuilder().
+		WithID(saved.Id).
+		WithCode(badge.Code()).
+		WithName(badge.Name()).
+		WithDescription(badge.Description()).
+		WithRule(badge.RuleType(), badge.Threshold()).
+		WithPrefecture(badge.Prefecture()).
+		WithSortOrder(badge.SortOrder()).
+		WithCreatedAt(saved.CreatedAt).
+		Build()
+}
+
+// badgeModelToEntity バッジモデルからエンティティに変換する
+func badgeModelToEntity(model *models.Badge) (*entity.Badge, error) {
+	return entity.NewBadgeBuilder().
+		WithID(model.Id).
+		WithCode(model.Code).
+		WithName(model.Name).
+		WithDescription(model.Description).
+		WithRule(model.RuleType, model.Threshold).
+		WithPrefecture(model.Prefecture).
+		WithIconURL(model.IconURL).
+		WithSortOrder(model.SortOrder).
+		WithCreatedAt(model.CreatedAt).
+		Build()
+}
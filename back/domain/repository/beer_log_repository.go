@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// flavorTagSeparator フレーバータグを1カラムに保存する際の区切り文字
+const flavorTagSeparator = ","
+
+// BeerLogRepository 訪問中に飲んだビールの記録のデータアクセスインターフェースを定義する
+type BeerLogRepository interface {
+	GetByID(id int) (*entity.BeerLog, error)
+	GetByVisit(visitID int) ([]*entity.BeerLog, error)
+	Create(beerLog *entity.BeerLog) (*entity.BeerLog, error)
+	Update(beerLog *entity.BeerLog) (*entity.BeerLog, error)
+	Delete(id int) error
+}
+
+// beegoBeerLogRepository Beego ORMを使用してBeerLogRepositoryを実装する
+type beegoBeerLogRepository struct {
+	orm orm.Ormer
+}
+
+// NewBeerLogRepository 新しいBeerLogRepositoryインスタンスを作成する
+func NewBeerLogRepository() BeerLogRepository {
+	return &beegoBeerLogRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByID IDでビールの記録を取得する
+func (r *beegoBeerLogRepository) GetByID(id int) (*entity.BeerLog, error) {
+	model := &models.BeerLog{}
+	err := r.orm.QueryTable("beer_log").Filter("id", id).One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrBeerLogNotFound, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// GetByVisit 訪問中に飲んだビールの記録を登録順に取得する
+func (r *beegoBeerLogRepository) GetByVisit(visitID int) ([]*entity.BeerLog, error) {
+	var beerLogModels []*models.BeerLog
+	_, err := r.orm.QueryTable("beer_log").
+		Filter("visit_id", visitID).
+		OrderBy("created_at", "id").
+		All(&beerLogModels)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.BeerLog, len(beerLogModels))
+	for i, model := range beerLogModels {
+		beerLog, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = beerLog
+	}
+
+	return entities, nil
+}
+
+// Create ビールの記録を作成する
+func (r *beegoBeerLogRepository) Create(beerLog *entity.BeerLog) (*entity.BeerLog, error) {
+	model := r.entityToModel(beerLog)
+	model.CreatedAt = time.Now()
+	model.UpdatedAt = time.Now()
+
+	_, err := r.orm.Insert(model)
+	if err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	return r.GetByID(model.Id)
+}
+
+// Update ビールの記録を更新する
+func (r *beegoBeerLogRepository) Update(beerLog *entity.BeerLog) (*entity.BeerLog, error) {
+	model := r.entityToModel(beerLog)
+	model.UpdatedAt = time.Now()
+
+	updated, err := r.orm.Update(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrBeerLogNotFound, nil)
+	}
+	if updated == 0 {
+		return nil, domainerr.ErrBeerLogNotFound
+	}
+
+	return r.GetByID(model.Id)
+}
+
+// Delete ビールの記録を削除する
+func (r *beegoBeerLogRepository) Delete(id int) error {
+	deleted, err := r.orm.QueryTable("beer_log").Filter("id", id).Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrBeerLogNotFound
+	}
+
+	return nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoBeerLogRepository) modelToEntity(model *models.BeerLog) (*entity.BeerLog, error) {
+	var beerID *int
+	if model.Beer != nil && model.Beer.Id > 0 {
+		id := model.Beer.Id
+		beerID = &id
+	}
+
+	var flavorTags []string
+	if model.FlavorTags != "" {
+		flavorTags = strings.Split(model.FlavorTags, flavorTagSeparator)
+	}
+
+	return entity.NewBeerLogBuilder().
+		WithID(model.Id).
+		WithVisitID(model.Visit.Id).
+		WithBeerID(beerID).
+		WithBeerName(model.BeerName).
+		WithServingType(model.ServingType).
+		WithRating(model.Rating).
+		WithNotes(model.Notes).
+		WithFlavorTags(flavorTags).
+		WithCreatedAt(model.CreatedAt).
+		WithUpdatedAt(model.UpdatedAt).
+		Build()
+}
+
+// entityToModel エンティティからモデルに変換する
+func (r *beegoBeerLogRepository) entityToModel(e *entity.BeerLog) *models.BeerLog {
+	model := &models.BeerLog{
+		Id:          e.ID(),
+		Visit:       &models.Visit{Id: e.VisitID()},
+		BeerName:    e.BeerName(),
+		ServingType: e.ServingType(),
+		Rating:      e.Rating(),
+		Notes:       e.Notes(),
+		FlavorTags:  strings.Join(e.FlavorTags(), flavorTagSeparator),
+		CreatedAt:   e.CreatedAt(),
+		UpdatedAt:   e.UpdatedAt(),
+	}
+	if e.BeerID() != nil {
+		model.Beer = &models.Beer{Id: *e.BeerID()}
+	}
+	return model
+}
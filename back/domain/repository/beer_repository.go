@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// BeerRepository ビールのデータアクセスインターフェースを定義する
+type BeerRepository interface {
+	GetByID(id int) (*entity.Beer, error)
+	GetByBrewery(breweryID int, limit, offset int) ([]*entity.Beer, int, error)
+	Create(beer *entity.Beer) (*entity.Beer, error)
+	Update(beer *entity.Beer, expectedUpdatedAt time.Time) (*entity.Beer, error)
+	Delete(id int) error
+}
+
+// beerRatingSummary ビールの評価の集計結果
+type beerRatingSummary struct {
+	BeerId        int
+	AverageRating float64
+	RatingCount   int
+}
+
+// beegoBeerRepository Beego ORMを使用してBeerRepositoryを実装する
+type beegoBeerRepository struct {
+	orm orm.Ormer
+}
+
+// NewBeerRepository 新しいBeerRepositoryインスタンスを作成する
+func NewBeerRepository() BeerRepository {
+	return &beegoBeerRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByID IDでビールを醸造所情報・評価の集計とともに取得する
+func (r *beegoBeerRepository) GetByID(id int) (*entity.Beer, error) {
+	model := &models.Beer{}
+	err := r.orm.QueryTable("beer").Filter("id", id).RelatedSel("brewery").One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrBeerNotFound, nil)
+	}
+
+	summaries, err := r.getRatingSummaries([]int{model.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(model, summaries[model.Id])
+}
+
+// GetByBrewery 醸造所のビールを名前順に取得する
+func (r *beegoBeerRepository) GetByBrewery(breweryID int, limit, offset int) ([]*entity.Beer, int, error) {
+	var beerModels []*models.Beer
+
+	qs := r.orm.QueryTable("beer").Filter("brewery_id", breweryID).OrderBy("name", "id")
+
+	// 総数取得
+	total, err := qs.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// ページネーション
+	_, err = qs.Limit(limit, offset).All(&beerModels)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int, len(beerModels))
+	for i, model := range beerModels {
+		ids[i] = model.Id
+	}
+	summaries, err := r.getRatingSummaries(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Beer, len(beerModels))
+	for i, model := range beerModels {
+		beer, err := r.modelToEntity(model, summaries[model.Id])
+		if err != nil {
+			return nil, 0, err
+		}
+		entities[i] = beer
+	}
+
+	return entities, int(total), nil
+}
+
+// Create ビールを作成する
+func (r *beegoBeerRepository) Create(beer *entity.Beer) (*entity.Beer, error) {
+	model := r.entityToModel(beer)
+	model.CreatedAt = time.Now()
+	model.UpdatedAt = time.Now()
+
+	_, err := r.orm.Insert(model)
+	if err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	// DBに保存された値（updated_at の精度を含む）で返すため再取得する
+	return r.GetByID(model.Id)
+}
+
+// Update ビールを更新する（updated_at による楽観的排他制御を行う）
+func (r *beegoBeerRepository) Update(beer *entity.Beer, expectedUpdatedAt time.Time) (*entity.Beer, error) {
+	sql := `UPDATE beer
+			SET name = ?, style = ?, abv = ?, ibu = ?, description = ?, is_seasonal = ?, is_limited = ?,
+				available_from = ?, available_until = ?, updated_at = ?
+			WHERE id = ? AND updated_at = ?`
+
+	result, err := r.orm.Raw(sql,
+		beer.Name(), beer.Style(), nullableFloat64(beer.ABV()), nullableInt(beer.IBU()), beer.Description(),
+		beer.IsSeasonal(), beer.IsLimited(),
+		nullableDate(beer.AvailableFrom()), nullableDate(beer.AvailableUntil()),
+		formatDBTimestamp(time.Now()),
+		beer.ID(), formatDBTimestamp(expectedUpdatedAt)).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		// 更新件数が0件の場合は不存在か競合かを判別する
+		if _, err := r.GetByID(beer.ID()); err != nil {
+			return nil, err
+		}
+		return nil, domainerr.ErrBeerModified
+	}
+
+	return r.GetByID(beer.ID())
+}
+
+// Delete ビールを削除する
+func (r *beegoBeerRepository) Delete(id int) error {
+	deleted, err := r.orm.QueryTable("beer").Filter("id", id).Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrBeerNotFound
+	}
+
+	return nil
+}
+
+// getRatingSummaries 訪問記録の評価をビールごとに集計する（評価のないビールは結果に含まれない）
+func (r *beegoBeerRepository) getRatingSummaries(beerIDs []int) (map[int]*beerRatingSummary, error) {
+	summaries := make(map[int]*beerRatingSummary, len(beerIDs))
+	if len(beerIDs) == 0 {
+		return summaries, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(beerIDs)), ", ")
+	sql := `SELECT beer_id, AVG(rating) AS average_rating, COUNT(rating) AS rating_count
+			FROM beer_log
+			WHERE rating IS NOT NULL AND beer_id IN (` + placeholders + `)
+			GROUP BY beer_id`
+
+	var rows []beerRatingSummary
+	if _, err := r.orm.Raw(sql, beerIDs).QueryRows(&rows); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		summaries[rows[i].BeerId] = &rows[i]
+	}
+
+	return summaries, nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoBeerRepository) modelToEntity(model *models.Beer, summary *beerRatingSummary) (*entity.Beer, error) {
+	builder := entity.NewBeerBuilder().
+		WithID(model.Id).
+		WithBreweryID(model.Brewery.Id).
+		WithName(model.Name).
+		WithStyle(model.Style).
+		WithABV(model.Abv).
+		WithIBU(model.Ibu).
+		WithDescription(model.Description).
+		WithSeasonal(model.IsSeasonal).
+		WithLimited(model.IsLimited).
+		WithAvailability(dateValue(model.AvailableFrom), dateValue(model.AvailableUntil)).
+		WithCreatedAt(model.CreatedAt).
+		WithUpdatedAt(model.UpdatedAt)
+
+	if summary != nil && summary.RatingCount > 0 {
+		averageRating := summary.AverageRating
+		builder = builder.WithRatingSummary(&averageRating, summary.RatingCount)
+	}
+
+	// 関連する醸造所情報がある場合
+	if model.Brewery.Name != "" {
+		brewery, err := breweryModelToEntity(model.Brewery)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.WithBrewery(brewery)
+	}
+
+	return builder.Build()
+}
+
+// entityToModel エンティティからモデルに変換する
+func (r *beegoBeerRepository) entityToModel(e *entity.Beer) *models.Beer {
+	return &models.Beer{
+		Id:             e.ID(),
+		Brewery:        &models.Brewery{Id: e.BreweryID()},
+		Name:           e.Name(),
+		Style:          e.Style(),
+		Abv:            e.ABV(),
+		Ibu:            e.IBU(),
+		Description:    e.Description(),
+		IsSeasonal:     e.IsSeasonal(),
+		IsLimited:      e.IsLimited(),
+		AvailableFrom:  e.AvailableFrom(),
+		AvailableUntil: e.AvailableUntil(),
+		CreatedAt:      e.CreatedAt(),
+		UpdatedAt:      e.UpdatedAt(),
+	}
+}
+
+// dateValue DBから読み込んだ日付を UTC の日付に揃える
+func dateValue(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	date := time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+// Beego ORM は nil ポインタのパラメータを扱えないため、生SQLのパラメータは interface{} の nil に変換する
+
+// nullableFloat64 生SQLのパラメータ用に *float64 を変換する
+func nullableFloat64(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+// nullableInt 生SQLのパラメータ用に *int を変換する
+func nullableInt(value *int) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+// nullableDate 生SQLのパラメータ用に日付を変換する
+func nullableDate(value *time.Time) interface{} {
+	if value == nil {
+		return nil
+	}
+	return value.Format("2006-01-02")
+}
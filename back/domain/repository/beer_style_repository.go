@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"strconv"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// BeerStyleRepository ビアスタイルと醸造所のスタイルのデータアクセスインターフェースを定義する
+type BeerStyleRepository interface {
+	GetAll() ([]*entity.BeerStyle, error)
+	Upsert(style *entity.BeerStyle) (*entity.BeerStyle, error)
+	GetByBrewery(breweryID int) ([]*entity.BeerStyle, error)
+	ReplaceBreweryStyles(breweryID int, styleIDs []int) error
+}
+
+// beegoBeerStyleRepository Beego ORMを使用してBeerStyleRepositoryを実装する
+type beegoBeerStyleRepository struct {
+	orm orm.Ormer
+}
+
+// NewBeerStyleRepository 新しいBeerStyleRepositoryインスタンスを作成する
+func NewBeerStyleRepository() BeerStyleRepository {
+	return &beegoBeerStyleRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetAll 全てのビアスタイルを表示順に取得する
+func (r *beegoBeerStyleRepository) GetAll() ([]*entity.BeerStyle, error) {
+	var styleModels []*models.BeerStyle
+	_, err := r.orm.QueryTable("beer_style").OrderBy("sort_order", "id").All(&styleModels)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modelsToEntities(styleModels)
+}
+
+// Upsert コードをキーにビアスタイルを登録・更新する（初期データの投入に使用する）
+func (r *beegoBeerStyleRepository) Upsert(style *entity.BeerStyle) (*entity.BeerStyle, error) {
+	sql := `INSERT INTO beer_style (code, parent_id, name_ja, name_en, sort_order)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (code) DO UPDATE
+			SET parent_id = EXCLUDED.parent_id, name_ja = EXCLUDED.name_ja,
+				name_en = EXCLUDED.name_en, sort_order = EXCLUDED.sort_order
+			RETURNING id`
+
+	var id int
+	err := r.orm.Raw(sql,
+		style.Code(), nullableInt(style.ParentID()), style.NameJa(), style.NameEn(), style.SortOrder()).QueryRow(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewBeerStyleBuilder().
+		WithID(id).
+		WithCode(style.Code()).
+		WithParentID(style.ParentID()).
+		WithNames(style.NameJa(), style.NameEn()).
+		WithSortOrder(style.SortOrder()).
+		Build()
+}
+
+// GetByBrewery 醸造所に紐付いたビアスタイルを表示順に取得する
+func (r *beegoBeerStyleRepository) GetByBrewery(breweryID int) ([]*entity.BeerStyle, error) {
+	var styleModels []*models.BeerStyle
+	_, err := r.orm.QueryTable("beer_style").
+		FilterRaw("id", "IN (SELECT beer_style_id FROM brewery_style WHERE brewery_id = "+strconv.Itoa(breweryID)+")").
+		OrderBy("sort_order", "id").
+		All(&styleModels)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modelsToEntities(styleModels)
+}
+
+// ReplaceBreweryStyles 醸造所に紐付くビアスタイルを置き換える
+func (r *beegoBeerStyleRepository) ReplaceBreweryStyles(breweryID int, styleIDs []int) error {
+	// トランザクションはリクエスト間で共有しない Ormer で実行する
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := o.QueryTable("brewery_style").Filter("brewery_id", breweryID).Delete(); err != nil {
+		o.Rollback()
+		return err
+	}
+
+	for _, styleID := range styleIDs {
+		breweryStyle := &models.BreweryStyle{
+			Brewery:   &models.Brewery{Id: breweryID},
+			BeerStyle: &models.BeerStyle{Id: styleID},
+		}
+		if _, err := o.Insert(breweryStyle); err != nil {
+			o.Rollback()
+			return translateError(err, nil, nil)
+		}
+	}
+
+	return o.Commit()
+}
+
+// modelsToEntities モデルの配列をエンティティの配列に変換する
+func (r *beegoBeerStyleRepository) modelsToEntities(styleModels []*models.BeerStyle) ([]*entity.BeerStyle, error) {
+	entities := make([]*entity.BeerStyle, len(styleModels))
+	for i, model := range styleModels {
+		var parentID *int
+		if model.Parent != nil && model.Parent.Id > 0 {
+			id := model.Parent.Id
+			parentID = &id
+		}
+
+		style, err := entity.NewBeerStyleBuilder().
+			WithID(model.Id).
+			WithCode(model.Code).
+			WithParentID(parentID).
+			WithNames(model.NameJa, model.NameEn).
+			WithSortOrder(model.SortOrder).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = style
+	}
+
+	return entities, nil
+}
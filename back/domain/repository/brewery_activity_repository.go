@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"strings"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// BreweryActivityRepository 醸造所のアクティビティ（フィード）のデータアクセスインターフェースを定義する
+type BreweryActivityRepository interface {
+	GetFeed(userProfileID int, beforeID int, limit int) ([]*entity.BreweryActivity, error)
+	Record(activities []*entity.BreweryActivity) (int, error)
+}
+
+// beegoBreweryActivityRepository Beego ORMを使用してBreweryActivityRepositoryを実装する
+type beegoBreweryActivityRepository struct {
+	orm orm.Ormer
+}
+
+// NewBreweryActivityRepository 新しいBreweryActivityRepositoryインスタンスを作成する
+func NewBreweryActivityRepository() BreweryActivityRepository {
+	return &beegoBreweryActivityRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetFeed フォローしている醸造所のアクティビティを新しい順に取得する（beforeID が正の場合はそれより前のもの）
+// フォロー数が多くても読み取り量が limit × フォロー数に収まるよう、醸造所ごとに (brewery_id, id) の
+// インデックスで上位 limit 件だけを取り出してから全体を並べ替える
+func (r *beegoBreweryActivityRepository) GetFeed(userProfileID int, beforeID int, limit int) ([]*entity.BreweryActivity, error) {
+	cursorCondition := ""
+	args := []interface{}{}
+	if beforeID > 0 {
+		cursorCondition = "AND a.id < ?"
+		args = append(args, beforeID)
+	}
+	args = append(args, limit, userProfileID, limit)
+
+	// アーカイブ済みの醸造所（関連する醸造所を含む）のアクティビティは掲載しない
+	sql := `SELECT feed.id FROM brewery_follow f
+			JOIN brewery b ON b.id = f.brewery_id AND b.deleted_at IS NULL
+			CROSS JOIN LATERAL (
+				SELECT a.id FROM brewery_activity a
+				LEFT JOIN brewery r ON r.id = a.related_brewery_id
+				WHERE a.brewery_id = f.brewery_id ` + cursorCondition + `
+					AND (a.related_brewery_id IS NULL OR r.deleted_at IS NULL)
+				ORDER BY a.id DESC
+				LIMIT ?
+			) feed
+			WHERE f.user_profile_id = ?
+			ORDER BY feed.id DESC
+			LIMIT ?`
+
+	var rows []struct {
+		Id int
+	}
+	if _, err := r.orm.Raw(sql, args...).QueryRows(&rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []*entity.BreweryActivity{}, nil
+	}
+
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		ids[i] = row.Id
+	}
+
+	var activityModels []*models.BreweryActivity
+	_, err := r.orm.QueryTable("brewery_activity").
+		Filter("id__in", ids).
+		RelatedSel("brewery", "related_brewery").
+		OrderBy("-id").
+		All(&activityModels)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.BreweryActivity, len(activityModels))
+	for i, model := range activityModels {
+		activity, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = activity
+	}
+
+	return entities, nil
+}
+
+// Record アクティビティを登録し、登録した件数を返す
+// 重複防止キーが登録済みのアクティビティは同じ出来事とみなして登録しない
+func (r *beegoBreweryActivityRepository) Record(activities []*entity.BreweryActivity) (int, error) {
+	if len(activities) == 0 {
+		return 0, nil
+	}
+
+	values := make([]string, len(activities))
+	args := make([]interface{}, 0, len(activities)*5)
+	for i, activity := range activities {
+		values[i] = "(?, ?, ?, ?, ?, NOW())"
+		var dedupeKey interface{}
+		if activity.DedupeKey() != "" {
+			dedupeKey = activity.DedupeKey()
+		}
+		args = append(args,
+			activity.BreweryID(), activity.Type(), nullableInt(activity.RelatedBreweryID()),
+			nullableInt(activity.Milestone()), dedupeKey)
+	}
+
+	sql := `INSERT INTO brewery_activity (brewery_id, activity_type, related_brewery_id, milestone, dedupe_key, created_at)
+			VALUES ` + strings.Join(values, ", ") + `
+			ON CONFLICT (dedupe_key) DO NOTHING`
+	result, err := r.orm.Raw(sql, args...).Exec()
+	if err != nil {
+		return 0, translateError(err, nil, nil)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(inserted), nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoBreweryActivityRepository) modelToEntity(model *models.BreweryActivity) (*entity.BreweryActivity, error) {
+	builder := entity.NewBreweryActivityBuilder().
+		WithID(model.Id).
+		WithBreweryID(model.Brewery.Id).
+		WithType(model.ActivityType).
+		WithMilestone(model.Milestone).
+		WithDedupeKey(model.DedupeKey).
+		WithCreatedAt(model.CreatedAt)
+
+	// 関連する醸造所情報がある場合
+	if model.Brewery.Name != "" {
+		brewery, err := breweryModelToEntity(model.Brewery)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.WithBrewery(brewery)
+	}
+	if model.RelatedBrewery != nil && model.RelatedBrewery.Id > 0 {
+		if model.RelatedBrewery.Name != "" {
+			relatedBrewery, err := breweryModelToEntity(model.RelatedBrewery)
+			if err != nil {
+				return nil, err
+			}
+			builder = builder.WithRelatedBrewery(relatedBrewery)
+		} else {
+			relatedBreweryID := model.RelatedBrewery.Id
+			builder = builder.WithRelatedBreweryID(&relatedBreweryID)
+		}
+	}
+
+	return builder.Build()
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// BreweryFollowRepository 醸造所のフォローのデータアクセスインターフェースを定義する
+type BreweryFollowRepository interface {
+	GetFollowedBreweries(userProfileID int, limit, offset int) ([]*entity.Brewery, int, error)
+	IsFollowing(userProfileID, breweryID int) (bool, error)
+	Follow(userProfileID, breweryID int) (bool, error)
+	Unfollow(userProfileID, breweryID int) error
+}
+
+// beegoBreweryFollowRepository Beego ORMを使用してBreweryFollowRepositoryを実装する
+type beegoBreweryFollowRepository struct {
+	orm orm.Ormer
+}
+
+// NewBreweryFollowRepository 新しいBreweryFollowRepositoryインスタンスを作成する
+func NewBreweryFollowRepository() BreweryFollowRepository {
+	return &beegoBreweryFollowRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetFollowedBreweries フォローしている醸造所をフォローした日時の新しい順に取得する（アーカイブ済みの醸造所を除く）
+func (r *beegoBreweryFollowRepository) GetFollowedBreweries(userProfileID int, limit, offset int) ([]*entity.Brewery, int, error) {
+	var followModels []*models.BreweryFollow
+
+	qs := r.orm.QueryTable("brewery_follow").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery__deleted_at__isnull", true)
+
+	// 総数取得
+	total, err := qs.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// ページネーション
+	_, err = qs.RelatedSel("brewery").OrderBy("-created_at", "-id").Limit(limit, offset).All(&followModels)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Brewery, len(followModels))
+	for i, model := range followModels {
+		brewery, err := breweryModelToEntity(model.Brewery)
+		if err != nil {
+			return nil, 0, err
+		}
+		entities[i] = brewery
+	}
+
+	return entities, int(total), nil
+}
+
+// IsFollowing 醸造所をフォローしているかどうかを判定する
+func (r *beegoBreweryFollowRepository) IsFollowing(userProfileID, breweryID int) (bool, error) {
+	return r.orm.QueryTable("brewery_follow").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery_id", breweryID).
+		Exist(), nil
+}
+
+// Follow 醸造所をフォローする（フォロー済みの場合は false を返す）
+func (r *beegoBreweryFollowRepository) Follow(userProfileID, breweryID int) (bool, error) {
+	var insertedID int
+	sql := `INSERT INTO brewery_follow (user_profile_id, brewery_id, created_at)
+			VALUES (?, ?, NOW())
+			ON CONFLICT (user_profile_id, brewery_id) DO NOTHING
+			RETURNING id`
+	err := r.orm.Raw(sql, userProfileID, breweryID).QueryRow(&insertedID)
+	if err == orm.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, translateError(err, nil, nil)
+	}
+	return true, nil
+}
+
+// Unfollow 醸造所のフォローを解除する
+func (r *beegoBreweryFollowRepository) Unfollow(userProfileID, breweryID int) error {
+	deleted, err := r.orm.QueryTable("brewery_follow").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery_id", breweryID).
+		Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrNotFollowingBrewery
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"fmt"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// BreweryManagerRepository 醸造所管理者のデータアクセスインターフェースを定義する
+type BreweryManagerRepository interface {
+	GetByBrewery(breweryID int) ([]*entity.BreweryManager, error)
+	GetByUserProfile(userProfileID int) ([]*entity.BreweryManager, error)
+	Exists(userProfileID, breweryID int) (bool, error)
+	Create(breweryManager *entity.BreweryManager) (*entity.BreweryManager, error)
+	Delete(userProfileID, breweryID int) error
+}
+
+// beegoBreweryManagerRepository Beego ORMを使用してBreweryManagerRepositoryを実装する
+type beegoBreweryManagerRepository struct {
+	orm orm.Ormer
+}
+
+// NewBreweryManagerRepository 新しいBreweryManagerRepositoryインスタンスを作成する
+func NewBreweryManagerRepository() BreweryManagerRepository {
+	return &beegoBreweryManagerRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByBrewery 醸造所の管理者一覧を取得する
+func (r *beegoBreweryManagerRepository) GetByBrewery(breweryID int) ([]*entity.BreweryManager, error) {
+	var models []*models.BreweryManager
+
+	_, err := r.orm.QueryTable("brewery_manager").
+		Filter("brewery_id", breweryID).
+		RelatedSel("user_profile", "brewery").
+		OrderBy("created_at").
+		All(&models)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modelsToEntities(models)
+}
+
+// GetByUserProfile ユーザーが管理する醸造所の一覧を取得する
+func (r *beegoBreweryManagerRepository) GetByUserProfile(userProfileID int) ([]*entity.BreweryManager, error) {
+	var models []*models.BreweryManager
+
+	_, err := r.orm.QueryTable("brewery_manager").
+		Filter("user_profile_id", userProfileID).
+		RelatedSel("user_profile", "brewery").
+		OrderBy("created_at").
+		All(&models)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modelsToEntities(models)
+}
+
+// Exists ユーザーが醸造所の管理者かどうかを確認する
+func (r *beegoBreweryManagerRepository) Exists(userProfileID, breweryID int) (bool, error) {
+	count, err := r.orm.QueryTable("brewery_manager").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery_id", breweryID).
+		Count()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Create 醸造所管理者を登録する
+func (r *beegoBreweryManagerRepository) Create(breweryManager *entity.BreweryManager) (*entity.BreweryManager, error) {
+	model := &models.BreweryManager{
+		UserProfile: &models.UserProfile{Id: breweryManager.UserProfileID()},
+		Brewery:     &models.Brewery{Id: breweryManager.BreweryID()},
+		CreatedAt:   time.Now(),
+	}
+
+	_, err := r.orm.Insert(model)
+	if err != nil {
+		return nil, translateError(err, nil, domainerr.ErrBreweryManagerAlreadyExists)
+	}
+
+	// 関連情報を含めて再取得する
+	created := &models.BreweryManager{}
+	err = r.orm.QueryTable("brewery_manager").
+		Filter("id", model.Id).
+		RelatedSel("user_profile", "brewery").
+		One(created)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrBreweryManagerNotFound, nil)
+	}
+
+	return r.modelToEntity(created)
+}
+
+// Delete 醸造所管理者の登録を解除する
+func (r *beegoBreweryManagerRepository) Delete(userProfileID, breweryID int) error {
+	deleted, err := r.orm.QueryTable("brewery_manager").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery_id", breweryID).
+		Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrBreweryManagerNotFound
+	}
+
+	return nil
+}
+
+// modelsToEntities モデルの配列をエンティティの配列に変換する
+func (r *beegoBreweryManagerRepository) modelsToEntities(models []*models.BreweryManager) ([]*entity.BreweryManager, error) {
+	entities := make([]*entity.BreweryManager, len(models))
+	for i, model := range models {
+		entity, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+
+	return entities, nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+// 外部キーは NOT NULL のため、関連（IDのみの場合を含む）がない行は不正なデータとしてエラーを返す
+func (r *beegoBreweryManagerRepository) modelToEntity(model *models.BreweryManager) (*entity.BreweryManager, error) {
+	userProfileModel, breweryModel := model.UserProfile, model.Brewery
+	if userProfileModel == nil || breweryModel == nil {
+		return nil, fmt.Errorf("brewery manager %d is missing its user profile or brewery", model.Id)
+	}
+
+	builder := entity.NewBreweryManagerBuilder().
+		WithID(model.Id).
+		WithUserProfileID(userProfileModel.Id).
+		WithBreweryID(breweryModel.Id).
+		WithCreatedAt(model.CreatedAt)
+
+	// 関連するユーザープロファイル情報がある場合（RelatedSel で読み込まれていない場合はIDのみ）
+	if userProfileModel.CognitoSub != "" {
+		userProfile, err := userProfileModelToEntity(userProfileModel)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.WithUserProfile(userProfile)
+	}
+
+	// 関連する醸造所情報がある場合
+	if breweryModel.Name != "" {
+		brewery, err := breweryModelToEntity(breweryModel)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.WithBrewery(brewery)
+	}
+
+	return builder.Build()
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// BreweryPostFilter 投稿一覧の絞り込み条件
+type BreweryPostFilter struct {
+	PublishedBefore *time.Time // 指定した場合はこの日時までに公開された投稿のみ（予約投稿を除く）
+	Visibilities    []string   // 指定した場合はこの公開範囲の投稿のみ（nil の場合は全ての公開範囲）
+	FanRanks        []string   // 公開範囲が fan_rank の投稿のうち、閲覧できる必要ファンランク
+}
+
+// BreweryPostRepository 醸造所の投稿のデータアクセスインターフェースを定義する
+type BreweryPostRepository interface {
+	GetByID(id int) (*entity.BreweryPost, error)
+	GetByBrewery(breweryID int, filter BreweryPostFilter, limit, offset int) ([]*entity.BreweryPost, int, error)
+	Create(post *entity.BreweryPost) (*entity.BreweryPost, error)
+	Update(post *entity.BreweryPost) (*entity.BreweryPost, error)
+	Delete(id int) error
+}
+
+// beegoBreweryPostRepository Beego ORMを使用してBreweryPostRepositoryを実装する
+type beegoBreweryPostRepository struct {
+	orm orm.Ormer
+}
+
+// NewBreweryPostRepository 新しいBreweryPostRepositoryインスタンスを作成する
+func NewBreweryPostRepository() BreweryPostRepository {
+	return &beegoBreweryPostRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByID IDで投稿を画像とともに取得する
+func (r *beegoBreweryPostRepository) GetByID(id int) (*entity.BreweryPost, error) {
+	model := &models.BreweryPost{}
+	err := r.orm.QueryTable("brewery_post").Filter("id", id).One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrBreweryPostNotFound, nil)
+	}
+
+	images, err := r.getImageURLs([]int{model.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(model, images[model.Id])
+}
+
+// GetByBrewery 醸造所の投稿を固定表示・公開日時の新しい順に取得する
+func (r *beegoBreweryPostRepository) GetByBrewery(breweryID int, filter BreweryPostFilter, limit, offset int) ([]*entity.BreweryPost, int, error) {
+	cond := orm.NewCondition().And("brewery_id", breweryID)
+	if filter.PublishedBefore != nil {
+		cond = cond.And("publish_at__lte", *filter.PublishedBefore)
+	}
+	if filter.Visibilities != nil {
+		visibilityCond := orm.NewCondition().And("visibility__in", filter.Visibilities)
+		if len(filter.FanRanks) > 0 {
+			visibilityCond = visibilityCond.OrCond(orm.NewCondition().
+				And("visibility", entity.PostVisibilityFanRank).
+				And("min_fan_rank__in", filter.FanRanks))
+		}
+		cond = cond.AndCond(visibilityCond)
+	}
+
+	qs := r.orm.QueryTable("brewery_post").SetCond(cond)
+
+	// 総数取得
+	total, err := qs.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// ページネーション
+	var postModels []*models.BreweryPost
+	_, err = qs.OrderBy("-is_pinned", "-publish_at", "-id").Limit(limit, offset).All(&postModels)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int, len(postModels))
+	for i, model := range postModels {
+		ids[i] = model.Id
+	}
+	images, err := r.getImageURLs(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.BreweryPost, len(postModels))
+	for i, model := range postModels {
+		post, err := r.modelToEntity(model, images[model.Id])
+		if err != nil {
+			return nil, 0, err
+		}
+		entities[i] = post
+	}
+
+	return entities, int(total), nil
+}
+
+// Create 投稿を画像とともに作成する
+func (r *beegoBreweryPostRepository) Create(post *entity.BreweryPost) (*entity.BreweryPost, error) {
+	model := r.entityToModel(post)
+
+	// トランザクションはリクエスト間で共有しない Ormer で実行する
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, err
+	}
+
+	if _, err := o.Insert(model); err != nil {
+		o.Rollback()
+		return nil, translateError(err, nil, nil)
+	}
+	if err := r.insertImages(o, model.Id, post.ImageURLs()); err != nil {
+		o.Rollback()
+		return nil, err
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(model.Id)
+}
+
+// Update 投稿を更新し、画像を置き換える
+func (r *beegoBreweryPostRepository) Update(post *entity.BreweryPost) (*entity.BreweryPost, error) {
+	model := r.entityToModel(post)
+
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, err
+	}
+
+	updated, err := o.Update(model, "Title", "Body", "PublishAt", "IsPinned", "Visibility", "MinFanRank", "UpdatedAt")
+	if err != nil {
+		o.Rollback()
+		return nil, translateError(err, domainerr.ErrBreweryPostNotFound, nil)
+	}
+	if updated == 0 {
+		o.Rollback()
+		return nil, domainerr.ErrBreweryPostNotFound
+	}
+
+	if _, err := o.QueryTable("brewery_post_image").Filter("post_id", model.Id).Delete(); err != nil {
+		o.Rollback()
+		return nil, err
+	}
+	if err := r.insertImages(o, model.Id, post.ImageURLs()); err != nil {
+		o.Rollback()
+		return nil, err
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(model.Id)
+}
+
+// Delete 投稿を削除する（画像は外部キーの CASCADE で削除される）
+func (r *beegoBreweryPostRepository) Delete(id int) error {
+	deleted, err := r.orm.QueryTable("brewery_post").Filter("id", id).Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrBreweryPostNotFound
+	}
+
+	return nil
+}
+
+// insertImages 投稿の画像を表示順に登録する
+func (r *beegoBreweryPostRepository) insertImages(o orm.Ormer, postID int, imageURLs []string) error {
+	for i, imageURL := range imageURLs {
+		image := &models.BreweryPostImage{
+			Post:      &models.BreweryPost{Id: postID},
+			Url:       imageURL,
+			SortOrder: i,
+		}
+		if _, err := o.Insert(image); err != nil {
+			return translateError(err, nil, nil)
+		}
+	}
+	return nil
+}
+
+// getImageURLs 投稿の画像のURLを投稿ごとに表示順で取得する
+func (r *beegoBreweryPostRepository) getImageURLs(postIDs []int) (map[int][]string, error) {
+	imageURLs := make(map[int][]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return imageURLs, nil
+	}
+
+	var imageModels []*models.BreweryPostImage
+	_, err := r.orm.QueryTable("brewery_post_image").
+		Filter("post_id__in", postIDs).
+		OrderBy("sort_order", "id").
+		All(&imageModels)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range imageModels {
+		imageURLs[image.Post.Id] = append(imageURLs[image.Post.Id], image.Url)
+	}
+
+	return imageURLs, nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoBreweryPostRepository) modelToEntity(model *models.BreweryPost, imageURLs []string) (*entity.BreweryPost, error) {
+	var authorID *int
+	if model.Author != nil && model.Author.Id > 0 {
+		id := model.Author.Id
+		authorID = &id
+	}
+
+	return entity.NewBreweryPostBuilder().
+		WithID(model.Id).
+		WithBreweryID(model.Brewery.Id).
+		WithAuthorUserProfileID(authorID).
+		WithTitle(model.Title).
+		WithBody(model.Body).
+		WithImageURLs(imageURLs).
+		WithPublishAt(model.PublishAt).
+		WithPinned(model.IsPinned).
+		WithVisibility(model.Visibility, model.MinFanRank).
+		WithCreatedAt(model.CreatedAt).
+		WithUpdatedAt(model.UpdatedAt).
+		Build()
+}
+
+// entityToModel エンティティからモデルに変換する
+func (r *beegoBreweryPostRepository) entityToModel(e *entity.BreweryPost) *models.BreweryPost {
+	model := &models.BreweryPost{
+		Id:         e.ID(),
+		Brewery:    &models.Brewery{Id: e.BreweryID()},
+		Title:      e.Title(),
+		Body:       e.Body(),
+		PublishAt:  e.PublishAt(),
+		IsPinned:   e.IsPinned(),
+		Visibility: e.Visibility(),
+		MinFanRank: e.MinFanRank(),
+		CreatedAt:  e.CreatedAt(),
+		UpdatedAt:  e.UpdatedAt(),
+	}
+	if e.AuthorUserProfileID() != nil {
+		model.Author = &models.UserProfile{Id: *e.AuthorUserProfileID()}
+	}
+	return model
+}
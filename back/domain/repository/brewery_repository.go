@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"math"
+	"mybeerlog/domain/domainerr"
 	"mybeerlog/domain/entity"
 	"mybeerlog/models"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/astaxie/beego/orm"
@@ -11,9 +15,11 @@ import (
 // BreweryRepository 醸造所のデータアクセスインターフェースを定義する
 type BreweryRepository interface {
 	GetByID(id int) (*entity.Brewery, error)
-	GetAll(limit, offset int) ([]*entity.Brewery, int, error)
-	GetByLocation(lat, lng, radius float64, limit, offset int) ([]*entity.Brewery, int, error)
+	GetAll(styleIDs []int, page PageQuery) ([]*entity.Brewery, *PageResult, error)
+	GetByLocation(lat, lng, radiusM float64, styleIDs []int, limit, offset int) ([]*entity.NearbyBrewery, int, error)
 	Create(brewery *entity.Brewery) (*entity.Brewery, error)
+	Update(brewery *entity.Brewery, expectedUpdatedAt time.Time) (*entity.Brewery, error)
+	Archive(id int, expectedUpdatedAt time.Time) (*entity.Brewery, error)
 }
 
 // beegoBreweryRepository Beego ORMを使用してBreweryRepositoryを実装する
@@ -32,92 +38,188 @@ func NewBreweryRepository() BreweryRepository {
 func (r *beegoBreweryRepository) GetByID(id int) (*entity.Brewery, error) {
 	model := &models.Brewery{}
 	err := r.orm.QueryTable("brewery").Filter("id", id).One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrBreweryNotFound, nil)
+	}
+
+	counts, err := r.getWantToGoCounts([]int{model.Id})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.modelToEntity(model)
+	return r.modelToEntity(model, counts[model.Id])
 }
 
-// GetAll 全ての醸造所を取得する
-func (r *beegoBreweryRepository) GetAll(limit, offset int) ([]*entity.Brewery, int, error) {
+// GetAll 全ての醸造所を (created_at, id) の新しい順に取得する（styleIDs を指定した場合はいずれかのスタイルを扱う醸造所のみ）
+func (r *beegoBreweryRepository) GetAll(styleIDs []int, page PageQuery) ([]*entity.Brewery, *PageResult, error) {
 	var models []*models.Brewery
 
-	qs := r.orm.QueryTable("brewery").OrderBy("-created_at")
+	qs := r.orm.QueryTable("brewery").Filter("deleted_at__isnull", true)
+	if len(styleIDs) > 0 {
+		qs = qs.FilterRaw("id", breweryStyleCondition(styleIDs))
+	}
 
-	// 総数取得
-	total, err := qs.Count()
-	if err != nil {
-		return nil, 0, err
+	// 総数取得（ページの位置に関係なく条件に一致する件数）
+	var total *int
+	if page.IncludeTotal {
+		count, err := qs.Count()
+		if err != nil {
+			return nil, nil, err
+		}
+		value := int(count)
+		total = &value
 	}
 
 	// ページネーション
-	_, err = qs.Limit(limit, offset).All(&models)
+	pageQS, reversed := applyPage(qs, "created_at", page, true)
+	if _, err := pageQS.All(&models); err != nil {
+		return nil, nil, err
+	}
+	result := pageResult(page, len(models))
+	result.Total = total
+	models = trimPage(models, page.Limit, reversed)
+
+	ids := make([]int, len(models))
+	for i, model := range models {
+		ids[i] = model.Id
+	}
+	counts, err := r.getWantToGoCounts(ids)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	entities := make([]*entity.Brewery, len(models))
 	for i, model := range models {
-		entity, err := r.modelToEntity(model)
+		entity, err := r.modelToEntity(model, counts[model.Id])
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, err
 		}
 		entities[i] = entity
 	}
 
-	return entities, int(total), nil
+	return entities, result, nil
 }
 
-// GetByLocation 位置情報で醸造所を検索する
-func (r *beegoBreweryRepository) GetByLocation(lat, lng, radius float64, limit, offset int) ([]*entity.Brewery, int, error) {
-	var models []*models.Brewery
+// GetByLocation 指定地点から半径 radiusM メートル以内の醸造所を距離の近い順に取得する
+// 経度・緯度の範囲で候補を絞り込んだ後、ハーバサイン公式による大円距離で判定する
+func (r *beegoBreweryRepository) GetByLocation(lat, lng, radiusM float64, styleIDs []int, limit, offset int) ([]*entity.NearbyBrewery, int, error) {
+	boxCondition, boxArgs := boundingBoxCondition(lat, lng, radiusM)
+	if len(styleIDs) > 0 {
+		boxCondition += " AND id " + breweryStyleCondition(styleIDs)
+	}
 
-	// PostgreSQLのearth拡張を使用した距離検索
-	// 注意: この実装では簡易的な矩形範囲検索を使用
-	// 実際の運用ではPostGIS等の地理空間拡張の使用を推奨
-	latRange := radius / 111000.0 // 緯度1度 ≈ 111km
-	lngRange := radius / (111000.0 * cosineApprox(lat))
-
-	sql := `SELECT * FROM brewery 
-			WHERE latitude BETWEEN ? AND ? 
-			AND longitude BETWEEN ? AND ?
-			ORDER BY created_at DESC
-			LIMIT ? OFFSET ?`
-
-	_, err := r.orm.Raw(sql,
-		lat-latRange, lat+latRange,
-		lng-lngRange, lng+lngRange,
-		limit, offset).QueryRows(&models)
-	if err != nil {
+	// 大円距離（Brewery.DistanceFrom と同じハーバサイン公式）。浮動小数点誤差で asin の定義域を超えないよう LEAST で丸める
+	nearbySQL := `SELECT id, distance_m FROM (
+				SELECT id, 2 * ? * ASIN(LEAST(1.0, SQRT(
+					POWER(SIN(RADIANS(latitude - ?) / 2), 2) +
+					COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2)
+				))) AS distance_m
+				FROM brewery
+				WHERE deleted_at IS NULL AND ` + boxCondition + `
+			) nearby
+			WHERE distance_m <= ?`
+	args := []interface{}{entity.EarthRadiusMeters, lat, lat, lng}
+	args = append(args, boxArgs...)
+	args = append(args, radiusM)
+
+	// 総数取得（円内の件数）
+	var total int64
+	if err := r.orm.Raw(`SELECT COUNT(*) FROM (`+nearbySQL+`) counted`, args...).QueryRow(&total); err != nil {
 		return nil, 0, err
 	}
 
-	// 総数取得（簡易版）
-	countSql := `SELECT COUNT(*) FROM brewery 
-				 WHERE latitude BETWEEN ? AND ? 
-				 AND longitude BETWEEN ? AND ?`
+	var rows []struct {
+		Id        int
+		DistanceM float64
+	}
+	pageArgs := append(append([]interface{}{}, args...), limit, offset)
+	if _, err := r.orm.Raw(nearbySQL+` ORDER BY distance_m ASC, id ASC LIMIT ? OFFSET ?`, pageArgs...).QueryRows(&rows); err != nil {
+		return nil, 0, err
+	}
+	if len(rows) == 0 {
+		return []*entity.NearbyBrewery{}, int(total), nil
+	}
 
-	var total int64
-	err = r.orm.Raw(countSql,
-		lat-latRange, lat+latRange,
-		lng-lngRange, lng+lngRange).QueryRow(&total)
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		ids[i] = row.Id
+	}
+
+	var breweryModels []*models.Brewery
+	if _, err := r.orm.QueryTable("brewery").Filter("id__in", ids).All(&breweryModels); err != nil {
+		return nil, 0, err
+	}
+	modelsByID := make(map[int]*models.Brewery, len(breweryModels))
+	for _, model := range breweryModels {
+		modelsByID[model.Id] = model
+	}
+	counts, err := r.getWantToGoCounts(ids)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	entities := make([]*entity.Brewery, len(models))
-	for i, model := range models {
-		entity, err := r.modelToEntity(model)
+	// 距離順を保ったままエンティティに変換する
+	entities := make([]*entity.NearbyBrewery, 0, len(rows))
+	for _, row := range rows {
+		model, ok := modelsByID[row.Id]
+		if !ok {
+			continue
+		}
+		brewery, err := r.modelToEntity(model, counts[row.Id])
 		if err != nil {
 			return nil, 0, err
 		}
-		entities[i] = entity
+		entities = append(entities, entity.NewNearbyBrewery(brewery, row.DistanceM))
 	}
 
 	return entities, int(total), nil
 }
 
+// breweryStyleCondition 指定したスタイルのいずれかを扱う醸造所に絞り込む条件（id に対する IN 句）を組み立てる
+// スタイルIDは整数のため、SQLに直接埋め込む
+func breweryStyleCondition(styleIDs []int) string {
+	ids := make([]string, len(styleIDs))
+	for i, id := range styleIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	return "IN (SELECT brewery_id FROM brewery_style WHERE beer_style_id IN (" + strings.Join(ids, ", ") + "))"
+}
+
+// boundingBoxCondition 半径 radiusM の円を包含する緯度・経度範囲の検索条件を組み立てる
+// 極を含む場合は経度で絞り込まず、日付変更線をまたぐ場合は経度範囲を2つに分割する
+func boundingBoxCondition(lat, lng, radiusM float64) (string, []interface{}) {
+	angularRadius := radiusM / entity.EarthRadiusMeters
+	if angularRadius >= math.Pi {
+		return "1 = 1", nil
+	}
+
+	angularDegrees := angularRadius * 180 / math.Pi
+	minLat := lat - angularDegrees
+	maxLat := lat + angularDegrees
+
+	// 円が極を含む場合は全経度が対象となる
+	if minLat <= -90 || maxLat >= 90 {
+		return "latitude BETWEEN ? AND ?", []interface{}{math.Max(minLat, -90), math.Min(maxLat, 90)}
+	}
+
+	latRad := lat * math.Pi / 180
+	deltaLng := math.Asin(math.Sin(angularRadius)/math.Cos(latRad)) * 180 / math.Pi
+	minLng := lng - deltaLng
+	maxLng := lng + deltaLng
+
+	switch {
+	case minLng < -180:
+		return "latitude BETWEEN ? AND ? AND (longitude >= ? OR longitude <= ?)",
+			[]interface{}{minLat, maxLat, minLng + 360, maxLng}
+	case maxLng > 180:
+		return "latitude BETWEEN ? AND ? AND (longitude >= ? OR longitude <= ?)",
+			[]interface{}{minLat, maxLat, minLng, maxLng - 360}
+	default:
+		return "latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?",
+			[]interface{}{minLat, maxLat, minLng, maxLng}
+	}
+}
+
 // Create 醸造所を作成する
 func (r *beegoBreweryRepository) Create(brewery *entity.Brewery) (*entity.Brewery, error) {
 	model := r.entityToModel(brewery)
@@ -125,25 +227,108 @@ func (r *beegoBreweryRepository) Create(brewery *entity.Brewery) (*entity.Brewer
 	model.UpdatedAt = time.Now()
 
 	_, err := r.orm.Insert(model)
+	if err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	// DBに保存された値（updated_at の精度を含む）で返すため再取得する
+	return r.GetByID(model.Id)
+}
+
+// Update 醸造所を更新する（updated_at による楽観的排他制御を行う）
+func (r *beegoBreweryRepository) Update(brewery *entity.Brewery, expectedUpdatedAt time.Time) (*entity.Brewery, error) {
+	sql := `UPDATE brewery
+			SET name = ?, address = ?, prefecture = ?, description = ?, latitude = ?, longitude = ?, updated_at = ?
+			WHERE id = ? AND deleted_at IS NULL AND updated_at = ?`
+
+	result, err := r.orm.Raw(sql,
+		brewery.Name(), brewery.Address(), brewery.Prefecture(), brewery.Description(),
+		brewery.Latitude(), brewery.Longitude(),
+		formatDBTimestamp(time.Now()),
+		brewery.ID(), formatDBTimestamp(expectedUpdatedAt)).Exec()
 	if err != nil {
 		return nil, err
 	}
 
-	// 作成されたエンティティを返す
-	return r.modelToEntity(model)
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, r.resolveWriteConflict(brewery.ID())
+	}
+
+	// 更新されたエンティティを返す
+	return r.GetByID(brewery.ID())
 }
 
+// Archive 醸造所をアーカイブ（論理削除）する
+// 訪問履歴を保持するため物理削除は行わない。expectedUpdatedAt がゼロ値の場合は排他制御を行わない（ユースケースでは必須とする）
+func (r *beegoBreweryRepository) Archive(id int, expectedUpdatedAt time.Time) (*entity.Brewery, error) {
+	now := formatDBTimestamp(time.Now())
+	sql := `UPDATE brewery
+			SET status = ?, deleted_at = ?, updated_at = ?
+			WHERE id = ? AND deleted_at IS NULL`
+	args := []interface{}{entity.BreweryStatusArchived, now, now, id}
+	if !expectedUpdatedAt.IsZero() {
+		sql += " AND updated_at = ?"
+		args = append(args, formatDBTimestamp(expectedUpdatedAt))
+	}
+
+	result, err := r.orm.Raw(sql, args...).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, r.resolveWriteConflict(id)
+	}
+
+	return r.GetByID(id)
+}
+
+// resolveWriteConflict 更新件数が0件だった理由（不存在・アーカイブ済み・競合）を判別する
+func (r *beegoBreweryRepository) resolveWriteConflict(id int) error {
+	current, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if current.IsArchived() {
+		return domainerr.ErrBreweryNotFound
+	}
+	return domainerr.ErrBreweryModified
+}
+
+// getWantToGoCounts 醸造所ごとに行きたいリストに登録しているユーザー数を集計する（0件の醸造所は結果に含まれない）
+func (r *beegoBreweryRepository) getWantToGoCounts(breweryIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(breweryIDs))
+	if len(breweryIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(breweryIDs)), ", ")
+	var rows []struct {
+		BreweryId int
+		Count     int
+	}
+	_, err := r.orm.Raw(`SELECT brewery_id, COUNT(*) AS count
+			FROM wishlist_item
+			WHERE brewery_id IN (`+placeholders+`)
+			GROUP BY brewery_id`, breweryIDs).QueryRows(&rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.BreweryId] = row.Count
+	}
+
+	return counts, nil
+}
 
 // modelToEntity モデルからエンティティに変換する
-func (r *beegoBreweryRepository) modelToEntity(model *models.Brewery) (*entity.Brewery, error) {
-	return entity.NewBreweryBuilder().
-		WithID(model.Id).
-		WithName(model.Name).
-		WithAddress(model.Address).
-		WithDescription(model.Description).
-		WithLocation(model.Latitude, model.Longitude).
-		WithCreatedAt(model.CreatedAt).
-		WithUpdatedAt(model.UpdatedAt).
+func (r *beegoBreweryRepository) modelToEntity(model *models.Brewery, wantToGoCount int) (*entity.Brewery, error) {
+	return breweryModelToBuilder(model).
+		WithWantToGoCount(wantToGoCount).
 		Build()
 }
 
@@ -153,22 +338,39 @@ func (r *beegoBreweryRepository) entityToModel(e *entity.Brewery) *models.Brewer
 		Id:          e.ID(),
 		Name:        e.Name(),
 		Address:     e.Address(),
+		Prefecture:  e.Prefecture(),
 		Description: e.Description(),
 		Latitude:    e.Latitude(),
 		Longitude:   e.Longitude(),
+		Status:      e.Status(),
+		DeletedAt:   e.DeletedAt(),
 		CreatedAt:   e.CreatedAt(),
 		UpdatedAt:   e.UpdatedAt(),
 	}
 }
 
-// cosineApprox 簡易コサイン近似（緯度の経度換算用）
-func cosineApprox(lat float64) float64 {
-	// 日本付近の緯度での近似値
-	if lat < 0 {
-		lat = -lat
-	}
-	if lat > 45 {
-		return 0.7 // 北海道付近
-	}
-	return 0.8 // 本州付近
+// breweryModelToEntity 醸造所モデルからエンティティに変換する（他リポジトリの関連読み込みでも使用する）
+func breweryModelToEntity(model *models.Brewery) (*entity.Brewery, error) {
+	return breweryModelToBuilder(model).Build()
+}
+
+// breweryModelToBuilder 醸造所モデルの値を設定した BreweryBuilder を作成する
+func breweryModelToBuilder(model *models.Brewery) *entity.BreweryBuilder {
+	return entity.NewBreweryBuilder().
+		WithID(model.Id).
+		WithName(model.Name).
+		WithAddress(model.Address).
+		WithPrefecture(model.Prefecture).
+		WithDescription(model.Description).
+		WithLocation(model.Latitude, model.Longitude).
+		WithStatus(model.Status).
+		WithDeletedAt(model.DeletedAt).
+		WithCreatedAt(model.CreatedAt).
+		WithUpdatedAt(model.UpdatedAt)
+}
+
+// formatDBTimestamp 生SQLのパラメータ用に日時をマイクロ秒精度の文字列に変換する
+// Beego ORM は time.Time のパラメータを秒精度に丸めるため、updated_at の比較には使用できない
+func formatDBTimestamp(t time.Time) string {
+	return t.In(orm.DefaultTimeLoc).Round(time.Microsecond).Format("2006-01-02 15:04:05.999999")
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// CalendarFeedRepository カレンダー購読設定のデータアクセスインターフェースを定義する
+type CalendarFeedRepository interface {
+	GetByUserProfile(userProfileID int) (*entity.CalendarFeed, error)
+	GetByTokenHash(tokenHash string) (*entity.CalendarFeed, error)
+	Rotate(userProfileID int, tokenHash string, rotatedAt time.Time) (*entity.CalendarFeed, error)
+	Delete(userProfileID int) error
+}
+
+// beegoCalendarFeedRepository Beego ORMを使用してCalendarFeedRepositoryを実装する
+type beegoCalendarFeedRepository struct {
+	orm orm.Ormer
+}
+
+// NewCalendarFeedRepository 新しいCalendarFeedRepositoryインスタンスを作成する
+func NewCalendarFeedRepository() CalendarFeedRepository {
+	return &beegoCalendarFeedRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByUserProfile ユーザーのカレンダー購読設定を取得する
+func (r *beegoCalendarFeedRepository) GetByUserProfile(userProfileID int) (*entity.CalendarFeed, error) {
+	model := &models.CalendarFeed{}
+	err := r.orm.QueryTable("calendar_feed").Filter("user_profile_id", userProfileID).One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrCalendarFeedNotFound, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// GetByTokenHash トークンのハッシュでカレンダー購読設定を取得する
+func (r *beegoCalendarFeedRepository) GetByTokenHash(tokenHash string) (*entity.CalendarFeed, error) {
+	model := &models.CalendarFeed{}
+	err := r.orm.QueryTable("calendar_feed").Filter("token_hash", tokenHash).One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrCalendarFeedNotFound, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// Rotate トークンを発行する（発行済みの場合は新しいトークンに置き換え、以前の購読URLは無効になる）
+func (r *beegoCalendarFeedRepository) Rotate(userProfileID int, tokenHash string, rotatedAt time.Time) (*entity.CalendarFeed, error) {
+	var id int
+	sql := `INSERT INTO calendar_feed (user_profile_id, token_hash, rotated_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (user_profile_id) DO UPDATE
+			SET token_hash = EXCLUDED.token_hash, rotated_at = EXCLUDED.rotated_at
+			RETURNING id`
+	err := r.orm.Raw(sql, userProfileID, tokenHash, formatDBTimestamp(rotatedAt)).QueryRow(&id)
+	if err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	return entity.NewCalendarFeedBuilder().
+		WithID(id).
+		WithUserProfileID(userProfileID).
+		WithTokenHash(tokenHash).
+		WithRotatedAt(rotatedAt).
+		Build()
+}
+
+// Delete カレンダー購読設定を削除する（購読URLは無効になる）
+func (r *beegoCalendarFeedRepository) Delete(userProfileID int) error {
+	deleted, err := r.orm.QueryTable("calendar_feed").Filter("user_profile_id", userProfileID).Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrCalendarFeedNotFound
+	}
+
+	return nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoCalendarFeedRepository) modelToEntity(model *models.CalendarFeed) (*entity.CalendarFeed, error) {
+	return entity.NewCalendarFeedBuilder().
+		WithID(model.Id).
+		WithUserProfileID(model.UserProfile.Id).
+		WithTokenHash(model.TokenHash).
+		WithRotatedAt(model.RotatedAt).
+		Build()
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// CheckinSecretRepository 醸造所のQRチェックイン用シークレットのデータアクセスインターフェースを定義する
+type CheckinSecretRepository interface {
+	GetByBrewery(breweryID int) (*entity.CheckinSecret, error)
+	Ensure(breweryID int, secret string, createdAt time.Time) (*entity.CheckinSecret, error)
+	Rotate(breweryID int, secret string, rotatedAt time.Time) (*entity.CheckinSecret, error)
+}
+
+// beegoCheckinSecretRepository Beego ORMを使用してCheckinSecretRepositoryを実装する
+type beegoCheckinSecretRepository struct {
+	orm orm.Ormer
+}
+
+// NewCheckinSecretRepository 新しいCheckinSecretRepositoryインスタンスを作成する
+func NewCheckinSecretRepository() CheckinSecretRepository {
+	return &beegoCheckinSecretRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByBrewery 醸造所のシークレットを取得する（未発行の場合は domainerr.ErrNotFound）
+func (r *beegoCheckinSecretRepository) GetByBrewery(breweryID int) (*entity.CheckinSecret, error) {
+	model := &models.BreweryCheckinSecret{}
+	err := r.orm.QueryTable("brewery_checkin_secret").Filter("brewery_id", breweryID).One(model)
+	if err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// Ensure 醸造所のシークレットを取得する（未発行の場合は secret で発行する）
+// 同時に発行された場合も先に保存されたシークレットを返すため、掲示中のコードが入れ替わることはない
+func (r *beegoCheckinSecretRepository) Ensure(breweryID int, secret string, createdAt time.Time) (*entity.CheckinSecret, error) {
+	sql := `INSERT INTO brewery_checkin_secret (brewery_id, secret, rotated_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (brewery_id) DO NOTHING`
+	if _, err := r.orm.Raw(sql, breweryID, secret, formatDBTimestamp(createdAt)).Exec(); err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	return r.GetByBrewery(breweryID)
+}
+
+// Rotate シークレットを再発行する（以前のシークレットから算出したコードは無効になる）
+func (r *beegoCheckinSecretRepository) Rotate(breweryID int, secret string, rotatedAt time.Time) (*entity.CheckinSecret, error) {
+	var id int
+	sql := `INSERT INTO brewery_checkin_secret (brewery_id, secret, rotated_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (brewery_id) DO UPDATE
+			SET secret = EXCLUDED.secret, rotated_at = EXCLUDED.rotated_at
+			RETURNING id`
+	err := r.orm.Raw(sql, breweryID, secret, formatDBTimestamp(rotatedAt)).QueryRow(&id)
+	if err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	return entity.NewCheckinSecretBuilder().
+		WithID(id).
+		WithBreweryID(breweryID).
+		WithSecret(secret).
+		WithRotatedAt(rotatedAt).
+		Build()
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoCheckinSecretRepository) modelToEntity(model *models.BreweryCheckinSecret) (*entity.CheckinSecret, error) {
+	return entity.NewCheckinSecretBuilder().
+		WithID(model.Id).
+		WithBreweryID(model.Brewery.Id).
+		WithSecret(model.Secret).
+		WithRotatedAt(model.RotatedAt).
+		Build()
+}
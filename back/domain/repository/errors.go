@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"errors"
+	"mybeerlog/domain/domainerr"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/lib/pq"
+)
+
+// PostgreSQLの一意制約違反のエラーコード
+const pqUniqueViolation = "23505"
+
+// translateError ORM・データベースのエラーをドメインエラーに変換する
+// レコードが存在しない場合は notFound を、一意制約違反の場合は conflict を返す（nil の場合は汎用エラー）
+// それ以外のエラー（DB障害など）はそのまま返す
+func translateError(err error, notFound, conflict *domainerr.Error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, orm.ErrNoRows) {
+		if notFound == nil {
+			notFound = domainerr.ErrNotFound
+		}
+		return notFound
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+		if conflict == nil {
+			conflict = domainerr.ErrConflict
+		}
+		return domainerr.Wrap(conflict, err)
+	}
+
+	return err
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// EventRepository 醸造所のイベントのデータアクセスインターフェースを定義する
+type EventRepository interface {
+	GetByID(id int) (*entity.Event, error)
+	GetByBrewery(breweryID int, endsAfter time.Time, limit, offset int) ([]*entity.Event, int, error)
+	Create(event *entity.Event) (*entity.Event, error)
+	Update(event *entity.Event) (*entity.Event, []int, error)
+	Delete(id int) error
+}
+
+// eventRSVPCounts イベントの参加申込の集計結果
+type eventRSVPCounts struct {
+	EventId       int
+	GoingCount    int
+	WaitlistCount int
+}
+
+// beegoEventRepository Beego ORMを使用してEventRepositoryを実装する
+type beegoEventRepository struct {
+	orm orm.Ormer
+}
+
+// NewEventRepository 新しいEventRepositoryインスタンスを作成する
+func NewEventRepository() EventRepository {
+	return &beegoEventRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByID IDでイベントを醸造所情報・参加申込の集計とともに取得する
+func (r *beegoEventRepository) GetByID(id int) (*entity.Event, error) {
+	model := &models.Event{}
+	err := r.orm.QueryTable("event").Filter("id", id).RelatedSel("brewery").One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrEventNotFound, nil)
+	}
+
+	counts, err := r.getRSVPCounts([]int{model.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modelToEntity(model, counts[model.Id])
+}
+
+// GetByBrewery 指定日時より後に終了する醸造所のイベントを開始日時の早い順に取得する
+func (r *beegoEventRepository) GetByBrewery(breweryID int, endsAfter time.Time, limit, offset int) ([]*entity.Event, int, error) {
+	var eventModels []*models.Event
+
+	qs := r.orm.QueryTable("event").
+		Filter("brewery_id", breweryID).
+		Filter("end_at__gt", endsAfter).
+		OrderBy("start_at", "id")
+
+	// 総数取得
+	total, err := qs.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// ページネーション
+	_, err = qs.RelatedSel("brewery").Limit(limit, offset).All(&eventModels)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int, len(eventModels))
+	for i, model := range eventModels {
+		ids[i] = model.Id
+	}
+	counts, err := r.getRSVPCounts(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Event, len(eventModels))
+	for i, model := range eventModels {
+		event, err := r.modelToEntity(model, counts[model.Id])
+		if err != nil {
+			return nil, 0, err
+		}
+		entities[i] = event
+	}
+
+	return entities, int(total), nil
+}
+
+// Create イベントを作成する
+func (r *beegoEventRepository) Create(event *entity.Event) (*entity.Event, error) {
+	model := r.entityToModel(event)
+
+	if _, err := r.orm.Insert(model); err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	return r.GetByID(model.Id)
+}
+
+// Update イベントを更新する
+// 定員が増えた場合は空いた席の分だけキャンセル待ちを申込順に繰り上げ、繰り上がったユーザーのIDを返す
+// 定員が減った場合も参加確定済みの申込はそのまま残す
+func (r *beegoEventRepository) Update(event *entity.Event) (*entity.Event, []int, error) {
+	// トランザクションはリクエスト間で共有しない Ormer で実行する
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, nil, err
+	}
+
+	// 参加申込と同じ行ロックで、定員の変更と申込の受付を直列化する
+	if _, err := lockEventCapacity(o, event.ID()); err != nil {
+		o.Rollback()
+		return nil, nil, err
+	}
+
+	model := r.entityToModel(event)
+	if _, err := o.Update(model, "Title", "Description", "Location", "StartAt", "EndAt", "TimeZone", "Capacity", "UpdatedAt"); err != nil {
+		o.Rollback()
+		return nil, nil, translateError(err, domainerr.ErrEventNotFound, nil)
+	}
+
+	promoted, err := promoteWaitlist(o, event.ID(), event.Capacity())
+	if err != nil {
+		o.Rollback()
+		return nil, nil, err
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	updated, err := r.GetByID(event.ID())
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, promoted, nil
+}
+
+// Delete イベントを削除する（参加申込は外部キーの CASCADE で削除される）
+func (r *beegoEventRepository) Delete(id int) error {
+	deleted, err := r.orm.QueryTable("event").Filter("id", id).Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrEventNotFound
+	}
+
+	return nil
+}
+
+// getRSVPCounts 参加確定・キャンセル待ちの人数をイベントごとに集計する（申込のないイベントは結果に含まれない）
+func (r *beegoEventRepository) getRSVPCounts(eventIDs []int) (map[int]*eventRSVPCounts, error) {
+	counts := make(map[int]*eventRSVPCounts, len(eventIDs))
+	if len(eventIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(eventIDs)), ", ")
+	sql := `SELECT event_id,
+				COUNT(*) FILTER (WHERE status = ?) AS going_count,
+				COUNT(*) FILTER (WHERE status = ?) AS waitlist_count
+			FROM event_rsvp
+			WHERE event_id IN (` + placeholders + `)
+			GROUP BY event_id`
+
+	var rows []eventRSVPCounts
+	if _, err := r.orm.Raw(sql, entity.RSVPStatusGoing, entity.RSVPStatusWaitlisted, eventIDs).QueryRows(&rows); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		counts[rows[i].EventId] = &rows[i]
+	}
+
+	return counts, nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoEventRepository) modelToEntity(model *models.Event, counts *eventRSVPCounts) (*entity.Event, error) {
+	builder := entity.NewEventBuilder().
+		WithID(model.Id).
+		WithBreweryID(model.Brewery.Id).
+		WithTitle(model.Title).
+		WithDescription(model.Description).
+		WithLocation(model.Location).
+		WithSchedule(model.StartAt, model.EndAt, model.TimeZone).
+		WithCapacity(model.Capacity).
+		WithCreatedAt(model.CreatedAt).
+		WithUpdatedAt(model.UpdatedAt)
+
+	if counts != nil {
+		builder = builder.WithRSVPCounts(counts.GoingCount, counts.WaitlistCount)
+	}
+
+	// 関連する醸造所情報がある場合
+	if model.Brewery.Name != "" {
+		brewery, err := breweryModelToEntity(model.Brewery)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.WithBrewery(brewery)
+	}
+
+	return builder.Build()
+}
+
+// entityToModel エンティティからモデルに変換する
+func (r *beegoEventRepository) entityToModel(e *entity.Event) *models.Event {
+	return &models.Event{
+		Id:          e.ID(),
+		Brewery:     &models.Brewery{Id: e.BreweryID()},
+		Title:       e.Title(),
+		Description: e.Description(),
+		Location:    e.Location(),
+		StartAt:     e.StartAt(),
+		EndAt:       e.EndAt(),
+		TimeZone:    e.TimeZone(),
+		Capacity:    e.Capacity(),
+		CreatedAt:   e.CreatedAt(),
+		UpdatedAt:   e.UpdatedAt(),
+	}
+}
+
+// lockEventCapacity イベントの行をロックして定員を取得する
+// 同じイベントへの参加申込・キャンセル・定員の変更はこのロックで直列化し、同時申込でも定員を超えないようにする
+func lockEventCapacity(o orm.Ormer, eventID int) (int, error) {
+	var capacity int
+	err := o.Raw("SELECT capacity FROM event WHERE id = ? FOR UPDATE", eventID).QueryRow(&capacity)
+	if err != nil {
+		return 0, translateError(err, domainerr.ErrEventNotFound, nil)
+	}
+	return capacity, nil
+}
+
+// promoteWaitlist 空いている席の分だけキャンセル待ちを申込順に参加確定へ繰り上げ、繰り上がったユーザーのIDを返す
+// lockEventCapacity でイベントの行をロックしたトランザクション内で呼び出す
+func promoteWaitlist(o orm.Ormer, eventID, capacity int) ([]int, error) {
+	var goingCount int
+	err := o.Raw("SELECT COUNT(*) FROM event_rsvp WHERE event_id = ? AND status = ?",
+		eventID, entity.RSVPStatusGoing).QueryRow(&goingCount)
+	if err != nil {
+		return nil, err
+	}
+
+	promoted := []int{}
+	openSeats := capacity - goingCount
+	if openSeats <= 0 {
+		return promoted, nil
+	}
+
+	sql := `UPDATE event_rsvp SET status = ?, updated_at = ?
+			WHERE id IN (
+				SELECT id FROM event_rsvp
+				WHERE event_id = ? AND status = ?
+				ORDER BY id
+				LIMIT ?
+			)
+			RETURNING user_profile_id`
+	_, err = o.Raw(sql, entity.RSVPStatusGoing, formatDBTimestamp(time.Now()),
+		eventID, entity.RSVPStatusWaitlisted, openSeats).QueryRows(&promoted)
+	if err != nil {
+		return nil, err
+	}
+
+	return promoted, nil
+}
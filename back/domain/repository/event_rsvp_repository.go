@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// EventRSVPRepository イベントへの参加申込のデータアクセスインターフェースを定義する
+type EventRSVPRepository interface {
+	Get(eventID, userProfileID int) (*entity.EventRSVP, error)
+	Create(eventID, userProfileID int) (*entity.EventRSVP, bool, error)
+	Cancel(eventID, userProfileID int) ([]int, error)
+	MarkAttended(eventID, userProfileID int, attendedAt time.Time) (bool, error)
+}
+
+// beegoEventRSVPRepository Beego ORMを使用してEventRSVPRepositoryを実装する
+type beegoEventRSVPRepository struct {
+	orm orm.Ormer
+}
+
+// NewEventRSVPRepository 新しいEventRSVPRepositoryインスタンスを作成する
+func NewEventRSVPRepository() EventRSVPRepository {
+	return &beegoEventRSVPRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// Get ユーザーのイベントへの参加申込をキャンセル待ちの順番とともに取得する
+func (r *beegoEventRSVPRepository) Get(eventID, userProfileID int) (*entity.EventRSVP, error) {
+	model := &models.EventRsvp{}
+	err := r.orm.QueryTable("event_rsvp").
+		Filter("event_id", eventID).
+		Filter("user_profile_id", userProfileID).
+		One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrEventRSVPNotFound, nil)
+	}
+
+	// キャンセル待ちの順番は自分より前に申し込んだキャンセル待ちの人数から求める
+	position := 0
+	if model.Status == entity.RSVPStatusWaitlisted {
+		err := r.orm.Raw("SELECT COUNT(*) FROM event_rsvp WHERE event_id = ? AND status = ? AND id <= ?",
+			eventID, entity.RSVPStatusWaitlisted, model.Id).QueryRow(&position)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entity.NewEventRSVPBuilder().
+		WithID(model.Id).
+		WithEventID(model.Event.Id).
+		WithUserProfileID(model.UserProfile.Id).
+		WithStatus(model.Status, position).
+		WithAttendedAt(model.AttendedAt).
+		WithCreatedAt(model.CreatedAt).
+		WithUpdatedAt(model.UpdatedAt).
+		Build()
+}
+
+// Create イベントに参加を申し込む（定員に空きがあれば参加確定、満席の場合はキャンセル待ち）
+// 申込済みの場合は既存の申込を返し、created は false
+func (r *beegoEventRSVPRepository) Create(eventID, userProfileID int) (*entity.EventRSVP, bool, error) {
+	// トランザクションはリクエスト間で共有しない Ormer で実行する
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, false, err
+	}
+
+	created, err := r.insertRSVP(o, eventID, userProfileID)
+	if err != nil {
+		o.Rollback()
+		return nil, false, err
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	rsvp, err := r.Get(eventID, userProfileID)
+	if err != nil {
+		return nil, false, err
+	}
+	return rsvp, created, nil
+}
+
+// insertRSVP トランザクション内でイベントの行をロックし、参加確定の人数を確認してから申込を挿入する
+func (r *beegoEventRSVPRepository) insertRSVP(o orm.Ormer, eventID, userProfileID int) (bool, error) {
+	capacity, err := lockEventCapacity(o, eventID)
+	if err != nil {
+		return false, err
+	}
+
+	var goingCount int
+	err = o.Raw("SELECT COUNT(*) FROM event_rsvp WHERE event_id = ? AND status = ?",
+		eventID, entity.RSVPStatusGoing).QueryRow(&goingCount)
+	if err != nil {
+		return false, err
+	}
+
+	status := entity.RSVPStatusGoing
+	if goingCount >= capacity {
+		status = entity.RSVPStatusWaitlisted
+	}
+
+	var insertedID int
+	sql := `INSERT INTO event_rsvp (event_id, user_profile_id, status, created_at, updated_at)
+			VALUES (?, ?, ?, NOW(), NOW())
+			ON CONFLICT (event_id, user_profile_id) DO NOTHING
+			RETURNING id`
+	err = o.Raw(sql, eventID, userProfileID, status).QueryRow(&insertedID)
+	if err == orm.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, translateError(err, nil, nil)
+	}
+	return true, nil
+}
+
+// Cancel 参加申込を取り消す
+// 参加確定の申込を取り消した場合は、空いた席にキャンセル待ちを申込順に繰り上げ、繰り上がったユーザーのIDを返す
+func (r *beegoEventRSVPRepository) Cancel(eventID, userProfileID int) ([]int, error) {
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, err
+	}
+
+	capacity, err := lockEventCapacity(o, eventID)
+	if err != nil {
+		o.Rollback()
+		return nil, err
+	}
+
+	var status string
+	err = o.Raw("DELETE FROM event_rsvp WHERE event_id = ? AND user_profile_id = ? RETURNING status",
+		eventID, userProfileID).QueryRow(&status)
+	if err != nil {
+		o.Rollback()
+		return nil, translateError(err, domainerr.ErrEventRSVPNotFound, nil)
+	}
+
+	promoted := []int{}
+	if status == entity.RSVPStatusGoing {
+		promoted, err = promoteWaitlist(o, eventID, capacity)
+		if err != nil {
+			o.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// MarkAttended 参加確定の申込に参加が確認された日時を記録する（確認済みの場合は false）
+func (r *beegoEventRSVPRepository) MarkAttended(eventID, userProfileID int, attendedAt time.Time) (bool, error) {
+	result, err := r.orm.Raw(`UPDATE event_rsvp SET attended_at = ?, updated_at = ?
+			WHERE event_id = ? AND user_profile_id = ? AND status = ? AND attended_at IS NULL`,
+		formatDBTimestamp(attendedAt), formatDBTimestamp(time.Now()),
+		eventID, userProfileID, entity.RSVPStatusGoing).Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// PageKey キーセットページングの位置（並び順のキーとなる日時とID）
+type PageKey struct {
+	Time time.Time
+	ID   int
+}
+
+// PageQuery 一覧取得のページ指定
+// After / Before のいずれかを指定した場合はキーセットで、それ以外は Offset でページングする
+// キーセットでのページングは件数の多い一覧でも高速で、取得中に行が追加されても重複・欠落が起きない
+type PageQuery struct {
+	Limit        int
+	Offset       int
+	After        *PageKey // 並び順でこのキーより後の行を取得する（次のページ）
+	Before       *PageKey // 並び順でこのキーより前の行を取得する（前のページ）
+	IncludeTotal bool     // 総数を取得するかどうか（総数の取得には条件に一致する全件の走査が必要）
+}
+
+// PageResult ページングの結果
+type PageResult struct {
+	Total   *int // IncludeTotal の場合のみ
+	HasNext bool // 並び順で後ろに行があるかどうか
+	HasPrev bool // 並び順で前に行があるかどうか
+}
+
+// applyPage 一覧の検索条件にページ指定を適用する
+// 一覧は descending の場合は (timeField, id) の降順、それ以外は昇順に並ぶ。次のページの有無を判定するため Limit より1件多く取得する
+// Before を指定した場合は逆順に取得するため、reversed が true になる（取得後に trimPage で並び順に戻す）
+func applyPage(qs orm.QuerySeter, timeField string, page PageQuery, descending bool) (orm.QuerySeter, bool) {
+	key, forward := page.After, true
+	if page.Before != nil {
+		key, forward = page.Before, false
+	}
+
+	// 取得する方向が降順かどうか
+	fetchDescending := descending == forward
+
+	offset := page.Offset
+	if key != nil {
+		timeOp, idOp := ">", "id__gt"
+		if fetchDescending {
+			timeOp, idOp = "<", "id__lt"
+		}
+		keyset := timeCondition(timeField, timeOp, key.Time).
+			OrCond(timeCondition(timeField, "=", key.Time).And(idOp, key.ID))
+
+		cond := qs.GetCond()
+		if cond == nil {
+			cond = orm.NewCondition()
+		}
+		qs = qs.SetCond(cond.AndCond(keyset))
+		offset = 0
+	}
+
+	if fetchDescending {
+		qs = qs.OrderBy("-"+timeField, "-id")
+	} else {
+		qs = qs.OrderBy(timeField, "id")
+	}
+	return qs.Limit(page.Limit+1, offset), !forward
+}
+
+// timeCondition 日時のカラムを比較する条件を作成する
+// Beego ORM は日時のパラメータを（文字列で渡しても）秒精度に丸めるため、マイクロ秒精度のリテラルで比較する
+// リテラルは formatDBTimestamp が生成する数字と記号のみの文字列のため、SQLに埋め込んでも安全
+func timeCondition(field, op string, t time.Time) *orm.Condition {
+	return orm.NewCondition().Raw(field, op+" '"+formatDBTimestamp(t)+"'")
+}
+
+// pageResult 取得した件数（Limit より1件多く取得した結果）からページの前後の有無を判定する
+func pageResult(page PageQuery, fetched int) *PageResult {
+	hasMore := fetched > page.Limit
+	if page.Before != nil {
+		return &PageResult{HasNext: true, HasPrev: hasMore}
+	}
+	return &PageResult{HasNext: hasMore, HasPrev: page.After != nil || page.Offset > 0}
+}
+
+// trimPage Limit より1件多く取得した結果から判定用の行を除き、並び順に揃える
+func trimPage[T any](rows []T, limit int, reversed bool) []T {
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+	if reversed {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	return rows
+}
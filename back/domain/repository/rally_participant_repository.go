@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// RallyParticipantRepository スタンプラリーへの参加とスタンプのデータアクセスインターフェースを定義する
+type RallyParticipantRepository interface {
+	Get(rallyID, userProfileID int) (*entity.RallyParticipant, error)
+	Join(rallyID, userProfileID int, joinedAt time.Time) (*entity.RallyParticipant, bool, error)
+	StampVisit(userProfileID, breweryID, visitID int, stampedAt time.Time) ([]*entity.RallyParticipant, error)
+	RedeemReward(rallyID int, rewardCode string, redeemedAt time.Time) (*entity.RallyParticipant, error)
+}
+
+// beegoRallyParticipantRepository Beego ORMを使用してRallyParticipantRepositoryを実装する
+type beegoRallyParticipantRepository struct {
+	orm orm.Ormer
+}
+
+// NewRallyParticipantRepository 新しいRallyParticipantRepositoryインスタンスを作成する
+func NewRallyParticipantRepository() RallyParticipantRepository {
+	return &beegoRallyParticipantRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// Get スタンプラリーへの参加をスタンプラリーの情報・獲得したスタンプとともに取得する
+func (r *beegoRallyParticipantRepository) Get(rallyID, userProfileID int) (*entity.RallyParticipant, error) {
+	model := &models.RallyParticipant{}
+	err := r.orm.QueryTable("rally_participant").
+		Filter("rally_id", rallyID).
+		Filter("user_profile_id", userProfileID).
+		One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrRallyNotJoined, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// Join スタンプラリーに参加する（参加済みの場合は既存の参加を返し、created は false）
+func (r *beegoRallyParticipantRepository) Join(rallyID, userProfileID int, joinedAt time.Time) (*entity.RallyParticipant, bool, error) {
+	var insertedID int
+	sql := `INSERT INTO rally_participant (rally_id, user_profile_id, joined_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (rally_id, user_profile_id) DO NOTHING
+			RETURNING id`
+	err := r.orm.Raw(sql, rallyID, userProfileID, formatDBTimestamp(joinedAt)).QueryRow(&insertedID)
+	created := err == nil
+	if err != nil && err != orm.ErrNoRows {
+		return nil, false, translateError(err, nil, nil)
+	}
+
+	participant, err := r.Get(rallyID, userProfileID)
+	if err != nil {
+		return nil, false, err
+	}
+	return participant, created, nil
+}
+
+// StampVisit 醸造所への訪問で、参加中かつ開催期間中のスタンプラリーのスタンプを押す
+// 新たにスタンプを押したスタンプラリーへの参加を返す（必要な数が揃った場合は達成とし、特典の引換コードを発行する）
+func (r *beegoRallyParticipantRepository) StampVisit(userProfileID, breweryID, visitID int, stampedAt time.Time) ([]*entity.RallyParticipant, error) {
+	// トランザクションはリクエスト間で共有しない Ormer で実行する
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, err
+	}
+
+	// 参加の行をロックし、同じユーザーの同時チェックインでも達成の判定が漏れないようにする
+	var rallyIDs []int
+	_, err := o.Raw(`SELECT p.rally_id
+			FROM rally_participant p
+			JOIN rally r ON r.id = p.rally_id
+			JOIN rally_brewery rb ON rb.rally_id = p.rally_id AND rb.brewery_id = ?
+			WHERE p.user_profile_id = ? AND r.start_at <= ? AND r.end_at > ?
+			ORDER BY p.rally_id
+			FOR UPDATE OF p`,
+		breweryID, userProfileID, formatDBTimestamp(stampedAt), formatDBTimestamp(stampedAt)).QueryRows(&rallyIDs)
+	if err != nil {
+		o.Rollback()
+		return nil, err
+	}
+	if len(rallyIDs) == 0 {
+		o.Rollback()
+		return []*entity.RallyParticipant{}, nil
+	}
+
+	// 獲得済みの醸造所のスタンプは押し直さない
+	stampedRallyIDs := make([]int, 0, len(rallyIDs))
+	for _, rallyID := range rallyIDs {
+		var stampedRallyID int
+		err := o.Raw(`INSERT INTO rally_stamp (rally_id, user_profile_id, brewery_id, visit_id, stamped_at)
+				VALUES (?, ?, ?, ?, ?)
+				ON CONFLICT (rally_id, user_profile_id, brewery_id) DO NOTHING
+				RETURNING rally_id`,
+			rallyID, userProfileID, breweryID, visitID, formatDBTimestamp(stampedAt)).QueryRow(&stampedRallyID)
+		if err == orm.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			o.Rollback()
+			return nil, translateError(err, nil, nil)
+		}
+		stampedRallyIDs = append(stampedRallyIDs, stampedRallyID)
+	}
+
+	for _, rallyID := range stampedRallyIDs {
+		if _, err := completeRallyParticipants(o, rallyID, userProfileID, stampedAt); err != nil {
+			o.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, err
+	}
+
+	participants := make([]*entity.RallyParticipant, 0, len(stampedRallyIDs))
+	for _, rallyID := range stampedRallyIDs {
+		participant, err := r.Get(rallyID, userProfileID)
+		if err != nil {
+			return nil, err
+		}
+		participants = append(participants, participant)
+	}
+	return participants, nil
+}
+
+// RedeemReward 特典の引換コードを引き換え済みにする
+func (r *beegoRallyParticipantRepository) RedeemReward(rallyID int, rewardCode string, redeemedAt time.Time) (*entity.RallyParticipant, error) {
+	var userProfileID int
+	err := r.orm.Raw(`UPDATE rally_participant SET reward_redeemed_at = ?
+			WHERE rally_id = ? AND reward_code = ? AND reward_redeemed_at IS NULL
+			RETURNING user_profile_id`,
+		formatDBTimestamp(redeemedAt), rallyID, rewardCode).QueryRow(&userProfileID)
+	if err == orm.ErrNoRows {
+		// 引換済みのコードと存在しないコードを区別する
+		redeemed := r.orm.QueryTable("rally_participant").
+			Filter("rally_id", rallyID).
+			Filter("reward_code", rewardCode).
+			Exist()
+		if redeemed {
+			return nil, domainerr.ErrRallyRewardRedeemed
+		}
+		return nil, domainerr.ErrRallyRewardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Get(rallyID, userProfileID)
+}
+
+// modelToEntity モデルからエンティティに変換する（スタンプラリーの情報と獲得したスタンプを読み込む）
+func (r *beegoRallyParticipantRepository) modelToEntity(model *models.RallyParticipant) (*entity.RallyParticipant, error) {
+	rally, err := getRallyByID(r.orm, model.Rally.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var stampModels []*models.RallyStamp
+	_, err = r.orm.QueryTable("rally_stamp").
+		Filter("rally_id", model.Rally.Id).
+		Filter("user_profile_id", model.UserProfile.Id).
+		OrderBy("stamped_at", "id").
+		All(&stampModels)
+	if err != nil {
+		return nil, err
+	}
+	stamps := make([]*entity.RallyStamp, len(stampModels))
+	for i, stamp := range stampModels {
+		var visitID *int
+		if stamp.Visit != nil && stamp.Visit.Id > 0 {
+			id := stamp.Visit.Id
+			visitID = &id
+		}
+		stamps[i] = entity.NewRallyStamp(stamp.Brewery.Id, visitID, stamp.StampedAt)
+	}
+
+	return entity.NewRallyParticipantBuilder().
+		WithID(model.Id).
+		WithRally(rally).
+		WithUserProfileID(model.UserProfile.Id).
+		WithStamps(stamps).
+		WithJoinedAt(model.JoinedAt).
+		WithReward(model.CompletedAt, model.RewardCode, model.RewardRedeemedAt).
+		Build()
+}
+
+// completeRallyParticipants 必要な数のスタンプが揃った未達成の参加者を達成とし、特典の引換コードを発行する
+// userProfileID が 0 の場合はスタンプラリーの全参加者を対象とする。達成とした参加者のユーザーIDを返す
+// 現在の対象醸造所のスタンプのみを数える
+func completeRallyParticipants(o orm.Ormer, rallyID, userProfileID int, completedAt time.Time) ([]int, error) {
+	sql := `SELECT p.user_profile_id
+			FROM rally_participant p
+			JOIN rally r ON r.id = p.rally_id
+			WHERE p.rally_id = ? AND p.completed_at IS NULL
+				AND (? = 0 OR p.user_profile_id = ?)
+				AND (
+					SELECT COUNT(*)
+					FROM rally_stamp s
+					JOIN rally_brewery rb ON rb.rally_id = s.rally_id AND rb.brewery_id = s.brewery_id
+					WHERE s.rally_id = p.rally_id AND s.user_profile_id = p.user_profile_id
+				) >= r.required_count
+			ORDER BY p.id
+			FOR UPDATE OF p`
+	var completed []int
+	if _, err := o.Raw(sql, rallyID, userProfileID, userProfileID).QueryRows(&completed); err != nil {
+		return nil, err
+	}
+
+	for _, id := range completed {
+		rewardCode, err := entity.GenerateRallyRewardCode()
+		if err != nil {
+			return nil, err
+		}
+		_, err = o.Raw(`UPDATE rally_participant SET completed_at = ?, reward_code = ?
+				WHERE rally_id = ? AND user_profile_id = ?`,
+			formatDBTimestamp(completedAt), rewardCode, rallyID, id).Exec()
+		if err != nil {
+			return nil, translateError(err, nil, nil)
+		}
+	}
+
+	return completed, nil
+}
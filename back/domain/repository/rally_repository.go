@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// RallyRepository スタンプラリーのデータアクセスインターフェースを定義する
+type RallyRepository interface {
+	GetByID(id int) (*entity.Rally, error)
+	GetOpen(endsAfter time.Time, limit, offset int) ([]*entity.Rally, int, error)
+	Create(rally *entity.Rally) (*entity.Rally, error)
+	Update(rally *entity.Rally) (*entity.Rally, error)
+	Delete(id int) error
+}
+
+// rallyParticipantCount スタンプラリーの参加者数の集計結果
+type rallyParticipantCount struct {
+	RallyId          int
+	ParticipantCount int
+}
+
+// beegoRallyRepository Beego ORMを使用してRallyRepositoryを実装する
+type beegoRallyRepository struct {
+	orm orm.Ormer
+}
+
+// NewRallyRepository 新しいRallyRepositoryインスタンスを作成する
+func NewRallyRepository() RallyRepository {
+	return &beegoRallyRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByID IDでスタンプラリーを対象醸造所・参加者数とともに取得する
+func (r *beegoRallyRepository) GetByID(id int) (*entity.Rally, error) {
+	return getRallyByID(r.orm, id)
+}
+
+// GetOpen 指定日時より後に終了するスタンプラリーを開始日時の早い順に取得する
+func (r *beegoRallyRepository) GetOpen(endsAfter time.Time, limit, offset int) ([]*entity.Rally, int, error) {
+	var rallyModels []*models.Rally
+
+	qs := r.orm.QueryTable("rally").
+		Filter("end_at__gt", endsAfter).
+		OrderBy("start_at", "id")
+
+	// 総数取得
+	total, err := qs.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// ページネーション
+	_, err = qs.Limit(limit, offset).All(&rallyModels)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entities, err := rallyModelsToEntities(r.orm, rallyModels)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entities, int(total), nil
+}
+
+// Create スタンプラリーを対象醸造所とともに作成する
+func (r *beegoRallyRepository) Create(rally *entity.Rally) (*entity.Rally, error) {
+	model := r.entityToModel(rally)
+
+	// トランザクションはリクエスト間で共有しない Ormer で実行する
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, err
+	}
+
+	if _, err := o.Insert(model); err != nil {
+		o.Rollback()
+		return nil, translateError(err, nil, nil)
+	}
+	if err := r.insertBreweries(o, model.Id, rally.BreweryIDs()); err != nil {
+		o.Rollback()
+		return nil, err
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(model.Id)
+}
+
+// Update スタンプラリーを更新し、対象醸造所を置き換える
+// 必要なスタンプ数の減少や対象醸造所の変更で条件を満たした参加者は、この時点で達成とする
+func (r *beegoRallyRepository) Update(rally *entity.Rally) (*entity.Rally, error) {
+	model := r.entityToModel(rally)
+
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, err
+	}
+
+	updated, err := o.Update(model, "Title", "Description", "StartAt", "EndAt", "RequiredCount", "RewardDescription", "UpdatedAt")
+	if err != nil {
+		o.Rollback()
+		return nil, translateError(err, domainerr.ErrRallyNotFound, nil)
+	}
+	if updated == 0 {
+		o.Rollback()
+		return nil, domainerr.ErrRallyNotFound
+	}
+
+	if _, err := o.QueryTable("rally_brewery").Filter("rally_id", model.Id).Delete(); err != nil {
+		o.Rollback()
+		return nil, err
+	}
+	if err := r.insertBreweries(o, model.Id, rally.BreweryIDs()); err != nil {
+		o.Rollback()
+		return nil, err
+	}
+
+	if _, err := completeRallyParticipants(o, model.Id, 0, time.Now()); err != nil {
+		o.Rollback()
+		return nil, err
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(model.Id)
+}
+
+// Delete スタンプラリーを削除する（対象醸造所・参加者・スタンプは外部キーの CASCADE で削除される）
+func (r *beegoRallyRepository) Delete(id int) error {
+	deleted, err := r.orm.QueryTable("rally").Filter("id", id).Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrRallyNotFound
+	}
+
+	return nil
+}
+
+// insertBreweries スタンプラリーの対象醸造所を登録する
+func (r *beegoRallyRepository) insertBreweries(o orm.Ormer, rallyID int, breweryIDs []int) error {
+	for _, breweryID := range breweryIDs {
+		rallyBrewery := &models.RallyBrewery{
+			Rally:   &models.Rally{Id: rallyID},
+			Brewery: &models.Brewery{Id: breweryID},
+		}
+		if _, err := o.Insert(rallyBrewery); err != nil {
+			return translateError(err, nil, nil)
+		}
+	}
+	return nil
+}
+
+// entityToModel エンティティからモデルに変換する
+func (r *beegoRallyRepository) entityToModel(e *entity.Rally) *models.Rally {
+	return &models.Rally{
+		Id:                e.ID(),
+		Title:             e.Title(),
+		Description:       e.Description(),
+		StartAt:           e.StartAt(),
+		EndAt:             e.EndAt(),
+		RequiredCount:     e.RequiredCount(),
+		RewardDescription: e.RewardDescription(),
+		CreatedAt:         e.CreatedAt(),
+		UpdatedAt:         e.UpdatedAt(),
+	}
+}
+
+// getRallyByID IDでスタンプラリーを対象醸造所・参加者数とともに取得する
+func getRallyByID(o orm.Ormer, id int) (*entity.Rally, error) {
+	model := &models.Rally{}
+	if err := o.QueryTable("rally").Filter("id", id).One(model); err != nil {
+		return nil, translateError(err, domainerr.ErrRallyNotFound, nil)
+	}
+
+	entities, err := rallyModelsToEntities(o, []*models.Rally{model})
+	if err != nil {
+		return nil, err
+	}
+	return entities[0], nil
+}
+
+// rallyModelsToEntities スタンプラリーのモデルを、対象醸造所と参加者数をまとめて読み込んでエンティティに変換する
+func rallyModelsToEntities(o orm.Ormer, rallyModels []*models.Rally) ([]*entity.Rally, error) {
+	entities := make([]*entity.Rally, len(rallyModels))
+	if len(rallyModels) == 0 {
+		return entities, nil
+	}
+
+	ids := make([]int, len(rallyModels))
+	for i, model := range rallyModels {
+		ids[i] = model.Id
+	}
+
+	// 対象醸造所は登録順に並べる
+	var breweryModels []*models.RallyBrewery
+	_, err := o.QueryTable("rally_brewery").
+		Filter("rally_id__in", ids).
+		RelatedSel("brewery").
+		OrderBy("id").
+		All(&breweryModels)
+	if err != nil {
+		return nil, err
+	}
+	breweries := make(map[int][]*entity.Brewery, len(ids))
+	for _, model := range breweryModels {
+		brewery, err := breweryModelToEntity(model.Brewery)
+		if err != nil {
+			return nil, err
+		}
+		breweries[model.Rally.Id] = append(breweries[model.Rally.Id], brewery)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	var counts []rallyParticipantCount
+	_, err = o.Raw(`SELECT rally_id, COUNT(*) AS participant_count
+			FROM rally_participant
+			WHERE rally_id IN (`+placeholders+`)
+			GROUP BY rally_id`, ids).QueryRows(&counts)
+	if err != nil {
+		return nil, err
+	}
+	participantCounts := make(map[int]int, len(counts))
+	for _, count := range counts {
+		participantCounts[count.RallyId] = count.ParticipantCount
+	}
+
+	for i, model := range rallyModels {
+		rally, err := entity.NewRallyBuilder().
+			WithID(model.Id).
+			WithTitle(model.Title).
+			WithDescription(model.Description).
+			WithBreweries(breweries[model.Id]).
+			WithPeriod(model.StartAt, model.EndAt).
+			WithRequiredCount(model.RequiredCount).
+			WithRewardDescription(model.RewardDescription).
+			WithParticipantCount(participantCounts[model.Id]).
+			WithCreatedAt(model.CreatedAt).
+			WithUpdatedAt(model.UpdatedAt).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = rally
+	}
+
+	return entities, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"mybeerlog/domain/domainerr"
 	"mybeerlog/domain/entity"
 	"mybeerlog/models"
 	"time"
@@ -10,7 +11,9 @@ import (
 
 // UserProfileRepository ユーザープロファイルのデータアクセスインターフェースを定義する
 type UserProfileRepository interface {
+	GetByID(id int) (*entity.UserProfile, error)
 	GetByCognitoSub(cognitoSub string) (*entity.UserProfile, error)
+	GetByHandle(handle string) (*entity.UserProfile, error)
 	Create(userProfile *entity.UserProfile) (*entity.UserProfile, error)
 	Update(userProfile *entity.UserProfile) (*entity.UserProfile, error)
 }
@@ -27,12 +30,34 @@ func NewUserProfileRepository() UserProfileRepository {
 	}
 }
 
+// GetByID IDでユーザープロファイルを取得する
+func (r *beegoUserProfileRepository) GetByID(id int) (*entity.UserProfile, error) {
+	model := &models.UserProfile{}
+	err := r.orm.QueryTable("user_profile").Filter("id", id).One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrUserProfileNotFound, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
 // GetByCognitoSub Cognito SUBでユーザープロファイルを取得する
 func (r *beegoUserProfileRepository) GetByCognitoSub(cognitoSub string) (*entity.UserProfile, error) {
 	model := &models.UserProfile{}
 	err := r.orm.QueryTable("user_profile").Filter("cognito_sub", cognitoSub).One(model)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err, domainerr.ErrUserProfileNotFound, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// GetByHandle 公開プロフィールのハンドルでユーザープロファイルを取得する
+func (r *beegoUserProfileRepository) GetByHandle(handle string) (*entity.UserProfile, error) {
+	model := &models.UserProfile{}
+	err := r.orm.QueryTable("user_profile").Filter("handle", handle).One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrUserProfileNotFound, nil)
 	}
 
 	return r.modelToEntity(model)
@@ -46,18 +71,11 @@ func (r *beegoUserProfileRepository) Create(userProfile *entity.UserProfile) (*e
 
 	_, err := r.orm.Insert(model)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err, nil, domainerr.ErrUserProfileAlreadyExists)
 	}
 
 	// 新しく作成されたエンティティを返す
-	return entity.NewUserProfileBuilder().
-		WithID(model.Id).
-		WithCognitoSub(model.CognitoSub).
-		WithDisplayName(model.DisplayName).
-		WithIconURL(model.IconURL).
-		WithCreatedAt(model.CreatedAt).
-		WithUpdatedAt(model.UpdatedAt).
-		Build()
+	return r.modelToEntity(model)
 }
 
 // Update ユーザープロファイルを更新する
@@ -67,28 +85,39 @@ func (r *beegoUserProfileRepository) Update(userProfile *entity.UserProfile) (*e
 
 	_, err := r.orm.Update(model)
 	if err != nil {
-		return nil, err
+		// 更新で一意制約に違反するのはハンドルのみ
+		return nil, translateError(err, domainerr.ErrUserProfileNotFound, domainerr.ErrHandleAlreadyTaken)
 	}
 
 	// 更新されたエンティティを返す
-	return entity.NewUserProfileBuilder().
-		WithID(model.Id).
-		WithCognitoSub(model.CognitoSub).
-		WithDisplayName(model.DisplayName).
-		WithIconURL(model.IconURL).
-		WithCreatedAt(model.CreatedAt).
-		WithUpdatedAt(model.UpdatedAt).
-		Build()
+	return r.modelToEntity(model)
 }
 
 
 // modelToEntity モデルからエンティティに変換する
 func (r *beegoUserProfileRepository) modelToEntity(model *models.UserProfile) (*entity.UserProfile, error) {
+	return userProfileModelToEntity(model)
+}
+
+// userProfileModelToEntity ユーザープロファイルモデルからエンティティに変換する（他リポジトリの関連読み込みでも使用する）
+func userProfileModelToEntity(model *models.UserProfile) (*entity.UserProfile, error) {
+	privacy, err := entity.NewProfilePrivacy(model.ProfilePublic, model.HideVisitTimes, model.VisitPublishDelayHours)
+	if err != nil {
+		return nil, err
+	}
+
+	var handle string
+	if model.Handle != nil {
+		handle = *model.Handle
+	}
+
 	return entity.NewUserProfileBuilder().
 		WithID(model.Id).
 		WithCognitoSub(model.CognitoSub).
 		WithDisplayName(model.DisplayName).
 		WithIconURL(model.IconURL).
+		WithHandle(handle).
+		WithPrivacy(privacy).
 		WithCreatedAt(model.CreatedAt).
 		WithUpdatedAt(model.UpdatedAt).
 		Build()
@@ -96,12 +125,20 @@ func (r *beegoUserProfileRepository) modelToEntity(model *models.UserProfile) (*
 
 // entityToModel エンティティからモデルに変換する
 func (r *beegoUserProfileRepository) entityToModel(e *entity.UserProfile) *models.UserProfile {
-	return &models.UserProfile{
-		Id:          e.ID(),
-		CognitoSub:  e.CognitoSub(),
-		DisplayName: e.DisplayName(),
-		IconURL:     e.IconURL(),
-		CreatedAt:   e.CreatedAt(),
-		UpdatedAt:   e.UpdatedAt(),
+	model := &models.UserProfile{
+		Id:                     e.ID(),
+		CognitoSub:             e.CognitoSub(),
+		DisplayName:            e.DisplayName(),
+		IconURL:                e.IconURL(),
+		ProfilePublic:          e.Privacy().IsPublic(),
+		HideVisitTimes:         e.Privacy().HideVisitTimes(),
+		VisitPublishDelayHours: e.Privacy().VisitPublishDelayHours(),
+		CreatedAt:              e.CreatedAt(),
+		UpdatedAt:              e.UpdatedAt(),
+	}
+	// ハンドルが未設定の場合は一意制約の対象外とするため NULL で保存する
+	if handle := e.Handle(); handle != "" {
+		model.Handle = &handle
 	}
+	return model
 }
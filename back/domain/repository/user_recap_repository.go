@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// UserRecapRepository 年間の振り返りのデータアクセスインターフェースを定義する
+type UserRecapRepository interface {
+	GetByUserProfileAndYear(userProfileID, year int) (*entity.UserRecap, error)
+	Compute(userProfileID, year int, timeZone string, from, to time.Time) (*entity.UserRecap, error)
+	Save(recap *entity.UserRecap) error
+	ListUserProfileIDsWithVisits(from, to time.Time, afterID, limit int) ([]int, error)
+}
+
+// beegoUserRecapRepository Beego ORMを使用してUserRecapRepositoryを実装する
+type beegoUserRecapRepository struct {
+	orm orm.Ormer
+}
+
+// NewUserRecapRepository 新しいUserRecapRepositoryインスタンスを作成する
+func NewUserRecapRepository() UserRecapRepository {
+	return &beegoUserRecapRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// GetByUserProfileAndYear 保存済みの振り返りを取得する（未集計の場合は domainerr.ErrNotFound）
+func (r *beegoUserRecapRepository) GetByUserProfileAndYear(userProfileID, year int) (*entity.UserRecap, error) {
+	model := &models.UserRecap{}
+	err := r.orm.QueryTable("user_recap").
+		Filter("user_profile_id", userProfileID).
+		Filter("year", year).
+		RelatedSel("favorite_brewery", "farthest_from_brewery", "farthest_to_brewery").
+		One(model)
+	if err != nil {
+		return nil, translateError(err, nil, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// Compute from 以降・to より前の訪問から振り返りを集計する（月・週は timeZone で集計する）
+// 醸造所間の最長の移動は含めない（VisitStatsRepository.GetTransitions の結果から求める）
+func (r *beegoUserRecapRepository) Compute(userProfileID, year int, timeZone string, from, to time.Time) (*entity.UserRecap, error) {
+	fromParam, toParam := formatDBTimestamp(from), formatDBTimestamp(to)
+
+	var summary struct {
+		TotalVisits        int
+		BreweriesVisited   int
+		PrefecturesVisited int
+		NewBreweries       int
+	}
+	// その年より前に訪問したことのない醸造所を「初めて訪問した醸造所」として数える
+	summarySQL := `SELECT
+				COUNT(*) AS total_visits,
+				COUNT(DISTINCT v.brewery_id) AS breweries_visited,
+				COUNT(DISTINCT NULLIF(b.prefecture, '')) AS prefectures_visited,
+				COUNT(DISTINCT v.brewery_id) FILTER (WHERE NOT EXISTS (
+					SELECT 1 FROM visit earlier
+					WHERE earlier.user_profile_id = v.user_profile_id AND earlier.brewery_id = v.brewery_id AND earlier.visited_at < ?
+				)) AS new_breweries
+			FROM visit v
+			JOIN brewery b ON b.id = v.brewery_id
+			WHERE v.user_profile_id = ? AND v.visited_at >= ? AND v.visited_at < ?`
+	if err := r.orm.Raw(summarySQL, fromParam, userProfileID, fromParam, toParam).QueryRow(&summary); err != nil {
+		return nil, err
+	}
+
+	recap := &entity.UserRecap{
+		UserProfileID:      userProfileID,
+		Year:               year,
+		TotalVisits:        summary.TotalVisits,
+		BreweriesVisited:   summary.BreweriesVisited,
+		NewBreweries:       summary.NewBreweries,
+		PrefecturesVisited: summary.PrefecturesVisited,
+	}
+
+	// 獲得したバッジ（訪問がない年でも、その年に獲得したバッジは数える）
+	badgeSQL := `SELECT COUNT(*) FROM user_badge WHERE user_profile_id = ? AND awarded_at >= ? AND awarded_at < ?`
+	if err := r.orm.Raw(badgeSQL, userProfileID, fromParam, toParam).QueryRow(&recap.BadgesEarned); err != nil {
+		return nil, err
+	}
+
+	if recap.TotalVisits == 0 {
+		return recap, nil
+	}
+
+	localArgs := []interface{}{dbTimeZoneOffsetSeconds(), timeZone, userProfileID, fromParam, toParam}
+
+	// 最も訪問の多かった月（同数の場合は早い月）
+	var busiest struct {
+		Month int
+		Count int
+	}
+	busiestSQL := localVisitsInRangeSQL + `
+			SELECT EXTRACT(MONTH FROM local_at)::int AS month, COUNT(*) AS count
+			FROM v
+			GROUP BY 1
+			ORDER BY count DESC, month
+			LIMIT 1`
+	if err := r.orm.Raw(busiestSQL, localArgs...).QueryRow(&busiest); err != nil {
+		return nil, err
+	}
+	recap.BusiestMonth = &entity.MonthlyVisitCount{Year: year, Month: time.Month(busiest.Month), Count: busiest.Count}
+
+	// 訪問のある週が連続した最長の週数
+	if err := r.orm.Raw(localVisitsInRangeSQL+longestWeekStreakSQL, localArgs...).QueryRow(&recap.LongestWeekStreak); err != nil {
+		return nil, err
+	}
+
+	// 最も訪問した醸造所（同数の場合は最後の訪問が新しい醸造所）
+	var favorite struct {
+		BreweryId  int
+		VisitCount int
+	}
+	favoriteSQL := `SELECT brewery_id, COUNT(*) AS visit_count
+			FROM visit
+			WHERE user_profile_id = ? AND visited_at >= ? AND visited_at < ?
+			GROUP BY brewery_id
+			ORDER BY visit_count DESC, MAX(visited_at) DESC, brewery_id
+			LIMIT 1`
+	if err := r.orm.Raw(favoriteSQL, userProfileID, fromParam, toParam).QueryRow(&favorite); err != nil {
+		return nil, err
+	}
+	breweries, err := getBreweriesByIDs(r.orm, []int{favorite.BreweryId})
+	if err != nil {
+		return nil, err
+	}
+	if brewery, ok := breweries[favorite.BreweryId]; ok {
+		recap.FavoriteBrewery = brewery
+		recap.FavoriteBreweryVisits = favorite.VisitCount
+	}
+
+	return recap, nil
+}
+
+// Save 振り返りを保存する（同じユーザー・年の振り返りがある場合は置き換える）
+func (r *beegoUserRecapRepository) Save(recap *entity.UserRecap) error {
+	var busiestMonth, busiestMonthVisits int
+	if recap.BusiestMonth != nil {
+		busiestMonth, busiestMonthVisits = int(recap.BusiestMonth.Month), recap.BusiestMonth.Count
+	}
+	// 醸造所の外部キーは、該当がない場合に NULL とするため interface{} の nil で渡す
+	var favoriteBreweryParam, farthestFromParam, farthestToParam interface{}
+	var farthestDistanceM float64
+	if recap.FavoriteBrewery != nil {
+		favoriteBreweryParam = recap.FavoriteBrewery.ID()
+	}
+	if recap.FarthestTrip != nil {
+		farthestFromParam = recap.FarthestTrip.From.ID()
+		farthestToParam = recap.FarthestTrip.To.ID()
+		farthestDistanceM = recap.FarthestTrip.DistanceM
+	}
+
+	sql := `INSERT INTO user_recap (
+				user_profile_id, year, total_visits, breweries_visited, new_breweries, prefectures_visited,
+				busiest_month, busiest_month_visits, favorite_brewery_id, favorite_brewery_visits,
+				farthest_from_brewery_id, farthest_to_brewery_id, farthest_distance_m,
+				longest_week_streak, badges_earned, generated_at
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (user_profile_id, year) DO UPDATE SET
+				total_visits = EXCLUDED.total_visits,
+				breweries_visited = EXCLUDED.breweries_visited,
+				new_breweries = EXCLUDED.new_breweries,
+				prefectures_visited = EXCLUDED.prefectures_visited,
+				busiest_month = EXCLUDED.busiest_month,
+				busiest_month_visits = EXCLUDED.busiest_month_visits,
+				favorite_brewery_id = EXCLUDED.favorite_brewery_id,
+				favorite_brewery_visits = EXCLUDED.favorite_brewery_visits,
+				farthest_from_brewery_id = EXCLUDED.farthest_from_brewery_id,
+				farthest_to_brewery_id = EXCLUDED.farthest_to_brewery_id,
+				farthest_distance_m = EXCLUDED.farthest_distance_m,
+				longest_week_streak = EXCLUDED.longest_week_streak,
+				badges_earned = EXCLUDED.badges_earned,
+				generated_at = EXCLUDED.generated_at`
+	_, err := r.orm.Raw(sql,
+		recap.UserProfileID, recap.Year, recap.TotalVisits, recap.BreweriesVisited, recap.NewBreweries, recap.PrefecturesVisited,
+		busiestMonth, busiestMonthVisits, favoriteBreweryParam, recap.FavoriteBreweryVisits,
+		farthestFromParam, farthestToParam, farthestDistanceM,
+		recap.LongestWeekStreak, recap.BadgesEarned, formatDBTimestamp(recap.GeneratedAt),
+	).Exec()
+	if err != nil {
+		return translateError(err, nil, nil)
+	}
+	return nil
+}
+
+// ListUserProfileIDsWithVisits from 以降・to より前に訪問のあるユーザーのうち、IDが afterID より大きいユーザーのIDを小さい順に最大 limit 件取得する
+// バッチでの集計対象をIDの順に少しずつ取得するために使用する
+func (r *beegoUserRecapRepository) ListUserProfileIDsWithVisits(from, to time.Time, afterID, limit int) ([]int, error) {
+	var ids []int
+	sql := `SELECT DISTINCT user_profile_id FROM visit
+			WHERE visited_at >= ? AND visited_at < ? AND user_profile_id > ?
+			ORDER BY user_profile_id
+			LIMIT ?`
+	if _, err := r.orm.Raw(sql, formatDBTimestamp(from), formatDBTimestamp(to), afterID, limit).QueryRows(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoUserRecapRepository) modelToEntity(model *models.UserRecap) (*entity.UserRecap, error) {
+	recap := &entity.UserRecap{
+		UserProfileID:      model.UserProfile.Id,
+		Year:               model.Year,
+		TotalVisits:        model.TotalVisits,
+		BreweriesVisited:   model.BreweriesVisited,
+		NewBreweries:       model.NewBreweries,
+		PrefecturesVisited: model.PrefecturesVisited,
+		LongestWeekStreak:  model.LongestWeekStreak,
+		BadgesEarned:       model.BadgesEarned,
+		GeneratedAt:        model.GeneratedAt,
+	}
+
+	if model.BusiestMonth >= 1 && model.BusiestMonth <= 12 {
+		recap.BusiestMonth = &entity.MonthlyVisitCount{
+			Year:  model.Year,
+			Month: time.Month(model.BusiestMonth),
+			Count: model.BusiestMonthVisits,
+		}
+	}
+
+	// 醸造所が削除された場合は外部キーが NULL になる（関連の読み込み結果は ID が0）
+	if model.FavoriteBrewery != nil && model.FavoriteBrewery.Id > 0 {
+		brewery, err := breweryModelToEntity(model.FavoriteBrewery)
+		if err != nil {
+			return nil, err
+		}
+		recap.FavoriteBrewery = brewery
+		recap.FavoriteBreweryVisits = model.FavoriteBreweryVisits
+	}
+
+	if model.FarthestFromBrewery != nil && model.FarthestFromBrewery.Id > 0 &&
+		model.FarthestToBrewery != nil && model.FarthestToBrewery.Id > 0 {
+		from, err := breweryModelToEntity(model.FarthestFromBrewery)
+		if err != nil {
+			return nil, err
+		}
+		to, err := breweryModelToEntity(model.FarthestToBrewery)
+		if err != nil {
+			return nil, err
+		}
+		recap.FarthestTrip = &entity.BreweryTrip{From: from, To: to, DistanceM: model.FarthestDistanceM}
+	}
+
+	return recap, nil
+}
@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"mybeerlog/domain/domainerr"
 	"mybeerlog/domain/entity"
 	"mybeerlog/models"
+	"strconv"
 	"time"
 
 	"github.com/astaxie/beego/orm"
@@ -17,10 +19,17 @@ type visitRepository struct {
 type VisitRepository interface {
 	GetByID(id int) (*entity.Visit, error)
 	GetByUserProfile(userProfileID int, limit, offset int) ([]*entity.Visit, int, error)
-	GetByUserProfileAndBrewery(userProfileID, breweryID int, limit, offset int) ([]*entity.Visit, int, error)
+	ListByUserProfile(userProfileID int, filter VisitFilter, page PageQuery) ([]*entity.Visit, *PageResult, error)
+	CountByBrewery(breweryID int) (int, error)
+	CountByUserProfileAndBrewery(userProfileID, breweryID int) (int, error)
 	Create(visit *entity.Visit) (*entity.Visit, error)
+	CreateWithCooldown(visit *entity.Visit, cooldown time.Duration, checkPrevious PreviousVisitCheck) (*entity.Visit, error)
 }
 
+// PreviousVisitCheck ユーザーの直前の訪問をもとに、新しい訪問を作成してよいかを検証する
+// エラーを返した場合は訪問を作成しない
+type PreviousVisitCheck func(previous *entity.Visit) error
+
 // NewVisitRepository 新しいVisitRepositoryインスタンスを作成する
 func NewVisitRepository() VisitRepository {
 	return &visitRepository{
@@ -33,74 +42,130 @@ func (r *visitRepository) GetByID(id int) (*entity.Visit, error) {
 	model := &models.Visit{}
 	err := r.orm.QueryTable("visit").Filter("id", id).RelatedSel("brewery").One(model)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err, domainerr.ErrVisitNotFound, nil)
 	}
 
 	return r.modelToEntity(model)
 }
 
-// GetByUserProfile ユーザープロファイルで訪問を取得する
+// VisitFilter 訪問履歴の絞り込み・並び順の条件（ゼロ値の項目は絞り込まない）
+type VisitFilter struct {
+	BreweryID        int
+	From             *time.Time // この日時以降の訪問
+	To               *time.Time // この日時より前の訪問（To の時刻ちょうどの訪問は含まない）
+	BreweryName      string     // 醸造所名の部分一致（大文字・小文字を区別しない）
+	Prefecture       string
+	Ascending        bool // 古い順に並べるかどうか（デフォルトは新しい順）
+	LatestPerBrewery bool // 醸造所ごとに条件に一致する最新の訪問のみを返すかどうか
+}
+
+// GetByUserProfile ユーザープロファイルで訪問を新しい順に取得する
 func (r *visitRepository) GetByUserProfile(userProfileID int, limit, offset int) ([]*entity.Visit, int, error) {
+	visits, result, err := r.ListByUserProfile(userProfileID, VisitFilter{}, PageQuery{Limit: limit, Offset: offset, IncludeTotal: true})
+	if err != nil {
+		return nil, 0, err
+	}
+	return visits, *result.Total, nil
+}
+
+// ListByUserProfile ユーザープロファイルの訪問を (visited_at, id) の順（デフォルトは新しい順）に取得する
+func (r *visitRepository) ListByUserProfile(userProfileID int, filter VisitFilter, page PageQuery) ([]*entity.Visit, *PageResult, error) {
 	var models []*models.Visit
 
-	qs := r.orm.QueryTable("visit").Filter("user_profile_id", userProfileID).RelatedSel("brewery").OrderBy("-visited_at")
+	qs := r.applyFilter(r.orm.QueryTable("visit").Filter("user_profile_id", userProfileID), userProfileID, filter)
 
-	// 総数取得
-	total, err := qs.Count()
-	if err != nil {
-		return nil, 0, err
+	// 総数取得（ページの位置に関係なく条件に一致する件数）
+	var total *int
+	if page.IncludeTotal {
+		count, err := qs.Count()
+		if err != nil {
+			return nil, nil, err
+		}
+		value := int(count)
+		total = &value
 	}
 
 	// ページネーション
-	_, err = qs.Limit(limit, offset).All(&models)
-	if err != nil {
-		return nil, 0, err
+	pageQS, reversed := applyPage(qs.RelatedSel("brewery"), "visited_at", page, !filter.Ascending)
+	if _, err := pageQS.All(&models); err != nil {
+		return nil, nil, err
 	}
+	result := pageResult(page, len(models))
+	result.Total = total
+	models = trimPage(models, page.Limit, reversed)
 
 	entities := make([]*entity.Visit, len(models))
 	for i, model := range models {
 		entity, err := r.modelToEntity(model)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, err
 		}
 		entities[i] = entity
 	}
 
-	return entities, int(total), nil
+	return entities, result, nil
 }
 
-// GetByUserProfileAndBrewery ユーザープロファイルと醸造所で訪問を取得する
-func (r *visitRepository) GetByUserProfileAndBrewery(userProfileID, breweryID int, limit, offset int) ([]*entity.Visit, int, error) {
-	var models []*models.Visit
+// applyFilter 訪問履歴の検索条件に絞り込み条件を適用する
+func (r *visitRepository) applyFilter(qs orm.QuerySeter, userProfileID int, filter VisitFilter) orm.QuerySeter {
+	cond := qs.GetCond()
+	if cond == nil {
+		cond = orm.NewCondition()
+	}
 
-	qs := r.orm.QueryTable("visit").
-		Filter("user_profile_id", userProfileID).
-		Filter("brewery_id", breweryID).
-		RelatedSel("brewery").
-		OrderBy("-visited_at")
+	if filter.BreweryID > 0 {
+		cond = cond.And("brewery_id", filter.BreweryID)
+	}
+	if filter.From != nil {
+		cond = cond.AndCond(timeCondition("visited_at", ">=", *filter.From))
+	}
+	if filter.To != nil {
+		cond = cond.AndCond(timeCondition("visited_at", "<", *filter.To))
+	}
+	if filter.BreweryName != "" {
+		cond = cond.And("brewery__name__icontains", filter.BreweryName)
+	}
+	if filter.Prefecture != "" {
+		cond = cond.And("brewery__prefecture", filter.Prefecture)
+	}
+	qs = qs.SetCond(cond)
 
-	// 総数取得
-	total, err := qs.Count()
-	if err != nil {
-		return nil, 0, err
+	if filter.LatestPerBrewery {
+		// 期間内の訪問から醸造所ごとに最新の1件を選ぶ（醸造所名・都道府県は醸造所単位の条件のため外側のクエリで絞り込む）
+		// 埋め込む値は整数と formatDBTimestamp が生成する日時のみのため、SQLに埋め込んでも安全
+		latest := "SELECT DISTINCT ON (brewery_id) id FROM visit WHERE user_profile_id = " + strconv.Itoa(userProfileID)
+		if filter.From != nil {
+			latest += " AND visited_at >= '" + formatDBTimestamp(*filter.From) + "'"
+		}
+		if filter.To != nil {
+			latest += " AND visited_at < '" + formatDBTimestamp(*filter.To) + "'"
+		}
+		latest += " ORDER BY brewery_id, visited_at DESC, id DESC"
+		qs = qs.FilterRaw("id", "IN ("+latest+")")
 	}
 
-	// ページネーション
-	_, err = qs.Limit(limit, offset).All(&models)
+	return qs
+}
+
+// CountByBrewery 醸造所への訪問数（全ユーザー）を取得する
+func (r *visitRepository) CountByBrewery(breweryID int) (int, error) {
+	count, err := r.orm.QueryTable("visit").Filter("brewery_id", breweryID).Count()
 	if err != nil {
-		return nil, 0, err
+		return 0, err
 	}
+	return int(count), nil
+}
 
-	entities := make([]*entity.Visit, len(models))
-	for i, model := range models {
-		entity, err := r.modelToEntity(model)
-		if err != nil {
-			return nil, 0, err
-		}
-		entities[i] = entity
+// CountByUserProfileAndBrewery ユーザーの醸造所への訪問回数を取得する
+func (r *visitRepository) CountByUserProfileAndBrewery(userProfileID, breweryID int) (int, error) {
+	count, err := r.orm.QueryTable("visit").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery_id", breweryID).
+		Count()
+	if err != nil {
+		return 0, err
 	}
-
-	return entities, int(total), nil
+	return int(count), nil
 }
 
 // Create 訪問を作成する
@@ -113,10 +178,88 @@ func (r *visitRepository) Create(visit *entity.Visit) (*entity.Visit, error) {
 		return nil, err
 	}
 
-	// 作成されたエンティティを返す
-	return r.modelToEntity(model)
+	// 関連する醸造所情報を含めて返す
+	return r.GetByID(model.Id)
 }
 
+// CreateWithCooldown 同一ユーザー・同一醸造所の直近 cooldown 以内の訪問がなく、checkPrevious を満たす場合のみ訪問を作成する
+// checkPrevious はユーザーの直前の訪問がある場合に呼ばれる（nil の場合は検証しない）
+// 判定と作成はトランザクション内でユーザー単位のアドバイザリロックを取得して行うため、
+// 同時に送信されたチェックインのうち、直前の訪問を確認せずに作成されるものはない
+func (r *visitRepository) CreateWithCooldown(visit *entity.Visit, cooldown time.Duration, checkPrevious PreviousVisitCheck) (*entity.Visit, error) {
+	if cooldown <= 0 && checkPrevious == nil {
+		return r.Create(visit)
+	}
+
+	// トランザクションはリクエスト間で共有しない Ormer で実行する
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, err
+	}
+
+	model, err := r.insertWithCooldown(o, visit, cooldown, checkPrevious)
+	if err != nil {
+		o.Rollback()
+		return nil, err
+	}
+	if err := o.Commit(); err != nil {
+		return nil, err
+	}
+
+	// 関連する醸造所情報を含めて返す
+	return r.GetByID(model.Id)
+}
+
+// insertWithCooldown トランザクション内でロックを取得し、重複と直前の訪問を確認してから訪問を挿入する
+func (r *visitRepository) insertWithCooldown(o orm.Ormer, visit *entity.Visit, cooldown time.Duration, checkPrevious PreviousVisitCheck) (*models.Visit, error) {
+	// 直前の訪問は醸造所をまたいで判定するため、ロックはユーザー単位で取得する（トランザクション終了時に自動で解放される）
+	if _, err := o.Raw("SELECT pg_advisory_xact_lock(?)", visit.UserProfileID()).Exec(); err != nil {
+		return nil, err
+	}
+
+	if cooldown > 0 {
+		var recentCount int64
+		err := o.Raw(`SELECT COUNT(*) FROM visit
+				WHERE user_profile_id = ? AND brewery_id = ? AND visited_at > ?`,
+			visit.UserProfileID(), visit.BreweryID(),
+			formatDBTimestamp(time.Now().Add(-cooldown))).QueryRow(&recentCount)
+		if err != nil {
+			return nil, err
+		}
+		if recentCount > 0 {
+			return nil, domainerr.ErrDuplicateCheckin
+		}
+	}
+
+	if checkPrevious != nil {
+		var previous models.Visit
+		err := o.QueryTable("visit").
+			Filter("user_profile_id", visit.UserProfileID()).
+			RelatedSel("brewery").
+			OrderBy("-visited_at", "-id").
+			Limit(1).
+			One(&previous)
+		if err != nil && err != orm.ErrNoRows {
+			return nil, err
+		}
+		if err == nil {
+			previousVisit, err := r.modelToEntity(&previous)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkPrevious(previousVisit); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	model := r.entityToModel(visit)
+	model.VisitedAt = time.Now()
+	if _, err := o.Insert(model); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
 
 // modelToEntity モデルからエンティティに変換する
 func (r *visitRepository) modelToEntity(model *models.Visit) (*entity.Visit, error) {
@@ -126,16 +269,29 @@ func (r *visitRepository) modelToEntity(model *models.Visit) (*entity.Visit, err
 		WithBreweryID(model.Brewery.Id).
 		WithVisitedAt(model.VisitedAt)
 
-	// 関連するユーザープロファイル情報がある場合
-	if model.UserProfile != nil {
-		userProfile, err := entity.NewUserProfileBuilder().
-			WithID(model.UserProfile.Id).
-			WithCognitoSub(model.UserProfile.CognitoSub).
-			WithDisplayName(model.UserProfile.DisplayName).
-			WithIconURL(model.UserProfile.IconURL).
-			WithCreatedAt(model.UserProfile.CreatedAt).
-			WithUpdatedAt(model.UserProfile.UpdatedAt).
-			Build()
+	if model.CheckinMethod != "" {
+		builder = builder.WithCheckinMethod(model.CheckinMethod)
+	}
+
+	// チェックイン時のGPS証跡がある場合
+	if model.CheckinLatitude != nil && model.CheckinLongitude != nil {
+		evidence, err := entity.NewCheckinEvidence(
+			*model.CheckinLatitude,
+			*model.CheckinLongitude,
+			model.GpsAccuracyM,
+			float64Value(model.DistanceM),
+			float64Value(model.CheckinRadiusM),
+			model.ClientVersion,
+		)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.WithCheckinEvidence(evidence)
+	}
+
+	// 関連するユーザープロファイル情報がある場合（RelatedSel で読み込まれていない場合はIDのみ）
+	if model.UserProfile != nil && model.UserProfile.CognitoSub != "" {
+		userProfile, err := userProfileModelToEntity(model.UserProfile)
 		if err != nil {
 			return nil, err
 		}
@@ -143,16 +299,8 @@ func (r *visitRepository) modelToEntity(model *models.Visit) (*entity.Visit, err
 	}
 
 	// 関連する醸造所情報がある場合
-	if model.Brewery != nil {
-		brewery, err := entity.NewBreweryBuilder().
-			WithID(model.Brewery.Id).
-			WithName(model.Brewery.Name).
-			WithAddress(model.Brewery.Address).
-			WithDescription(model.Brewery.Description).
-			WithLocation(model.Brewery.Latitude, model.Brewery.Longitude).
-			WithCreatedAt(model.Brewery.CreatedAt).
-			WithUpdatedAt(model.Brewery.UpdatedAt).
-			Build()
+	if model.Brewery != nil && model.Brewery.Name != "" {
+		brewery, err := breweryModelToEntity(model.Brewery)
 		if err != nil {
 			return nil, err
 		}
@@ -165,8 +313,22 @@ func (r *visitRepository) modelToEntity(model *models.Visit) (*entity.Visit, err
 // entityToModel エンティティからモデルに変換する
 func (r *visitRepository) entityToModel(e *entity.Visit) *models.Visit {
 	visit := &models.Visit{
-		Id:        e.ID(),
-		VisitedAt: e.VisitedAt(),
+		Id:            e.ID(),
+		UserProfile:   &models.UserProfile{Id: e.UserProfileID()},
+		Brewery:       &models.Brewery{Id: e.BreweryID()},
+		CheckinMethod: e.CheckinMethod(),
+		VisitedAt:     e.VisitedAt(),
+	}
+
+	if evidence := e.CheckinEvidence(); evidence != nil {
+		latitude, longitude := evidence.Latitude(), evidence.Longitude()
+		distanceM, radiusM := evidence.DistanceM(), evidence.RadiusM()
+		visit.CheckinLatitude = &latitude
+		visit.CheckinLongitude = &longitude
+		visit.GpsAccuracyM = evidence.AccuracyM()
+		visit.DistanceM = &distanceM
+		visit.CheckinRadiusM = &radiusM
+		visit.ClientVersion = evidence.ClientVersion()
 	}
 
 	if e.UserProfile() != nil {
@@ -184,9 +346,12 @@ func (r *visitRepository) entityToModel(e *entity.Visit) *models.Visit {
 			Id:          e.Brewery().ID(),
 			Name:        e.Brewery().Name(),
 			Address:     e.Brewery().Address(),
+			Prefecture:  e.Brewery().Prefecture(),
 			Description: e.Brewery().Description(),
 			Latitude:    e.Brewery().Latitude(),
 			Longitude:   e.Brewery().Longitude(),
+			Status:      e.Brewery().Status(),
+			DeletedAt:   e.Brewery().DeletedAt(),
 			CreatedAt:   e.Brewery().CreatedAt(),
 			UpdatedAt:   e.Brewery().UpdatedAt(),
 		}
@@ -194,3 +359,11 @@ func (r *visitRepository) entityToModel(e *entity.Visit) *models.Visit {
 
 	return visit
 }
+
+// float64Value NULL許容の数値をゼロ値を既定として取り出す
+func float64Value(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// VisitStatsRepository 訪問履歴の集計のデータアクセスインターフェースを定義する
+type VisitStatsRepository interface {
+	GetStats(userProfileID int, timeZone string, topBreweries int) (*entity.VisitStats, error)
+	GetTransitions(userProfileID int, from, to *time.Time) ([]*entity.BreweryTransition, error)
+}
+
+// beegoVisitStatsRepository Beego ORMを使用してVisitStatsRepositoryを実装する
+type beegoVisitStatsRepository struct {
+	orm orm.Ormer
+}
+
+// NewVisitStatsRepository 新しいVisitStatsRepositoryインスタンスを作成する
+func NewVisitStatsRepository() VisitStatsRepository {
+	return &beegoVisitStatsRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// localVisitsSQL ユーザーの訪問を timeZone の日時（local_at）に変換する共通テーブル式
+// visited_at は orm.DefaultTimeLoc の日時として保存されているため、UTC に戻してから timeZone に変換する
+// パラメータ: DefaultTimeLoc の UTC からの時差（秒）、タイムゾーン名、ユーザープロファイルID
+const localVisitsSQL = `WITH v AS (
+				SELECT id, brewery_id, visited_at,
+					((visited_at - make_interval(secs => ?)) AT TIME ZONE 'UTC') AT TIME ZONE ? AS local_at
+				FROM visit
+				WHERE user_profile_id = ?
+			)`
+
+// localVisitsInRangeSQL localVisitsSQL の対象を期間内の訪問に限定した共通テーブル式
+// パラメータ: localVisitsSQL のパラメータに続けて、期間の開始日時・終了日時（終了日時ちょうどの訪問は含まない）
+const localVisitsInRangeSQL = `WITH v AS (
+				SELECT id, brewery_id, visited_at,
+					((visited_at - make_interval(secs => ?)) AT TIME ZONE 'UTC') AT TIME ZONE ? AS local_at
+				FROM visit
+				WHERE user_profile_id = ? AND visited_at >= ? AND visited_at < ?
+			)`
+
+// longestWeekStreakSQL 共通テーブル式 v の訪問で、訪問のある週（月曜始まり）が連続した最長の週数を求める
+// 連続する週は「週の開始日 - 順位 × 7日」が同じ値になるため、その値でグループ化して数える
+const longestWeekStreakSQL = `
+			SELECT COALESCE(MAX(weeks), 0) FROM (
+				SELECT COUNT(*) AS weeks FROM (
+					SELECT week - (ROW_NUMBER() OVER (ORDER BY week)) * INTERVAL '7 days' AS streak
+					FROM (SELECT DISTINCT date_trunc('week', local_at) AS week FROM v) visited_weeks
+				) numbered
+				GROUP BY streak
+			) streaks`
+
+// GetStats ユーザーの訪問履歴を集計する（月・曜日・時間帯は timeZone で集計し、最も訪問した醸造所は topBreweries 件まで）
+// 醸造所間の移動距離は含めない（GetTransitions の結果から計算する）
+func (r *beegoVisitStatsRepository) GetStats(userProfileID int, timeZone string, topBreweries int) (*entity.VisitStats, error) {
+	var summary struct {
+		TotalVisits       int
+		DistinctBreweries int
+		FirstVisitAt      time.Time
+		LastVisitAt       time.Time
+	}
+	summarySQL := `SELECT
+				COUNT(*) AS total_visits,
+				COUNT(DISTINCT brewery_id) AS distinct_breweries,
+				MIN(visited_at) AS first_visit_at,
+				MAX(visited_at) AS last_visit_at
+			FROM visit
+			WHERE user_profile_id = ?`
+	if err := r.orm.Raw(summarySQL, userProfileID).QueryRow(&summary); err != nil {
+		return nil, err
+	}
+
+	stats := &entity.VisitStats{
+		TotalVisits:          summary.TotalVisits,
+		DistinctBreweries:    summary.DistinctBreweries,
+		VisitsByMonth:        []entity.MonthlyVisitCount{},
+		MostVisitedBreweries: []*entity.BreweryVisitCount{},
+	}
+	if stats.TotalVisits == 0 {
+		return stats, nil
+	}
+	stats.FirstVisitAt = &summary.FirstVisitAt
+	stats.LastVisitAt = &summary.LastVisitAt
+
+	localArgs := []interface{}{dbTimeZoneOffsetSeconds(), timeZone, userProfileID}
+
+	// 月ごとの訪問数
+	var months []struct {
+		Year  int
+		Month int
+		Count int
+	}
+	monthSQL := localVisitsSQL + `
+			SELECT EXTRACT(YEAR FROM local_at)::int AS year, EXTRACT(MONTH FROM local_at)::int AS month, COUNT(*) AS count
+			FROM v
+			GROUP BY 1, 2
+			ORDER BY 1, 2`
+	if _, err := r.orm.Raw(monthSQL, localArgs...).QueryRows(&months); err != nil {
+		return nil, err
+	}
+	for _, m := range months {
+		stats.VisitsByMonth = append(stats.VisitsByMonth, entity.MonthlyVisitCount{
+			Year:  m.Year,
+			Month: time.Month(m.Month),
+			Count: m.Count,
+		})
+	}
+
+	// 曜日（日曜日=0）・時ごとの訪問数
+	var buckets []struct {
+		Kind   string
+		Bucket int
+		Count  int
+	}
+	bucketSQL := localVisitsSQL + `
+			SELECT 'weekday' AS kind, EXTRACT(DOW FROM local_at)::int AS bucket, COUNT(*) AS count FROM v GROUP BY 2
+			UNION ALL
+			SELECT 'hour' AS kind, EXTRACT(HOUR FROM local_at)::int AS bucket, COUNT(*) AS count FROM v GROUP BY 2`
+	if _, err := r.orm.Raw(bucketSQL, localArgs...).QueryRows(&buckets); err != nil {
+		return nil, err
+	}
+	for _, b := range buckets {
+		switch {
+		case b.Kind == "weekday" && b.Bucket >= 0 && b.Bucket < len(stats.VisitsByWeekday):
+			stats.VisitsByWeekday[b.Bucket] = b.Count
+		case b.Kind == "hour" && b.Bucket >= 0 && b.Bucket < len(stats.VisitsByHour):
+			stats.VisitsByHour[b.Bucket] = b.Count
+		}
+	}
+
+	// 訪問のある週が連続した最長の週数
+	if err := r.orm.Raw(localVisitsSQL+longestWeekStreakSQL, localArgs...).QueryRow(&stats.LongestWeekStreak); err != nil {
+		return nil, err
+	}
+
+	mostVisited, err := r.getMostVisitedBreweries(userProfileID, topBreweries)
+	if err != nil {
+		return nil, err
+	}
+	stats.MostVisitedBreweries = mostVisited
+
+	return stats, nil
+}
+
+// getMostVisitedBreweries 訪問数の多い順（同数の場合は最後の訪問が新しい順）に醸造所を取得する
+func (r *beegoVisitStatsRepository) getMostVisitedBreweries(userProfileID, limit int) ([]*entity.BreweryVisitCount, error) {
+	var rows []struct {
+		BreweryId     int
+		VisitCount    int
+		LastVisitedAt time.Time
+	}
+	sql := `SELECT brewery_id, COUNT(*) AS visit_count, MAX(visited_at) AS last_visited_at
+			FROM visit
+			WHERE user_profile_id = ?
+			GROUP BY brewery_id
+			ORDER BY visit_count DESC, last_visited_at DESC, brewery_id
+			LIMIT ?`
+	if _, err := r.orm.Raw(sql, userProfileID, limit).QueryRows(&rows); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		ids[i] = row.BreweryId
+	}
+	breweries, err := getBreweriesByIDs(r.orm, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.BreweryVisitCount, 0, len(rows))
+	for _, row := range rows {
+		brewery, ok := breweries[row.BreweryId]
+		if !ok {
+			continue
+		}
+		result = append(result, &entity.BreweryVisitCount{
+			Brewery:       brewery,
+			VisitCount:    row.VisitCount,
+			LastVisitedAt: row.LastVisitedAt,
+		})
+	}
+	return result, nil
+}
+
+// GetTransitions 訪問日時の順に連続する訪問で、異なる醸造所へ移動した組み合わせとその回数を取得する
+// from / to を指定した場合は from 以降・to より前の訪問のみを対象とする
+// 訪問ごとではなく醸造所の組み合わせごとに集計するため、訪問数が多くても取得する行数は抑えられる
+func (r *beegoVisitStatsRepository) GetTransitions(userProfileID int, from, to *time.Time) ([]*entity.BreweryTransition, error) {
+	var rows []struct {
+		FromBreweryId int
+		ToBreweryId   int
+		Count         int
+	}
+	where := "user_profile_id = ?"
+	args := []interface{}{userProfileID}
+	if from != nil {
+		where += " AND visited_at >= ?"
+		args = append(args, formatDBTimestamp(*from))
+	}
+	if to != nil {
+		where += " AND visited_at < ?"
+		args = append(args, formatDBTimestamp(*to))
+	}
+	sql := `SELECT from_brewery_id, to_brewery_id, COUNT(*) AS count FROM (
+				SELECT LAG(brewery_id) OVER (ORDER BY visited_at, id) AS from_brewery_id, brewery_id AS to_brewery_id
+				FROM visit
+				WHERE ` + where + `
+			) consecutive
+			WHERE from_brewery_id IS NOT NULL AND from_brewery_id <> to_brewery_id
+			GROUP BY from_brewery_id, to_brewery_id`
+	if _, err := r.orm.Raw(sql, args...).QueryRows(&rows); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(rows)*2)
+	for _, row := range rows {
+		ids = append(ids, row.FromBreweryId, row.ToBreweryId)
+	}
+	breweries, err := getBreweriesByIDs(r.orm, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([]*entity.BreweryTransition, 0, len(rows))
+	for _, row := range rows {
+		from, fromOK := breweries[row.FromBreweryId]
+		to, toOK := breweries[row.ToBreweryId]
+		if !fromOK || !toOK {
+			continue
+		}
+		transitions = append(transitions, &entity.BreweryTransition{
+			From:  from,
+			To:    to,
+			Count: row.Count,
+		})
+	}
+	return transitions, nil
+}
+
+// getBreweriesByIDs IDで醸造所をまとめて取得する（訪問履歴の集計のため、アーカイブ済みの醸造所も含める）
+func getBreweriesByIDs(o orm.Ormer, ids []int) (map[int]*entity.Brewery, error) {
+	breweries := make(map[int]*entity.Brewery, len(ids))
+	if len(ids) == 0 {
+		return breweries, nil
+	}
+
+	// All は Limit を指定しない場合に取得件数が制限されるため、上限なしで取得する
+	var breweryModels []*models.Brewery
+	if _, err := o.QueryTable("brewery").Filter("id__in", ids).Limit(-1).All(&breweryModels); err != nil {
+		return nil, err
+	}
+	for _, model := range breweryModels {
+		brewery, err := breweryModelToEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		breweries[model.Id] = brewery
+	}
+	return breweries, nil
+}
+
+// dbTimeZoneOffsetSeconds 日時カラムの保存に使用するタイムゾーン（orm.DefaultTimeLoc）の現在の UTC からの時差（秒）
+func dbTimeZoneOffsetSeconds() int {
+	_, offset := time.Now().In(orm.DefaultTimeLoc).Zone()
+	return offset
+}
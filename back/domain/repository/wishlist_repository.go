@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/models"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// WishlistRepository 行きたいリストのデータアクセスインターフェースを定義する
+type WishlistRepository interface {
+	Get(userProfileID, breweryID int) (*entity.WishlistItem, error)
+	GetByUserProfile(userProfileID int) ([]*entity.WishlistItem, error)
+	Add(userProfileID, breweryID int) (*entity.WishlistItem, bool, error)
+	Remove(userProfileID, breweryID int) error
+	MarkVisited(userProfileID, breweryID int, visitedAt time.Time) (bool, error)
+}
+
+// beegoWishlistRepository Beego ORMを使用してWishlistRepositoryを実装する
+type beegoWishlistRepository struct {
+	orm orm.Ormer
+}
+
+// NewWishlistRepository 新しいWishlistRepositoryインスタンスを作成する
+func NewWishlistRepository() WishlistRepository {
+	return &beegoWishlistRepository{
+		orm: orm.NewOrm(),
+	}
+}
+
+// Get 行きたいリストの醸造所を醸造所情報とともに取得する
+func (r *beegoWishlistRepository) Get(userProfileID, breweryID int) (*entity.WishlistItem, error) {
+	model := &models.WishlistItem{}
+	err := r.orm.QueryTable("wishlist_item").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery_id", breweryID).
+		RelatedSel("brewery").
+		One(model)
+	if err != nil {
+		return nil, translateError(err, domainerr.ErrWishlistItemNotFound, nil)
+	}
+
+	return r.modelToEntity(model)
+}
+
+// GetByUserProfile 行きたいリストの醸造所を登録日時の新しい順に取得する（アーカイブ済みの醸造所を除く）
+func (r *beegoWishlistRepository) GetByUserProfile(userProfileID int) ([]*entity.WishlistItem, error) {
+	var itemModels []*models.WishlistItem
+	_, err := r.orm.QueryTable("wishlist_item").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery__deleted_at__isnull", true).
+		RelatedSel("brewery").
+		OrderBy("-created_at", "-id").
+		All(&itemModels)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.WishlistItem, len(itemModels))
+	for i, model := range itemModels {
+		item, err := r.modelToEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = item
+	}
+
+	return entities, nil
+}
+
+// Add 醸造所を行きたいリストに登録する（登録済みの場合は既存の登録を返し、created は false）
+func (r *beegoWishlistRepository) Add(userProfileID, breweryID int) (*entity.WishlistItem, bool, error) {
+	var insertedID int
+	sql := `INSERT INTO wishlist_item (user_profile_id, brewery_id, created_at)
+			VALUES (?, ?, NOW())
+			ON CONFLICT (user_profile_id, brewery_id) DO NOTHING
+			RETURNING id`
+	err := r.orm.Raw(sql, userProfileID, breweryID).QueryRow(&insertedID)
+	created := err == nil
+	if err != nil && err != orm.ErrNoRows {
+		return nil, false, translateError(err, nil, nil)
+	}
+
+	item, err := r.Get(userProfileID, breweryID)
+	if err != nil {
+		return nil, false, err
+	}
+	return item, created, nil
+}
+
+// Remove 醸造所を行きたいリストから削除する
+func (r *beegoWishlistRepository) Remove(userProfileID, breweryID int) error {
+	deleted, err := r.orm.QueryTable("wishlist_item").
+		Filter("user_profile_id", userProfileID).
+		Filter("brewery_id", breweryID).
+		Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domainerr.ErrWishlistItemNotFound
+	}
+
+	return nil
+}
+
+// MarkVisited 行きたいリストの醸造所を訪問済みにする（未登録・訪問済みの場合は false）
+func (r *beegoWishlistRepository) MarkVisited(userProfileID, breweryID int, visitedAt time.Time) (bool, error) {
+	result, err := r.orm.Raw(`UPDATE wishlist_item SET visited_at = ?
+			WHERE user_profile_id = ? AND brewery_id = ? AND visited_at IS NULL`,
+		formatDBTimestamp(visitedAt), userProfileID, breweryID).Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// modelToEntity モデルからエンティティに変換する
+func (r *beegoWishlistRepository) modelToEntity(model *models.WishlistItem) (*entity.WishlistItem, error) {
+	builder := entity.NewWishlistItemBuilder().
+		WithID(model.Id).
+		WithUserProfileID(model.UserProfile.Id).
+		WithBreweryID(model.Brewery.Id).
+		WithVisitedAt(model.VisitedAt).
+		WithCreatedAt(model.CreatedAt)
+
+	// 関連する醸造所情報がある場合
+	if model.Brewery.Name != "" {
+		brewery, err := breweryModelToEntity(model.Brewery)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.WithBrewery(brewery)
+	}
+
+	return builder.Build()
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+)
+
+// badgeUsecase バッジユースケースの実装
+type badgeUsecase struct {
+	badgeRepo repository.BadgeRepository
+}
+
+// BadgeUsecase バッジのビジネスロジックインターフェースを定義する
+type BadgeUsecase interface {
+	GetUserBadges(userProfileID int) ([]*entity.UserBadge, error)
+	AwardBadges(userProfileID int, visitID *int) ([]*entity.UserBadge, error)
+	SeedBadges(seedJSON []byte) (int, error)
+}
+
+// BadgeSeed バッジ定義の初期データ
+type BadgeSeed struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	RuleType    string `json:"rule_type"`
+	Threshold   int    `json:"threshold"`
+	Prefecture  string `json:"prefecture"`
+}
+
+// badgeSeedSortOrderStep 初期データの配列の順序から表示順を決める際の間隔（間にバッジを追加しやすくするため）
+const badgeSeedSortOrderStep = 10
+
+// NewBadgeUsecase 新しいバッジユースケースを作成する
+func NewBadgeUsecase(badgeRepo repository.BadgeRepository) BadgeUsecase {
+	return &badgeUsecase{
+		badgeRepo: badgeRepo,
+	}
+}
+
+// GetUserBadges ユーザーが獲得したバッジを取得する
+func (b *badgeUsecase) GetUserBadges(userProfileID int) ([]*entity.UserBadge, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+
+	return b.badgeRepo.GetByUserProfile(userProfileID)
+}
+
+// AwardBadges 訪問実績を評価し、新たに条件を満たしたバッジを付与する
+// 判定は累計の訪問実績に基づくため、付与に失敗したバッジも次回の評価時に付与される
+func (b *badgeUsecase) AwardBadges(userProfileID int, visitID *int) ([]*entity.UserBadge, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+
+	badges, err := b.badgeRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := b.badgeRepo.GetByUserProfile(userProfileID)
+	if err != nil {
+		return nil, err
+	}
+	ownedBadgeIDs := make(map[int]bool, len(owned))
+	for _, userBadge := range owned {
+		ownedBadgeIDs[userBadge.BadgeID()] = true
+	}
+
+	// 全て獲得済みの場合は集計を省略する
+	if len(ownedBadgeIDs) >= len(badges) {
+		return []*entity.UserBadge{}, nil
+	}
+
+	stats, err := b.badgeRepo.GetStats(userProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	awarded := []*entity.UserBadge{}
+	for _, badge := range badges {
+		if ownedBadgeIDs[badge.ID()] || !badge.IsSatisfiedBy(stats) {
+			continue
+		}
+
+		userBadge, created, err := b.badgeRepo.Award(userProfileID, badge, visitID)
+		if err != nil {
+			return awarded, err
+		}
+		if created {
+			awarded = append(awarded, userBadge)
+		}
+	}
+
+	return awarded, nil
+}
+
+// SeedBadges 初期データのバッジ定義をコードをキーに登録・更新し、登録・更新した件数を返す
+// 内容が変わっていないバッジは書き込まない。初期データから削除されたバッジは、獲得済みのバッジを保つため削除しない
+func (b *badgeUsecase) SeedBadges(seedJSON []byte) (int, error) {
+	var seeds []BadgeSeed
+	if err := json.Unmarshal(seedJSON, &seeds); err != nil {
+		return 0, fmt.Errorf("invalid badge seed data: %w", err)
+	}
+
+	badges := make([]*entity.Badge, len(seeds))
+	seen := make(map[string]bool, len(seeds))
+	for i, seed := range seeds {
+		if seen[seed.Code] {
+			return 0, fmt.Errorf("duplicate badge code %q", seed.Code)
+		}
+		seen[seed.Code] = true
+
+		badge, err := entity.NewBadgeBuilder().
+			WithCode(seed.Code).
+			WithName(seed.Name).
+			WithDescription(seed.Description).
+			WithRule(seed.RuleType, seed.Threshold).
+			WithPrefecture(seed.Prefecture).
+			WithSortOrder((i + 1) * badgeSeedSortOrderStep).
+			Build()
+		if err != nil {
+			return 0, fmt.Errorf("invalid badge seed %q: %w", seed.Code, err)
+		}
+		badges[i] = badge
+	}
+
+	existingBadges, err := b.badgeRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[string]*entity.Badge, len(existingBadges))
+	for _, badge := range existingBadges {
+		existing[badge.Code()] = badge
+	}
+
+	count := 0
+	for _, badge := range badges {
+		if saved, ok := existing[badge.Code()]; ok && sameBadge(saved, badge) {
+			continue
+		}
+		if _, err := b.badgeRepo.Upsert(badge); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// sameBadge 登録済みのバッジ定義と初期データの内容が同じかどうかを判定する
+func sameBadge(saved, seed *entity.Badge) bool {
+	return saved.Name() == seed.Name() &&
+		saved.Description() == seed.Description() &&
+		saved.RuleType() == seed.RuleType() &&
+		saved.Threshold() == seed.Threshold() &&
+		saved.Prefecture() == seed.Prefecture() &&
+		saved.SortOrder() == seed.SortOrder()
+}
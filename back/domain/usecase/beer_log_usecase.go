@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+)
+
+// beerLogUsecase ビールの記録ユースケースの実装
+type beerLogUsecase struct {
+	beerLogRepo repository.BeerLogRepository
+	visitRepo   repository.VisitRepository
+	beerRepo    repository.BeerRepository
+}
+
+// BeerLogInput ビールの記録の登録・更新内容
+type BeerLogInput struct {
+	BeerID      *int // カタログのビールID（カタログにないビールの場合は nil とし BeerName を指定する）
+	BeerName    string
+	ServingType string
+	Rating      *float64
+	Notes       string
+	FlavorTags  []string
+}
+
+// BeerLogUsecase ビールの記録のビジネスロジックインターフェースを定義する
+type BeerLogUsecase interface {
+	GetVisitBeerLogs(visitID int) ([]*entity.BeerLog, error)
+	AddBeerLog(visitID, userProfileID int, input BeerLogInput) (*entity.BeerLog, error)
+	UpdateBeerLog(visitID, beerLogID, userProfileID int, input BeerLogInput) (*entity.BeerLog, error)
+	DeleteBeerLog(visitID, beerLogID, userProfileID int) error
+}
+
+// NewBeerLogUsecase 新しいビールの記録ユースケースを作成する
+func NewBeerLogUsecase(beerLogRepo repository.BeerLogRepository, visitRepo repository.VisitRepository, beerRepo repository.BeerRepository) BeerLogUsecase {
+	return &beerLogUsecase{
+		beerLogRepo: beerLogRepo,
+		visitRepo:   visitRepo,
+		beerRepo:    beerRepo,
+	}
+}
+
+// GetVisitBeerLogs 訪問中に飲んだビールの記録を取得する（訪問へのアクセス権は呼び出し元で確認する）
+func (u *beerLogUsecase) GetVisitBeerLogs(visitID int) ([]*entity.BeerLog, error) {
+	if visitID <= 0 {
+		return nil, domainerr.Invalid("invalid visit id")
+	}
+
+	return u.beerLogRepo.GetByVisit(visitID)
+}
+
+// AddBeerLog 訪問にビールの記録を追加する
+func (u *beerLogUsecase) AddBeerLog(visitID, userProfileID int, input BeerLogInput) (*entity.BeerLog, error) {
+	if err := u.checkVisitOwner(visitID, userProfileID); err != nil {
+		return nil, err
+	}
+
+	builder, err := u.newBeerLogBuilder(input)
+	if err != nil {
+		return nil, err
+	}
+
+	beerLog, err := builder.
+		WithVisitID(visitID).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.beerLogRepo.Create(beerLog)
+}
+
+// UpdateBeerLog ビールの記録を全て置き換える
+func (u *beerLogUsecase) UpdateBeerLog(visitID, beerLogID, userProfileID int, input BeerLogInput) (*entity.BeerLog, error) {
+	beerLog, err := u.getOwnedBeerLog(visitID, beerLogID, userProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err := u.newBeerLogBuilder(input)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBeerLog, err := builder.
+		WithID(beerLog.ID()).
+		WithVisitID(beerLog.VisitID()).
+		WithCreatedAt(beerLog.CreatedAt()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.beerLogRepo.Update(updatedBeerLog)
+}
+
+// DeleteBeerLog ビールの記録を削除する
+func (u *beerLogUsecase) DeleteBeerLog(visitID, beerLogID, userProfileID int) error {
+	beerLog, err := u.getOwnedBeerLog(visitID, beerLogID, userProfileID)
+	if err != nil {
+		return err
+	}
+
+	return u.beerLogRepo.Delete(beerLog.ID())
+}
+
+// checkVisitOwner 訪問が指定ユーザーのものかどうかを確認する
+func (u *beerLogUsecase) checkVisitOwner(visitID, userProfileID int) error {
+	if visitID <= 0 || userProfileID <= 0 {
+		return domainerr.Invalid("invalid visit id or user profile id")
+	}
+
+	visit, err := u.visitRepo.GetByID(visitID)
+	if err != nil {
+		return err
+	}
+
+	// 自分の訪問のみ記録を編集できる
+	if visit.UserProfileID() != userProfileID {
+		return domainerr.ErrVisitAccessDenied
+	}
+
+	return nil
+}
+
+// getOwnedBeerLog 指定ユーザーの訪問に属するビールの記録を取得する
+func (u *beerLogUsecase) getOwnedBeerLog(visitID, beerLogID, userProfileID int) (*entity.BeerLog, error) {
+	if beerLogID <= 0 {
+		return nil, domainerr.Invalid("invalid beer log id")
+	}
+	if err := u.checkVisitOwner(visitID, userProfileID); err != nil {
+		return nil, err
+	}
+
+	beerLog, err := u.beerLogRepo.GetByID(beerLogID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 別の訪問の記録は存在しないものとして扱う
+	if beerLog.VisitID() != visitID {
+		return nil, domainerr.ErrBeerLogNotFound
+	}
+
+	return beerLog, nil
+}
+
+// newBeerLogBuilder 入力内容を設定した BeerLogBuilder を作成する
+// カタログのビールを参照する場合は、ビールが削除されても記録が残るよう名前を控えておく
+func (u *beerLogUsecase) newBeerLogBuilder(input BeerLogInput) (*entity.BeerLogBuilder, error) {
+	beerName := input.BeerName
+	if input.BeerID != nil {
+		beer, err := u.beerRepo.GetByID(*input.BeerID)
+		if err != nil {
+			return nil, err
+		}
+		beerName = beer.Name()
+	}
+
+	return entity.NewBeerLogBuilder().
+		WithBeerID(input.BeerID).
+		WithBeerName(beerName).
+		WithServingType(input.ServingType).
+		WithRating(input.Rating).
+		WithNotes(input.Notes).
+		WithFlavorTags(input.FlavorTags), nil
+}
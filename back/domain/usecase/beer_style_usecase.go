@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+)
+
+// beerStyleUsecase ビアスタイルユースケースの実装
+type beerStyleUsecase struct {
+	styleRepo   repository.BeerStyleRepository
+	breweryRepo repository.BreweryRepository
+}
+
+// BeerStyleSeed ビアスタイルの初期データ（子スタイルを入れ子で持つ）
+type BeerStyleSeed struct {
+	Code     string          `json:"code"`
+	NameJa   string          `json:"name_ja"`
+	NameEn   string          `json:"name_en"`
+	Children []BeerStyleSeed `json:"children"`
+}
+
+// BeerStyleUsecase ビアスタイルのビジネスロジックインターフェースを定義する
+type BeerStyleUsecase interface {
+	GetStyleTree() (*entity.BeerStyleTree, error)
+	SeedStyles(seedJSON []byte) (int, error)
+	GetBreweryStyles(breweryID int) ([]*entity.BeerStyle, error)
+	UpdateBreweryStyles(breweryID int, styleCodes []string) ([]*entity.BeerStyle, error)
+}
+
+// NewBeerStyleUsecase 新しいビアスタイルユースケースを作成する
+func NewBeerStyleUsecase(styleRepo repository.BeerStyleRepository, breweryRepo repository.BreweryRepository) BeerStyleUsecase {
+	return &beerStyleUsecase{
+		styleRepo:   styleRepo,
+		breweryRepo: breweryRepo,
+	}
+}
+
+// GetStyleTree ビアスタイルの木構造を取得する
+func (u *beerStyleUsecase) GetStyleTree() (*entity.BeerStyleTree, error) {
+	styles, err := u.styleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewBeerStyleTree(styles), nil
+}
+
+// SeedStyles 初期データのビアスタイルをコードをキーに登録・更新し、登録・更新した件数を返す
+// 内容が変わっていないスタイルは書き込まない。初期データから削除されたスタイルは、醸造所との紐付けを保つため削除しない
+func (u *beerStyleUsecase) SeedStyles(seedJSON []byte) (int, error) {
+	var seeds []BeerStyleSeed
+	if err := json.Unmarshal(seedJSON, &seeds); err != nil {
+		return 0, fmt.Errorf("invalid beer style seed data: %w", err)
+	}
+	if err := validateStyleSeeds(seeds, 0, make(map[string]bool)); err != nil {
+		return 0, err
+	}
+
+	existingStyles, err := u.styleRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[string]*entity.BeerStyle, len(existingStyles))
+	for _, style := range existingStyles {
+		existing[style.Code()] = style
+	}
+
+	return u.seedStyles(seeds, nil, existing)
+}
+
+// seedStyles 親から順にビアスタイルを登録する
+func (u *beerStyleUsecase) seedStyles(seeds []BeerStyleSeed, parentID *int, existing map[string]*entity.BeerStyle) (int, error) {
+	count := 0
+	for i, seed := range seeds {
+		style, err := entity.NewBeerStyleBuilder().
+			WithCode(seed.Code).
+			WithParentID(parentID).
+			WithNames(seed.NameJa, seed.NameEn).
+			WithSortOrder(i + 1).
+			Build()
+		if err != nil {
+			return count, err
+		}
+
+		saved, ok := existing[style.Code()]
+		if !ok || !sameBeerStyle(saved, style) {
+			if saved, err = u.styleRepo.Upsert(style); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		savedID := saved.ID()
+		childCount, err := u.seedStyles(seed.Children, &savedID, existing)
+		count += childCount
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// sameBeerStyle 登録済みのスタイルと初期データの内容が同じかどうかを判定する
+func sameBeerStyle(saved, seed *entity.BeerStyle) bool {
+	sameParent := (saved.ParentID() == nil && seed.ParentID() == nil) ||
+		(saved.ParentID() != nil && seed.ParentID() != nil && *saved.ParentID() == *seed.ParentID())
+	return sameParent &&
+		saved.NameJa() == seed.NameJa() &&
+		saved.NameEn() == seed.NameEn() &&
+		saved.SortOrder() == seed.SortOrder()
+}
+
+// validateStyleSeeds 初期データのコードの重複と階層の深さを検証する
+func validateStyleSeeds(seeds []BeerStyleSeed, depth int, seen map[string]bool) error {
+	for _, seed := range seeds {
+		if depth >= entity.MaxBeerStyleDepth {
+			return fmt.Errorf("beer style %q exceeds max depth %d", seed.Code, entity.MaxBeerStyleDepth)
+		}
+		if seen[seed.Code] {
+			return fmt.Errorf("duplicate beer style code %q", seed.Code)
+		}
+		seen[seed.Code] = true
+
+		if err := validateStyleSeeds(seed.Children, depth+1, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBreweryStyles 醸造所が扱うビアスタイルを取得する
+func (u *beerStyleUsecase) GetBreweryStyles(breweryID int) ([]*entity.BeerStyle, error) {
+	if err := u.checkActiveBrewery(breweryID); err != nil {
+		return nil, err
+	}
+
+	return u.styleRepo.GetByBrewery(breweryID)
+}
+
+// UpdateBreweryStyles 醸造所が扱うビアスタイルをコードで指定して置き換える
+func (u *beerStyleUsecase) UpdateBreweryStyles(breweryID int, styleCodes []string) ([]*entity.BeerStyle, error) {
+	if err := u.checkActiveBrewery(breweryID); err != nil {
+		return nil, err
+	}
+
+	tree, err := u.GetStyleTree()
+	if err != nil {
+		return nil, err
+	}
+
+	styleIDs := make([]int, 0, len(styleCodes))
+	seen := make(map[int]bool, len(styleCodes))
+	for _, code := range styleCodes {
+		style, ok := tree.FindByCode(code)
+		if !ok {
+			return nil, domainerr.Wrap(domainerr.ErrUnknownStyle, fmt.Errorf("style %q", code))
+		}
+		if seen[style.ID()] {
+			continue
+		}
+		seen[style.ID()] = true
+		styleIDs = append(styleIDs, style.ID())
+	}
+
+	if err := u.styleRepo.ReplaceBreweryStyles(breweryID, styleIDs); err != nil {
+		return nil, err
+	}
+
+	return u.styleRepo.GetByBrewery(breweryID)
+}
+
+// checkActiveBrewery 醸造所が存在し、アーカイブされていないことを確認する
+func (u *beerStyleUsecase) checkActiveBrewery(breweryID int) error {
+	if breweryID <= 0 {
+		return domainerr.Invalid("invalid brewery id")
+	}
+
+	brewery, err := u.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		return err
+	}
+	if brewery.IsArchived() {
+		return domainerr.ErrBreweryNotFound
+	}
+
+	return nil
+}
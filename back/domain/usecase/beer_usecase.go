@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"time"
+)
+
+// beerUsecase ビールユースケースの実装
+type beerUsecase struct {
+	beerRepo    repository.BeerRepository
+	breweryRepo repository.BreweryRepository
+}
+
+// BeerInput ビールの登録・更新内容
+type BeerInput struct {
+	Name           string
+	Style          string
+	ABV            *float64
+	IBU            *int
+	Description    string
+	IsSeasonal     bool
+	IsLimited      bool
+	AvailableFrom  *time.Time
+	AvailableUntil *time.Time
+}
+
+// BeerUsecase ビールのビジネスロジックインターフェースを定義する
+type BeerUsecase interface {
+	GetBeer(id int) (*entity.Beer, error)
+	GetBreweryBeers(breweryID int, limit, offset int) ([]*entity.Beer, int, error)
+	CreateBeer(breweryID int, input BeerInput) (*entity.Beer, error)
+	UpdateBeer(id int, input BeerInput, expectedUpdatedAt time.Time) (*entity.Beer, error)
+	DeleteBeer(id int) error
+}
+
+// NewBeerUsecase 新しいビールユースケースを作成する
+func NewBeerUsecase(beerRepo repository.BeerRepository, breweryRepo repository.BreweryRepository) BeerUsecase {
+	return &beerUsecase{
+		beerRepo:    beerRepo,
+		breweryRepo: breweryRepo,
+	}
+}
+
+// GetBeer IDでビールを取得する
+func (u *beerUsecase) GetBeer(id int) (*entity.Beer, error) {
+	if id <= 0 {
+		return nil, domainerr.Invalid("invalid beer id")
+	}
+
+	beer, err := u.beerRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// アーカイブ済みの醸造所のビールは存在しないものとして扱う
+	if beer.Brewery() != nil && beer.Brewery().IsArchived() {
+		return nil, domainerr.ErrBeerNotFound
+	}
+
+	return beer, nil
+}
+
+// GetBreweryBeers 醸造所のビール一覧を取得する
+func (u *beerUsecase) GetBreweryBeers(breweryID int, limit, offset int) ([]*entity.Beer, int, error) {
+	if _, err := u.getActiveBrewery(breweryID); err != nil {
+		return nil, 0, err
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.beerRepo.GetByBrewery(breweryID, limit, offset)
+}
+
+// CreateBeer 醸造所にビールを登録する
+func (u *beerUsecase) CreateBeer(breweryID int, input BeerInput) (*entity.Beer, error) {
+	brewery, err := u.getActiveBrewery(breweryID)
+	if err != nil {
+		return nil, err
+	}
+
+	beer, err := newBeerBuilder(input).
+		WithBrewery(brewery).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.beerRepo.Create(beer)
+}
+
+// UpdateBeer ビール情報を全て置き換える
+func (u *beerUsecase) UpdateBeer(id int, input BeerInput, expectedUpdatedAt time.Time) (*entity.Beer, error) {
+	if expectedUpdatedAt.IsZero() {
+		return nil, domainerr.ErrUpdatedAtRequired
+	}
+
+	beer, err := u.GetBeer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// BeerBuilder でバリデーションを行う
+	updatedBeer, err := newBeerBuilder(input).
+		WithID(beer.ID()).
+		WithBreweryID(beer.BreweryID()).
+		WithCreatedAt(beer.CreatedAt()).
+		WithUpdatedAt(beer.UpdatedAt()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.beerRepo.Update(updatedBeer, expectedUpdatedAt)
+}
+
+// DeleteBeer ビールを削除する
+func (u *beerUsecase) DeleteBeer(id int) error {
+	if id <= 0 {
+		return domainerr.Invalid("invalid beer id")
+	}
+
+	return u.beerRepo.Delete(id)
+}
+
+// getActiveBrewery アーカイブされていない醸造所を取得する
+func (u *beerUsecase) getActiveBrewery(breweryID int) (*entity.Brewery, error) {
+	if breweryID <= 0 {
+		return nil, domainerr.Invalid("invalid brewery id")
+	}
+
+	brewery, err := u.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		return nil, err
+	}
+	if brewery.IsArchived() {
+		return nil, domainerr.ErrBreweryNotFound
+	}
+
+	return brewery, nil
+}
+
+// newBeerBuilder 入力内容を設定した BeerBuilder を作成する
+func newBeerBuilder(input BeerInput) *entity.BeerBuilder {
+	return entity.NewBeerBuilder().
+		WithName(input.Name).
+		WithStyle(input.Style).
+		WithABV(input.ABV).
+		WithIBU(input.IBU).
+		WithDescription(input.Description).
+		WithSeasonal(input.IsSeasonal).
+		WithLimited(input.IsLimited).
+		WithAvailability(input.AvailableFrom, input.AvailableUntil)
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+)
+
+// breweryManagerUsecase 醸造所管理者ユースケースの実装
+type breweryManagerUsecase struct {
+	breweryManagerRepo repository.BreweryManagerRepository
+	breweryRepo        repository.BreweryRepository
+	userProfileRepo    repository.UserProfileRepository
+}
+
+// BreweryManagerUsecase 醸造所管理者のビジネスロジックインターフェースを定義する
+type BreweryManagerUsecase interface {
+	AssignManager(breweryID, userProfileID int) (*entity.BreweryManager, error)
+	RevokeManager(breweryID, userProfileID int) error
+	GetManagers(breweryID int) ([]*entity.BreweryManager, error)
+	GetManagedBreweries(userProfileID int) ([]*entity.Brewery, error)
+	IsManager(userProfileID, breweryID int) (bool, error)
+}
+
+// NewBreweryManagerUsecase 新しい醸造所管理者ユースケースを作成する
+func NewBreweryManagerUsecase(
+	breweryManagerRepo repository.BreweryManagerRepository,
+	breweryRepo repository.BreweryRepository,
+	userProfileRepo repository.UserProfileRepository,
+) BreweryManagerUsecase {
+	return &breweryManagerUsecase{
+		breweryManagerRepo: breweryManagerRepo,
+		breweryRepo:        breweryRepo,
+		userProfileRepo:    userProfileRepo,
+	}
+}
+
+// AssignManager ユーザーを醸造所の管理者として登録する
+func (u *breweryManagerUsecase) AssignManager(breweryID, userProfileID int) (*entity.BreweryManager, error) {
+	if breweryID <= 0 || userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid brewery id or user profile id")
+	}
+
+	brewery, err := u.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		return nil, err
+	}
+	if brewery.IsArchived() {
+		return nil, domainerr.ErrBreweryNotFound
+	}
+	if _, err := u.userProfileRepo.GetByID(userProfileID); err != nil {
+		return nil, err
+	}
+
+	// 重複登録チェック
+	exists, err := u.breweryManagerRepo.Exists(userProfileID, breweryID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domainerr.ErrBreweryManagerAlreadyExists
+	}
+
+	breweryManager, err := entity.NewBreweryManagerBuilder().
+		WithUserProfileID(userProfileID).
+		WithBreweryID(breweryID).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.breweryManagerRepo.Create(breweryManager)
+}
+
+// RevokeManager 醸造所の管理者登録を解除する
+func (u *breweryManagerUsecase) RevokeManager(breweryID, userProfileID int) error {
+	if breweryID <= 0 || userProfileID <= 0 {
+		return domainerr.Invalid("invalid brewery id or user profile id")
+	}
+
+	return u.breweryManagerRepo.Delete(userProfileID, breweryID)
+}
+
+// GetManagers 醸造所の管理者一覧を取得する
+func (u *breweryManagerUsecase) GetManagers(breweryID int) ([]*entity.BreweryManager, error) {
+	if breweryID <= 0 {
+		return nil, domainerr.Invalid("invalid brewery id")
+	}
+
+	if _, err := u.breweryRepo.GetByID(breweryID); err != nil {
+		return nil, err
+	}
+
+	return u.breweryManagerRepo.GetByBrewery(breweryID)
+}
+
+// GetManagedBreweries ユーザーが管理する醸造所の一覧を取得する
+func (u *breweryManagerUsecase) GetManagedBreweries(userProfileID int) ([]*entity.Brewery, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+
+	managers, err := u.breweryManagerRepo.GetByUserProfile(userProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	breweries := make([]*entity.Brewery, 0, len(managers))
+	for _, manager := range managers {
+		if manager.Brewery() != nil && !manager.Brewery().IsArchived() {
+			breweries = append(breweries, manager.Brewery())
+		}
+	}
+
+	return breweries, nil
+}
+
+// IsManager ユーザーが醸造所の管理者かどうかを判定する
+func (u *breweryManagerUsecase) IsManager(userProfileID, breweryID int) (bool, error) {
+	if userProfileID <= 0 || breweryID <= 0 {
+		return false, nil
+	}
+
+	return u.breweryManagerRepo.Exists(userProfileID, breweryID)
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"time"
+)
+
+// breweryPostUsecase 醸造所の投稿ユースケースの実装
+type breweryPostUsecase struct {
+	postRepo    repository.BreweryPostRepository
+	breweryRepo repository.BreweryRepository
+	followRepo  repository.BreweryFollowRepository
+	visitRepo   repository.VisitRepository
+}
+
+// PostViewer 投稿を閲覧するユーザー
+type PostViewer struct {
+	UserProfileID int  // 未ログインの場合は 0
+	CanManage     bool // 管理者または担当の醸造所管理者（予約投稿・全ての公開範囲の投稿を閲覧できる）
+}
+
+// BreweryPostInput 投稿の登録・更新内容
+type BreweryPostInput struct {
+	Title      string
+	Body       string
+	ImageURLs  []string
+	PublishAt  *time.Time // nil の場合は即時公開
+	IsPinned   bool
+	Visibility string // 空の場合は public
+	MinFanRank string // 公開範囲が fan_rank で空の場合は regular
+}
+
+// BreweryPostUsecase 醸造所の投稿のビジネスロジックインターフェースを定義する
+type BreweryPostUsecase interface {
+	GetPosts(breweryID int, viewer PostViewer, limit, offset int) ([]*entity.BreweryPost, int, error)
+	GetPost(breweryID, postID int, viewer PostViewer) (*entity.BreweryPost, error)
+	CreatePost(breweryID, authorUserProfileID int, input BreweryPostInput) (*entity.BreweryPost, error)
+	UpdatePost(breweryID, postID int, input BreweryPostInput) (*entity.BreweryPost, error)
+	DeletePost(breweryID, postID int) error
+}
+
+// NewBreweryPostUsecase 新しい醸造所の投稿ユースケースを作成する
+func NewBreweryPostUsecase(postRepo repository.BreweryPostRepository, breweryRepo repository.BreweryRepository, followRepo repository.BreweryFollowRepository, visitRepo repository.VisitRepository) BreweryPostUsecase {
+	return &breweryPostUsecase{
+		postRepo:    postRepo,
+		breweryRepo: breweryRepo,
+		followRepo:  followRepo,
+		visitRepo:   visitRepo,
+	}
+}
+
+// GetPosts 閲覧者が見られる醸造所の投稿を固定表示・公開日時の新しい順に取得する
+func (u *breweryPostUsecase) GetPosts(breweryID int, viewer PostViewer, limit, offset int) ([]*entity.BreweryPost, int, error) {
+	if err := u.checkActiveBrewery(breweryID); err != nil {
+		return nil, 0, err
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	filter, err := u.viewerFilter(breweryID, viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return u.postRepo.GetByBrewery(breweryID, filter, limit, offset)
+}
+
+// GetPost 投稿を取得する（閲覧者が見られない投稿は存在しないものとして扱う）
+func (u *breweryPostUsecase) GetPost(breweryID, postID int, viewer PostViewer) (*entity.BreweryPost, error) {
+	post, err := u.getBreweryPost(breweryID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := u.viewerFilter(breweryID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewPost(post, filter) {
+		return nil, domainerr.ErrBreweryPostNotFound
+	}
+
+	return post, nil
+}
+
+// CreatePost 醸造所の投稿を作成する
+func (u *breweryPostUsecase) CreatePost(breweryID, authorUserProfileID int, input BreweryPostInput) (*entity.BreweryPost, error) {
+	if err := u.checkActiveBrewery(breweryID); err != nil {
+		return nil, err
+	}
+
+	builder := newBreweryPostBuilder(input).
+		WithBreweryID(breweryID)
+	if authorUserProfileID > 0 {
+		builder = builder.WithAuthorUserProfileID(&authorUserProfileID)
+	}
+
+	post, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.postRepo.Create(post)
+}
+
+// UpdatePost 投稿の内容を全て置き換える
+func (u *breweryPostUsecase) UpdatePost(breweryID, postID int, input BreweryPostInput) (*entity.BreweryPost, error) {
+	post, err := u.getBreweryPost(breweryID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 公開日時を省略した場合は元の公開日時を維持する（公開済みの投稿を編集しても公開日時が変わらないようにする）
+	if input.PublishAt == nil {
+		publishAt := post.PublishAt()
+		input.PublishAt = &publishAt
+	}
+
+	updatedPost, err := newBreweryPostBuilder(input).
+		WithID(post.ID()).
+		WithBreweryID(post.BreweryID()).
+		WithAuthorUserProfileID(post.AuthorUserProfileID()).
+		WithCreatedAt(post.CreatedAt()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.postRepo.Update(updatedPost)
+}
+
+// DeletePost 投稿を削除する
+func (u *breweryPostUsecase) DeletePost(breweryID, postID int) error {
+	post, err := u.getBreweryPost(breweryID, postID)
+	if err != nil {
+		return err
+	}
+
+	return u.postRepo.Delete(post.ID())
+}
+
+// getBreweryPost アーカイブされていない醸造所の投稿を取得する
+func (u *breweryPostUsecase) getBreweryPost(breweryID, postID int) (*entity.BreweryPost, error) {
+	if postID <= 0 {
+		return nil, domainerr.Invalid("invalid post id")
+	}
+	if err := u.checkActiveBrewery(breweryID); err != nil {
+		return nil, err
+	}
+
+	post, err := u.postRepo.GetByID(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 別の醸造所の投稿は存在しないものとして扱う
+	if post.BreweryID() != breweryID {
+		return nil, domainerr.ErrBreweryPostNotFound
+	}
+
+	return post, nil
+}
+
+// checkActiveBrewery 醸造所が存在し、アーカイブされていないことを確認する
+func (u *breweryPostUsecase) checkActiveBrewery(breweryID int) error {
+	if breweryID <= 0 {
+		return domainerr.Invalid("invalid brewery id")
+	}
+
+	brewery, err := u.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		return err
+	}
+	if brewery.IsArchived() {
+		return domainerr.ErrBreweryNotFound
+	}
+
+	return nil
+}
+
+// viewerFilter 閲覧者が見られる投稿の絞り込み条件を作成する
+// 公開済みの投稿のうち、public は全員、followers はフォロワー、fan_rank は必要なファンランクに到達したユーザーが閲覧できる
+func (u *breweryPostUsecase) viewerFilter(breweryID int, viewer PostViewer) (repository.BreweryPostFilter, error) {
+	if viewer.CanManage {
+		return repository.BreweryPostFilter{}, nil
+	}
+
+	now := time.Now()
+	filter := repository.BreweryPostFilter{
+		PublishedBefore: &now,
+		Visibilities:    []string{entity.PostVisibilityPublic},
+	}
+	if viewer.UserProfileID <= 0 {
+		return filter, nil
+	}
+
+	following, err := u.followRepo.IsFollowing(viewer.UserProfileID, breweryID)
+	if err != nil {
+		return filter, err
+	}
+	if following {
+		filter.Visibilities = append(filter.Visibilities, entity.PostVisibilityFollowers)
+	}
+
+	visitCount, err := u.visitRepo.CountByUserProfileAndBrewery(viewer.UserProfileID, breweryID)
+	if err != nil {
+		return filter, err
+	}
+	filter.FanRanks = entity.FanRanksForVisits(visitCount)
+
+	return filter, nil
+}
+
+// canViewPost 投稿が絞り込み条件を満たすかどうかを判定する
+func canViewPost(post *entity.BreweryPost, filter repository.BreweryPostFilter) bool {
+	if filter.PublishedBefore != nil && !post.IsPublishedAt(*filter.PublishedBefore) {
+		return false
+	}
+	if filter.Visibilities == nil {
+		return true
+	}
+	if post.Visibility() == entity.PostVisibilityFanRank {
+		return containsString(filter.FanRanks, post.MinFanRank())
+	}
+	return containsString(filter.Visibilities, post.Visibility())
+}
+
+// containsString スライスに文字列が含まれるかどうかを判定する
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// newBreweryPostBuilder 入力内容を設定した BreweryPostBuilder を作成する
+func newBreweryPostBuilder(input BreweryPostInput) *entity.BreweryPostBuilder {
+	visibility := input.Visibility
+	if visibility == "" {
+		visibility = entity.PostVisibilityPublic
+	}
+	minFanRank := input.MinFanRank
+	if minFanRank == "" {
+		minFanRank = entity.FanRankRegular
+	}
+
+	builder := entity.NewBreweryPostBuilder().
+		WithTitle(input.Title).
+		WithBody(input.Body).
+		WithImageURLs(input.ImageURLs).
+		WithPinned(input.IsPinned).
+		WithVisibility(visibility, minFanRank)
+	if input.PublishAt != nil {
+		builder = builder.WithPublishAt(*input.PublishAt)
+	}
+	return builder
+}
@@ -1,62 +1,93 @@
 package usecase
 
 import (
-	"errors"
+	"mybeerlog/domain/domainerr"
 	"mybeerlog/domain/entity"
 	"mybeerlog/domain/repository"
+	"strings"
+	"time"
 )
 
 // breweryUsecase 醸造所ユースケースの実装
 type breweryUsecase struct {
 	breweryRepo repository.BreweryRepository
+	styleRepo   repository.BeerStyleRepository
 }
 
 // BreweryUsecase 醸造所のビジネスロジックインターフェースを定義する
 type BreweryUsecase interface {
 	GetBrewery(id int) (*entity.Brewery, error)
-	GetBreweries(limit, offset int) ([]*entity.Brewery, int, error)
-	GetBreweriesByLocation(lat, lng, radius float64, limit, offset int) ([]*entity.Brewery, int, error)
+	GetBreweries(styleCode string, page repository.PageQuery) ([]*entity.Brewery, *repository.PageResult, error)
+	GetBreweriesByLocation(lat, lng, radiusKm float64, styleCode string, limit, offset int) ([]*entity.NearbyBrewery, int, error)
 	CreateBrewery(name, address, description string, lat, lng float64) (*entity.Brewery, error)
+	UpdateBrewery(id int, name, address, description string, lat, lng float64, expectedUpdatedAt time.Time) (*entity.Brewery, error)
+	PatchBrewery(id int, patch BreweryPatch, expectedUpdatedAt time.Time) (*entity.Brewery, error)
+	ArchiveBrewery(id int, expectedUpdatedAt time.Time) (*entity.Brewery, error)
+}
+
+// BreweryPatch 醸造所の部分更新内容（nil の項目は変更しない）
+type BreweryPatch struct {
+	Name        *string
+	Address     *string
+	Description *string
+	Latitude    *float64
+	Longitude   *float64
 }
 
 // NewBreweryUsecase 新しい醸造所ユースケースを作成する
-func NewBreweryUsecase(repo repository.BreweryRepository) BreweryUsecase {
+func NewBreweryUsecase(repo repository.BreweryRepository, styleRepo repository.BeerStyleRepository) BreweryUsecase {
 	return &breweryUsecase{
 		breweryRepo: repo,
+		styleRepo:   styleRepo,
 	}
 }
 
 // GetBrewery IDで醸造所を取得する
 func (b *breweryUsecase) GetBrewery(id int) (*entity.Brewery, error) {
 	if id <= 0 {
-		return nil, errors.New("invalid brewery id")
+		return nil, domainerr.Invalid("invalid brewery id")
+	}
+
+	brewery, err := b.breweryRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// アーカイブ済みの醸造所は存在しないものとして扱う
+	if brewery.IsArchived() {
+		return nil, domainerr.ErrBreweryNotFound
 	}
 
-	return b.breweryRepo.GetByID(id)
+	return brewery, nil
 }
 
 // GetBreweries 全ての醸造所を取得する
-func (b *breweryUsecase) GetBreweries(limit, offset int) ([]*entity.Brewery, int, error) {
-	if limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
+// styleCode を指定した場合は、そのスタイルまたは子孫のスタイルを扱う醸造所のみを返す
+func (b *breweryUsecase) GetBreweries(styleCode string, page repository.PageQuery) ([]*entity.Brewery, *repository.PageResult, error) {
+	styleIDs, err := b.resolveStyleIDs(styleCode)
+	if err != nil {
+		return nil, nil, err
 	}
-	if offset < 0 {
-		offset = 0
+
+	page, err = normalizePageQuery(page)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return b.breweryRepo.GetAll(limit, offset)
+	return b.breweryRepo.GetAll(styleIDs, page)
 }
 
-// GetBreweriesByLocation 位置情報で醸造所を検索する
-func (b *breweryUsecase) GetBreweriesByLocation(lat, lng, radius float64, limit, offset int) ([]*entity.Brewery, int, error) {
-	if lat == 0 || lng == 0 {
-		return nil, 0, errors.New("invalid location parameters")
+// GetBreweriesByLocation 指定地点から半径 radiusKm キロメートル以内の醸造所を距離の近い順に取得する
+func (b *breweryUsecase) GetBreweriesByLocation(lat, lng, radiusKm float64, styleCode string, limit, offset int) ([]*entity.NearbyBrewery, int, error) {
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, 0, domainerr.ErrInvalidSearchRange
+	}
+	styleIDs, err := b.resolveStyleIDs(styleCode)
+	if err != nil {
+		return nil, 0, err
 	}
-	if radius <= 0 {
-		radius = 10.0 // デフォルト10km
+	if radiusKm <= 0 {
+		radiusKm = 10.0 // デフォルト10km
 	}
 	if limit <= 0 {
 		limit = 20
@@ -68,7 +99,27 @@ func (b *breweryUsecase) GetBreweriesByLocation(lat, lng, radius float64, limit,
 		offset = 0
 	}
 
-	return b.breweryRepo.GetByLocation(lat, lng, radius*1000, limit, offset) // kmをmに変換
+	return b.breweryRepo.GetByLocation(lat, lng, radiusKm*1000, styleIDs, limit, offset) // kmをmに変換
+}
+
+// resolveStyleIDs スタイルコードを、そのスタイルと子孫のスタイルのIDに変換する（未指定の場合は nil）
+func (b *breweryUsecase) resolveStyleIDs(styleCode string) ([]int, error) {
+	if strings.TrimSpace(styleCode) == "" {
+		return nil, nil
+	}
+
+	styles, err := b.styleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	tree := entity.NewBeerStyleTree(styles)
+	style, ok := tree.FindByCode(styleCode)
+	if !ok {
+		return nil, domainerr.ErrUnknownStyle
+	}
+
+	return tree.SubtreeIDs(style), nil
 }
 
 // CreateBrewery 新しい醸造所を作成する
@@ -90,3 +141,82 @@ func (b *breweryUsecase) CreateBrewery(name, address, description string, lat, l
 
 	return createdBrewery, nil
 }
+
+// UpdateBrewery 醸造所情報を全て置き換える
+func (b *breweryUsecase) UpdateBrewery(id int, name, address, description string, lat, lng float64, expectedUpdatedAt time.Time) (*entity.Brewery, error) {
+	return b.PatchBrewery(id, BreweryPatch{
+		Name:        &name,
+		Address:     &address,
+		Description: &description,
+		Latitude:    &lat,
+		Longitude:   &lng,
+	}, expectedUpdatedAt)
+}
+
+// PatchBrewery 醸造所情報を部分的に更新する
+func (b *breweryUsecase) PatchBrewery(id int, patch BreweryPatch, expectedUpdatedAt time.Time) (*entity.Brewery, error) {
+	if id <= 0 {
+		return nil, domainerr.Invalid("invalid brewery id")
+	}
+	if expectedUpdatedAt.IsZero() {
+		return nil, domainerr.ErrUpdatedAtRequired
+	}
+
+	brewery, err := b.GetBrewery(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 新しい醸造所エンティティを作成（イミュータブル）
+	newName := brewery.Name()
+	newAddress := brewery.Address()
+	newDescription := brewery.Description()
+	newLat := brewery.Latitude()
+	newLng := brewery.Longitude()
+
+	if patch.Name != nil {
+		newName = *patch.Name
+	}
+	if patch.Address != nil {
+		newAddress = *patch.Address
+	}
+	if patch.Description != nil {
+		newDescription = *patch.Description
+	}
+	if patch.Latitude != nil {
+		newLat = *patch.Latitude
+	}
+	if patch.Longitude != nil {
+		newLng = *patch.Longitude
+	}
+
+	// BreweryBuilder でバリデーションを行う
+	updatedBrewery, err := entity.NewBreweryBuilder().
+		WithID(brewery.ID()).
+		WithName(newName).
+		WithAddress(newAddress).
+		WithDescription(newDescription).
+		WithLocation(newLat, newLng).
+		WithStatus(brewery.Status()).
+		WithCreatedAt(brewery.CreatedAt()).
+		WithUpdatedAt(brewery.UpdatedAt()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.breweryRepo.Update(updatedBrewery, expectedUpdatedAt)
+}
+
+// ArchiveBrewery 醸造所をアーカイブ（論理削除）する
+// 訪問履歴は保持されるが、一覧・詳細・チェックインの対象外になる
+func (b *breweryUsecase) ArchiveBrewery(id int, expectedUpdatedAt time.Time) (*entity.Brewery, error) {
+	if id <= 0 {
+		return nil, domainerr.Invalid("invalid brewery id")
+	}
+	if expectedUpdatedAt.IsZero() {
+		return nil, domainerr.ErrUpdatedAtRequired
+	}
+
+	return b.breweryRepo.Archive(id, expectedUpdatedAt)
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"time"
+)
+
+// calendarUsecase カレンダー購読ユースケースの実装
+type calendarUsecase struct {
+	calendarFeedRepo repository.CalendarFeedRepository
+	visitRepo        repository.VisitRepository
+}
+
+// CalendarUsecase 訪問履歴のカレンダー購読のビジネスロジックインターフェースを定義する
+type CalendarUsecase interface {
+	GetFeed(userProfileID int) (*entity.CalendarFeed, error)
+	RotateToken(userProfileID int) (*entity.CalendarFeed, string, error)
+	RevokeToken(userProfileID int) error
+	GetVisitsByToken(token string, maxEvents int) ([]*entity.Visit, error)
+}
+
+// NewCalendarUsecase 新しいカレンダー購読ユースケースを作成する
+func NewCalendarUsecase(calendarFeedRepo repository.CalendarFeedRepository, visitRepo repository.VisitRepository) CalendarUsecase {
+	return &calendarUsecase{
+		calendarFeedRepo: calendarFeedRepo,
+		visitRepo:        visitRepo,
+	}
+}
+
+// GetFeed ユーザーのカレンダー購読設定を取得する
+func (u *calendarUsecase) GetFeed(userProfileID int) (*entity.CalendarFeed, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+
+	return u.calendarFeedRepo.GetByUserProfile(userProfileID)
+}
+
+// RotateToken 購読URLのトークンを発行し直す（以前のURLは無効になる）
+// トークンはハッシュのみ保存するため、平文のトークンを返すのはこのときだけ
+func (u *calendarUsecase) RotateToken(userProfileID int) (*entity.CalendarFeed, string, error) {
+	if userProfileID <= 0 {
+		return nil, "", domainerr.Invalid("invalid user profile id")
+	}
+
+	token, err := entity.GenerateCalendarToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	feed, err := u.calendarFeedRepo.Rotate(userProfileID, entity.HashCalendarToken(token), time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+	return feed, token, nil
+}
+
+// RevokeToken 購読URLを無効にする
+func (u *calendarUsecase) RevokeToken(userProfileID int) error {
+	if userProfileID <= 0 {
+		return domainerr.Invalid("invalid user profile id")
+	}
+
+	return u.calendarFeedRepo.Delete(userProfileID)
+}
+
+// GetVisitsByToken トークンに対応するユーザーの訪問履歴を新しい順に最大 maxEvents 件取得する
+// トークンが不正・無効な場合はいずれも ErrCalendarFeedNotFound を返す
+func (u *calendarUsecase) GetVisitsByToken(token string, maxEvents int) ([]*entity.Visit, error) {
+	if !entity.IsValidCalendarTokenFormat(token) {
+		return nil, domainerr.ErrCalendarFeedNotFound
+	}
+	if maxEvents <= 0 {
+		return nil, domainerr.Invalid("max events must be positive")
+	}
+
+	feed, err := u.calendarFeedRepo.GetByTokenHash(entity.HashCalendarToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	visits, _, err := u.visitRepo.GetByUserProfile(feed.UserProfileID(), maxEvents, 0)
+	if err != nil {
+		return nil, err
+	}
+	return visits, nil
+}
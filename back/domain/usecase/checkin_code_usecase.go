@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"time"
+)
+
+// checkinCodeUsecase QRチェックイン用コードのユースケースの実装
+type checkinCodeUsecase struct {
+	checkinSecretRepo repository.CheckinSecretRepository
+	breweryRepo       repository.BreweryRepository
+}
+
+// CheckinCodeUsecase 醸造所に掲示するQRチェックイン用コードのビジネスロジックインターフェースを定義する
+type CheckinCodeUsecase interface {
+	GetCurrentCode(breweryID int, period time.Duration) (*entity.CheckinCode, error)
+	RotateSecret(breweryID int, period time.Duration) (*entity.CheckinCode, error)
+}
+
+// NewCheckinCodeUsecase 新しいQRチェックイン用コードのユースケースを作成する
+func NewCheckinCodeUsecase(checkinSecretRepo repository.CheckinSecretRepository, breweryRepo repository.BreweryRepository) CheckinCodeUsecase {
+	return &checkinCodeUsecase{
+		checkinSecretRepo: checkinSecretRepo,
+		breweryRepo:       breweryRepo,
+	}
+}
+
+// GetCurrentCode 醸造所の現在のコードを取得する（シークレットが未発行の場合は発行する）
+func (u *checkinCodeUsecase) GetCurrentCode(breweryID int, period time.Duration) (*entity.CheckinCode, error) {
+	if err := u.checkBrewery(breweryID, period); err != nil {
+		return nil, err
+	}
+
+	generated, err := entity.GenerateCheckinSecret()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := u.checkinSecretRepo.Ensure(breweryID, generated, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return secret.CodeAt(time.Now(), period), nil
+}
+
+// RotateSecret シークレットを再発行し、新しいシークレットでの現在のコードを返す
+// 掲示中のコードが撮影・共有された場合などに、以前のコードを即座に無効にするために使用する
+func (u *checkinCodeUsecase) RotateSecret(breweryID int, period time.Duration) (*entity.CheckinCode, error) {
+	if err := u.checkBrewery(breweryID, period); err != nil {
+		return nil, err
+	}
+
+	generated, err := entity.GenerateCheckinSecret()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := u.checkinSecretRepo.Rotate(breweryID, generated, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return secret.CodeAt(time.Now(), period), nil
+}
+
+// checkBrewery コードを発行できる醸造所かどうかを検証する（アーカイブ済みの醸造所は存在しないものとして扱う）
+func (u *checkinCodeUsecase) checkBrewery(breweryID int, period time.Duration) error {
+	if breweryID <= 0 {
+		return domainerr.Invalid("invalid brewery id")
+	}
+	if period < time.Second {
+		return domainerr.Invalid("code period must be at least one second")
+	}
+
+	brewery, err := u.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		return err
+	}
+	if brewery.IsArchived() {
+		return domainerr.ErrBreweryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"errors"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"time"
+)
+
+// eventUsecase イベントユースケースの実装
+type eventUsecase struct {
+	eventRepo   repository.EventRepository
+	rsvpRepo    repository.EventRSVPRepository
+	breweryRepo repository.BreweryRepository
+}
+
+// EventInput イベントの登録・更新内容
+type EventInput struct {
+	Title       string
+	Description string
+	Location    string
+	StartAt     time.Time
+	EndAt       time.Time
+	TimeZone    string
+	Capacity    int
+}
+
+// EventCheckInInput イベントへの参加チェックインの入力値
+type EventCheckInInput struct {
+	EventID       int
+	UserProfileID int
+	Latitude      float64
+	Longitude     float64
+	AccuracyM     *float64 // 端末が報告したGPSの精度（メートル、任意）
+
+	// 不正チェックイン対策の設定（醸造所へのチェックインと同じ設定を使用する）
+	MaxDistance     float64       // チェックイン許可半径（メートル）
+	MaxAccuracyM    float64       // 許容するGPS精度の上限（メートル、0以下の場合は判定しない）
+	RequireAccuracy bool          // GPS精度の送信を必須とするか
+	EarlyCheckin    time.Duration // 開始前にチェックインを受け付ける時間
+}
+
+// EventUsecase イベントのビジネスロジックインターフェースを定義する
+type EventUsecase interface {
+	GetEvent(id int) (*entity.Event, error)
+	GetBreweryEvents(breweryID int, limit, offset int) ([]*entity.Event, int, error)
+	CreateEvent(breweryID int, input EventInput) (*entity.Event, error)
+	UpdateEvent(id int, input EventInput) (*entity.Event, []int, error)
+	DeleteEvent(id int) error
+	GetRSVP(eventID, userProfileID int) (*entity.EventRSVP, error)
+	RSVP(eventID, userProfileID int) (*entity.EventRSVP, bool, error)
+	CancelRSVP(eventID, userProfileID int) ([]int, error)
+	CheckIn(input EventCheckInInput) (*entity.EventRSVP, error)
+}
+
+// NewEventUsecase 新しいイベントユースケースを作成する
+func NewEventUsecase(eventRepo repository.EventRepository, rsvpRepo repository.EventRSVPRepository, breweryRepo repository.BreweryRepository) EventUsecase {
+	return &eventUsecase{
+		eventRepo:   eventRepo,
+		rsvpRepo:    rsvpRepo,
+		breweryRepo: breweryRepo,
+	}
+}
+
+// GetEvent IDでイベントを取得する
+func (u *eventUsecase) GetEvent(id int) (*entity.Event, error) {
+	if id <= 0 {
+		return nil, domainerr.Invalid("invalid event id")
+	}
+
+	event, err := u.eventRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// アーカイブ済みの醸造所のイベントは存在しないものとして扱う
+	if event.Brewery() != nil && event.Brewery().IsArchived() {
+		return nil, domainerr.ErrEventNotFound
+	}
+
+	return event, nil
+}
+
+// GetBreweryEvents 醸造所の開催予定・開催中のイベントを開始日時の早い順に取得する
+func (u *eventUsecase) GetBreweryEvents(breweryID int, limit, offset int) ([]*entity.Event, int, error) {
+	if _, err := u.getActiveBrewery(breweryID); err != nil {
+		return nil, 0, err
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.eventRepo.GetByBrewery(breweryID, time.Now(), limit, offset)
+}
+
+// CreateEvent 醸造所のイベントを登録する
+func (u *eventUsecase) CreateEvent(breweryID int, input EventInput) (*entity.Event, error) {
+	brewery, err := u.getActiveBrewery(breweryID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := newEventBuilder(input).
+		WithBrewery(brewery).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	if event.HasEndedAt(time.Now()) {
+		return nil, domainerr.Invalid("end_at must be in the future")
+	}
+
+	return u.eventRepo.Create(event)
+}
+
+// UpdateEvent イベント情報を全て置き換える（定員の増加で繰り上がったユーザーのIDも返す）
+func (u *eventUsecase) UpdateEvent(id int, input EventInput) (*entity.Event, []int, error) {
+	event, err := u.GetEvent(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// EventBuilder でバリデーションを行う
+	updatedEvent, err := newEventBuilder(input).
+		WithID(event.ID()).
+		WithBreweryID(event.BreweryID()).
+		WithCreatedAt(event.CreatedAt()).
+		Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return u.eventRepo.Update(updatedEvent)
+}
+
+// DeleteEvent イベントを削除する
+func (u *eventUsecase) DeleteEvent(id int) error {
+	if id <= 0 {
+		return domainerr.Invalid("invalid event id")
+	}
+
+	return u.eventRepo.Delete(id)
+}
+
+// GetRSVP ユーザーのイベントへの参加申込を取得する
+func (u *eventUsecase) GetRSVP(eventID, userProfileID int) (*entity.EventRSVP, error) {
+	if _, err := u.GetEvent(eventID); err != nil {
+		return nil, err
+	}
+
+	return u.rsvpRepo.Get(eventID, userProfileID)
+}
+
+// RSVP イベントに参加を申し込む（満席の場合はキャンセル待ち、申込済みの場合は既存の申込を返し created は false）
+func (u *eventUsecase) RSVP(eventID, userProfileID int) (*entity.EventRSVP, bool, error) {
+	if userProfileID <= 0 {
+		return nil, false, domainerr.Invalid("invalid user profile id")
+	}
+
+	event, err := u.GetEvent(eventID)
+	if err != nil {
+		return nil, false, err
+	}
+	if event.HasEndedAt(time.Now()) {
+		return nil, false, domainerr.ErrEventClosed
+	}
+
+	return u.rsvpRepo.Create(eventID, userProfileID)
+}
+
+// CancelRSVP 参加申込を取り消す（キャンセル待ちから繰り上がったユーザーのIDを返す）
+func (u *eventUsecase) CancelRSVP(eventID, userProfileID int) ([]int, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+
+	event, err := u.GetEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.HasEndedAt(time.Now()) {
+		return nil, domainerr.ErrEventClosed
+	}
+
+	return u.rsvpRepo.Cancel(eventID, userProfileID)
+}
+
+// CheckIn 開催中のイベント会場（醸造所）で GPS チェックインし、参加を確認する
+// 参加確定のユーザーのみ、開始前の受付時間から終了までの間に醸造所のチェックイン範囲内でチェックインできる
+func (u *eventUsecase) CheckIn(input EventCheckInInput) (*entity.EventRSVP, error) {
+	if input.UserProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+	if input.MaxDistance <= 0 {
+		return nil, errors.New("max distance must be positive")
+	}
+
+	event, err := u.GetEvent(input.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !event.IsCheckinOpenAt(now, input.EarlyCheckin) {
+		return nil, domainerr.ErrEventNotInProgress
+	}
+
+	rsvp, err := u.rsvpRepo.Get(event.ID(), input.UserProfileID)
+	if err != nil {
+		return nil, err
+	}
+	if !rsvp.IsGoing() {
+		return nil, domainerr.ErrNotGoingToEvent
+	}
+
+	// GPS精度チェック（醸造所へのチェックインと同じ基準）
+	if err := checkLocationAccuracy(CheckInInput{
+		AccuracyM:       input.AccuracyM,
+		MaxAccuracyM:    input.MaxAccuracyM,
+		RequireAccuracy: input.RequireAccuracy,
+	}); err != nil {
+		return nil, err
+	}
+
+	// GPS距離チェック
+	brewery := event.Brewery()
+	if brewery == nil {
+		if brewery, err = u.breweryRepo.GetByID(event.BreweryID()); err != nil {
+			return nil, err
+		}
+	}
+	withinRange, err := brewery.IsWithinCheckinRange(input.Latitude, input.Longitude, input.MaxDistance)
+	if err != nil {
+		return nil, err
+	}
+	if !withinRange {
+		return nil, domainerr.ErrTooFarFromBrewery
+	}
+
+	// 確認済みの場合は最初の確認日時を残す
+	if _, err := u.rsvpRepo.MarkAttended(event.ID(), input.UserProfileID, now); err != nil {
+		return nil, err
+	}
+
+	return u.rsvpRepo.Get(event.ID(), input.UserProfileID)
+}
+
+// getActiveBrewery アーカイブされていない醸造所を取得する
+func (u *eventUsecase) getActiveBrewery(breweryID int) (*entity.Brewery, error) {
+	if breweryID <= 0 {
+		return nil, domainerr.Invalid("invalid brewery id")
+	}
+
+	brewery, err := u.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		return nil, err
+	}
+	if brewery.IsArchived() {
+		return nil, domainerr.ErrBreweryNotFound
+	}
+
+	return brewery, nil
+}
+
+// newEventBuilder 入力内容を設定した EventBuilder を作成する
+func newEventBuilder(input EventInput) *entity.EventBuilder {
+	return entity.NewEventBuilder().
+		WithTitle(input.Title).
+		WithDescription(input.Description).
+		WithLocation(input.Location).
+		WithSchedule(input.StartAt, input.EndAt, input.TimeZone).
+		WithCapacity(input.Capacity)
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"strconv"
+	"time"
+)
+
+// maxNearbyActivities 新しい醸造所の登録時にアクティビティを作成する近隣の醸造所の上限
+const maxNearbyActivities = 100
+
+// feedUsecase フィードユースケースの実装
+type feedUsecase struct {
+	activityRepo repository.BreweryActivityRepository
+	breweryRepo  repository.BreweryRepository
+	visitRepo    repository.VisitRepository
+}
+
+// FeedPage フィードの1ページ分
+type FeedPage struct {
+	Activities []*entity.BreweryActivity
+	NextCursor string // 次のページがない場合は空
+}
+
+// FeedUsecase フォローしている醸造所のフィードのビジネスロジックインターフェースを定義する
+type FeedUsecase interface {
+	GetFeed(userProfileID int, cursor string, limit int) (*FeedPage, error)
+	RecordBreweryUpdated(brewery *entity.Brewery) error
+	RecordBreweryAdded(brewery *entity.Brewery, radiusKm float64) error
+	RecordVisitMilestone(breweryID int) error
+}
+
+// NewFeedUsecase 新しいフィードユースケースを作成する
+func NewFeedUsecase(activityRepo repository.BreweryActivityRepository, breweryRepo repository.BreweryRepository, visitRepo repository.VisitRepository) FeedUsecase {
+	return &feedUsecase{
+		activityRepo: activityRepo,
+		breweryRepo:  breweryRepo,
+		visitRepo:    visitRepo,
+	}
+}
+
+// GetFeed フォローしている醸造所のアクティビティを新しい順に取得する
+// cursor には前のページの NextCursor を指定する（空の場合は最新から）
+func (u *feedUsecase) GetFeed(userProfileID int, cursor string, limit int) (*FeedPage, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+	beforeID, err := decodeFeedCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// 次のページの有無を判定するため1件多く取得する
+	activities, err := u.activityRepo.GetFeed(userProfileID, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &FeedPage{Activities: activities}
+	if len(activities) > limit {
+		page.Activities = activities[:limit]
+		page.NextCursor = encodeFeedCursor(activities[limit-1].ID())
+	}
+	return page, nil
+}
+
+// RecordBreweryUpdated 醸造所のプロフィール更新をフィードに掲載する
+// 短時間の連続した更新でフィードが埋まらないよう、醸造所ごとに1日（UTC）1件までとする
+func (u *feedUsecase) RecordBreweryUpdated(brewery *entity.Brewery) error {
+	activity, err := entity.NewBreweryActivityBuilder().
+		WithBreweryID(brewery.ID()).
+		WithType(entity.ActivityTypeBreweryUpdated).
+		WithDedupeKey(fmt.Sprintf("%s:%d:%s", entity.ActivityTypeBreweryUpdated, brewery.ID(), time.Now().UTC().Format("2006-01-02"))).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = u.activityRepo.Record([]*entity.BreweryActivity{activity})
+	return err
+}
+
+// RecordBreweryAdded 新しい醸造所の登録を、半径 radiusKm キロメートル以内の醸造所のフィードに掲載する
+func (u *feedUsecase) RecordBreweryAdded(brewery *entity.Brewery, radiusKm float64) error {
+	if radiusKm <= 0 {
+		return nil
+	}
+
+	nearbyBreweries, _, err := u.breweryRepo.GetByLocation(brewery.Latitude(), brewery.Longitude(), radiusKm*1000, nil, maxNearbyActivities+1, 0)
+	if err != nil {
+		return err
+	}
+
+	addedBreweryID := brewery.ID()
+	activities := make([]*entity.BreweryActivity, 0, len(nearbyBreweries))
+	for _, nearby := range nearbyBreweries {
+		if nearby.Brewery().ID() == addedBreweryID || len(activities) >= maxNearbyActivities {
+			continue
+		}
+		activity, err := entity.NewBreweryActivityBuilder().
+			WithBreweryID(nearby.Brewery().ID()).
+			WithType(entity.ActivityTypeNearbyBreweryAdded).
+			WithRelatedBreweryID(&addedBreweryID).
+			WithDedupeKey(fmt.Sprintf("%s:%d:%d", entity.ActivityTypeNearbyBreweryAdded, nearby.Brewery().ID(), addedBreweryID)).
+			Build()
+		if err != nil {
+			return err
+		}
+		activities = append(activities, activity)
+	}
+
+	_, err = u.activityRepo.Record(activities)
+	return err
+}
+
+// RecordVisitMilestone 醸造所の訪問数が節目に達していればフィードに掲載する
+// 同時のチェックインで節目の数をまたいでも掲載漏れがないよう、到達済みの最大の節目を毎回登録し、重複は重複防止キーで除く
+func (u *feedUsecase) RecordVisitMilestone(breweryID int) error {
+	visitCount, err := u.visitRepo.CountByBrewery(breweryID)
+	if err != nil {
+		return err
+	}
+
+	milestone := entity.ReachedVisitMilestone(visitCount)
+	if milestone == 0 {
+		return nil
+	}
+
+	activity, err := entity.NewBreweryActivityBuilder().
+		WithBreweryID(breweryID).
+		WithType(entity.ActivityTypeVisitMilestone).
+		WithMilestone(&milestone).
+		WithDedupeKey(fmt.Sprintf("%s:%d:%d", entity.ActivityTypeVisitMilestone, breweryID, milestone)).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = u.activityRepo.Record([]*entity.BreweryActivity{activity})
+	return err
+}
+
+// encodeFeedCursor アクティビティIDからカーソルを作成する
+func encodeFeedCursor(activityID int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(activityID)))
+}
+
+// decodeFeedCursor カーソルからアクティビティIDを取り出す（空の場合は 0）
+func decodeFeedCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, domainerr.ErrInvalidCursor
+	}
+	activityID, err := strconv.Atoi(string(decoded))
+	if err != nil || activityID <= 0 {
+		return 0, domainerr.ErrInvalidCursor
+	}
+	return activityID, nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+)
+
+// followUsecase 醸造所のフォローユースケースの実装
+type followUsecase struct {
+	followRepo  repository.BreweryFollowRepository
+	breweryRepo repository.BreweryRepository
+}
+
+// FollowUsecase 醸造所のフォローのビジネスロジックインターフェースを定義する
+type FollowUsecase interface {
+	GetFollowedBreweries(userProfileID int, limit, offset int) ([]*entity.Brewery, int, error)
+	FollowBrewery(userProfileID, breweryID int) (bool, error)
+	UnfollowBrewery(userProfileID, breweryID int) error
+}
+
+// NewFollowUsecase 新しいフォローユースケースを作成する
+func NewFollowUsecase(followRepo repository.BreweryFollowRepository, breweryRepo repository.BreweryRepository) FollowUsecase {
+	return &followUsecase{
+		followRepo:  followRepo,
+		breweryRepo: breweryRepo,
+	}
+}
+
+// GetFollowedBreweries フォローしている醸造所を取得する
+func (u *followUsecase) GetFollowedBreweries(userProfileID int, limit, offset int) ([]*entity.Brewery, int, error) {
+	if userProfileID <= 0 {
+		return nil, 0, domainerr.Invalid("invalid user profile id")
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.followRepo.GetFollowedBreweries(userProfileID, limit, offset)
+}
+
+// FollowBrewery 醸造所をフォローする（フォロー済みの場合は created が false）
+func (u *followUsecase) FollowBrewery(userProfileID, breweryID int) (bool, error) {
+	if userProfileID <= 0 || breweryID <= 0 {
+		return false, domainerr.Invalid("invalid user profile id or brewery id")
+	}
+
+	brewery, err := u.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		return false, err
+	}
+	if brewery.IsArchived() {
+		return false, domainerr.ErrBreweryNotFound
+	}
+
+	return u.followRepo.Follow(userProfileID, breweryID)
+}
+
+// UnfollowBrewery 醸造所のフォローを解除する
+func (u *followUsecase) UnfollowBrewery(userProfileID, breweryID int) error {
+	if userProfileID <= 0 || breweryID <= 0 {
+		return domainerr.Invalid("invalid user profile id or brewery id")
+	}
+
+	return u.followRepo.Unfollow(userProfileID, breweryID)
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/repository"
+)
+
+// normalizePageQuery ページ指定を検証し、件数・オフセットを既定の範囲に収める
+func normalizePageQuery(page repository.PageQuery) (repository.PageQuery, error) {
+	if page.After != nil && page.Before != nil {
+		return page, domainerr.ErrInvalidCursor
+	}
+
+	if page.Limit <= 0 {
+		page.Limit = 20
+	}
+	if page.Limit > 100 {
+		page.Limit = 100
+	}
+	if page.Offset < 0 {
+		page.Offset = 0
+	}
+	return page, nil
+}
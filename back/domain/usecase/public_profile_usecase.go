@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"strings"
+	"time"
+)
+
+// publicProfileRecentVisitsLimit 公開プロフィールに表示する最近の訪問の件数
+const publicProfileRecentVisitsLimit = 10
+
+// publicProfileUsecase 公開プロフィールのユースケースの実装
+type publicProfileUsecase struct {
+	userProfileRepo repository.UserProfileRepository
+	visitRepo       repository.VisitRepository
+}
+
+// PublicProfileUsecase 公開プロフィールのビジネスロジックインターフェースを定義する
+type PublicProfileUsecase interface {
+	GetPublicProfile(handle, viewerCognitoSub string) (*entity.PublicProfile, error)
+}
+
+// NewPublicProfileUsecase 新しい公開プロフィールのユースケースを作成する
+func NewPublicProfileUsecase(userProfileRepo repository.UserProfileRepository, visitRepo repository.VisitRepository) PublicProfileUsecase {
+	return &publicProfileUsecase{
+		userProfileRepo: userProfileRepo,
+		visitRepo:       visitRepo,
+	}
+}
+
+// GetPublicProfile ハンドルで公開プロフィールを取得する（viewerCognitoSub は未認証の場合は空文字列）
+// 非公開のプロフィールは本人以外には存在しないものとして扱う。本人は非公開でも他のユーザーからの見え方を確認できる
+// 訪問の公開を遅らせる設定の場合、まだ公開していない訪問は訪問数・最近の訪問のどちらにも含めない
+func (u *publicProfileUsecase) GetPublicProfile(handle, viewerCognitoSub string) (*entity.PublicProfile, error) {
+	handle = strings.ToLower(strings.TrimSpace(handle))
+	if handle == "" {
+		return nil, domainerr.ErrUserProfileNotFound
+	}
+
+	profile, err := u.userProfileRepo.GetByHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	if !profile.IsPubliclyVisible() && (viewerCognitoSub == "" || profile.CognitoSub() != viewerCognitoSub) {
+		return nil, domainerr.ErrUserProfileNotFound
+	}
+
+	cutoff := profile.Privacy().VisitPublishCutoff(time.Now())
+	visits, result, err := u.visitRepo.ListByUserProfile(profile.ID(), repository.VisitFilter{To: &cutoff}, repository.PageQuery{
+		Limit:        publicProfileRecentVisitsLimit,
+		IncludeTotal: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.PublicProfile{
+		Profile:      profile,
+		VisitCount:   *result.Total,
+		RecentVisits: visits,
+	}, nil
+}
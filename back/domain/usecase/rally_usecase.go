@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"strings"
+	"time"
+)
+
+// rallyUsecase スタンプラリーユースケースの実装
+type rallyUsecase struct {
+	rallyRepo          repository.RallyRepository
+	participantRepo    repository.RallyParticipantRepository
+	breweryRepo        repository.BreweryRepository
+	breweryManagerRepo repository.BreweryManagerRepository
+}
+
+// RallyInput スタンプラリーの登録・更新内容
+type RallyInput struct {
+	Title             string
+	Description       string
+	BreweryIDs        []int
+	StartAt           time.Time
+	EndAt             time.Time
+	RequiredCount     int
+	RewardDescription string
+}
+
+// RallyOrganizer スタンプラリーを編集・特典を引き換えるユーザー
+type RallyOrganizer struct {
+	UserProfileID    int
+	IsAdmin          bool // PF管理者は全てのスタンプラリーを編集できる
+	IsBreweryManager bool // 醸造所管理者グループに所属しているか
+}
+
+// RallyUsecase スタンプラリーのビジネスロジックインターフェースを定義する
+type RallyUsecase interface {
+	GetRally(id int) (*entity.Rally, error)
+	GetOpenRallies(limit, offset int) ([]*entity.Rally, int, error)
+	CreateRally(organizer RallyOrganizer, input RallyInput) (*entity.Rally, error)
+	UpdateRally(organizer RallyOrganizer, id int, input RallyInput) (*entity.Rally, error)
+	DeleteRally(organizer RallyOrganizer, id int) error
+	JoinRally(rallyID, userProfileID int) (*entity.RallyParticipant, bool, error)
+	GetProgress(rallyID, userProfileID int) (*entity.RallyParticipant, error)
+	RedeemReward(organizer RallyOrganizer, rallyID int, rewardCode string) (*entity.RallyParticipant, error)
+}
+
+// NewRallyUsecase 新しいスタンプラリーユースケースを作成する
+func NewRallyUsecase(rallyRepo repository.RallyRepository, participantRepo repository.RallyParticipantRepository, breweryRepo repository.BreweryRepository, breweryManagerRepo repository.BreweryManagerRepository) RallyUsecase {
+	return &rallyUsecase{
+		rallyRepo:          rallyRepo,
+		participantRepo:    participantRepo,
+		breweryRepo:        breweryRepo,
+		breweryManagerRepo: breweryManagerRepo,
+	}
+}
+
+// GetRally IDでスタンプラリーを取得する
+func (u *rallyUsecase) GetRally(id int) (*entity.Rally, error) {
+	if id <= 0 {
+		return nil, domainerr.Invalid("invalid rally id")
+	}
+
+	return u.rallyRepo.GetByID(id)
+}
+
+// GetOpenRallies 開催予定・開催中のスタンプラリーを開始日時の早い順に取得する
+func (u *rallyUsecase) GetOpenRallies(limit, offset int) ([]*entity.Rally, int, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.rallyRepo.GetOpen(time.Now(), limit, offset)
+}
+
+// CreateRally スタンプラリーを登録する
+// 醸造所管理者は、対象の醸造所を全て管理している場合のみ登録できる
+func (u *rallyUsecase) CreateRally(organizer RallyOrganizer, input RallyInput) (*entity.Rally, error) {
+	rally, err := newRallyBuilder(input).Build()
+	if err != nil {
+		return nil, err
+	}
+	if rally.HasEndedAt(time.Now()) {
+		return nil, domainerr.Invalid("end_at must be in the future")
+	}
+
+	if err := u.checkActiveBreweries(rally.BreweryIDs(), nil); err != nil {
+		return nil, err
+	}
+	if err := u.checkOrganizer(organizer, rally.BreweryIDs(), true); err != nil {
+		return nil, err
+	}
+
+	return u.rallyRepo.Create(rally)
+}
+
+// UpdateRally スタンプラリーの内容を全て置き換える
+// 醸造所管理者は、変更前後の対象醸造所を全て管理している場合のみ更新できる
+func (u *rallyUsecase) UpdateRally(organizer RallyOrganizer, id int, input RallyInput) (*entity.Rally, error) {
+	rally, err := u.GetRally(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// RallyBuilder でバリデーションを行う
+	updatedRally, err := newRallyBuilder(input).
+		WithID(rally.ID()).
+		WithCreatedAt(rally.CreatedAt()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	// 既に対象になっている醸造所はアーカイブされていても残せる
+	if err := u.checkActiveBreweries(updatedRally.BreweryIDs(), rally); err != nil {
+		return nil, err
+	}
+	breweryIDs := append([]int{}, rally.BreweryIDs()...)
+	breweryIDs = append(breweryIDs, updatedRally.BreweryIDs()...)
+	if err := u.checkOrganizer(organizer, breweryIDs, true); err != nil {
+		return nil, err
+	}
+
+	return u.rallyRepo.Update(updatedRally)
+}
+
+// DeleteRally スタンプラリーを削除する
+func (u *rallyUsecase) DeleteRally(organizer RallyOrganizer, id int) error {
+	rally, err := u.GetRally(id)
+	if err != nil {
+		return err
+	}
+	if err := u.checkOrganizer(organizer, rally.BreweryIDs(), true); err != nil {
+		return err
+	}
+
+	return u.rallyRepo.Delete(id)
+}
+
+// JoinRally スタンプラリーに参加する（参加済みの場合は既存の参加を返し、created は false）
+// スタンプは参加後のチェックインから押される
+func (u *rallyUsecase) JoinRally(rallyID, userProfileID int) (*entity.RallyParticipant, bool, error) {
+	if userProfileID <= 0 {
+		return nil, false, domainerr.Invalid("invalid user profile id")
+	}
+
+	rally, err := u.GetRally(rallyID)
+	if err != nil {
+		return nil, false, err
+	}
+	now := time.Now()
+	if rally.HasEndedAt(now) {
+		return nil, false, domainerr.ErrRallyClosed
+	}
+
+	return u.participantRepo.Join(rally.ID(), userProfileID, now)
+}
+
+// GetProgress スタンプラリーの進捗（獲得したスタンプ・達成状況・特典の引換コード）を取得する
+func (u *rallyUsecase) GetProgress(rallyID, userProfileID int) (*entity.RallyParticipant, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+	if _, err := u.GetRally(rallyID); err != nil {
+		return nil, err
+	}
+
+	return u.participantRepo.Get(rallyID, userProfileID)
+}
+
+// RedeemReward 達成特典の引換コードを引き換え済みにする
+// 醸造所管理者は、対象の醸造所のいずれかを管理している場合に引き換えられる
+func (u *rallyUsecase) RedeemReward(organizer RallyOrganizer, rallyID int, rewardCode string) (*entity.RallyParticipant, error) {
+	rewardCode = strings.ToUpper(strings.TrimSpace(rewardCode))
+	if rewardCode == "" {
+		return nil, domainerr.Invalid("reward_code is required")
+	}
+
+	rally, err := u.GetRally(rallyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkOrganizer(organizer, rally.BreweryIDs(), false); err != nil {
+		return nil, err
+	}
+
+	return u.participantRepo.RedeemReward(rally.ID(), rewardCode, time.Now())
+}
+
+// checkActiveBreweries 対象の醸造所が存在し、アーカイブされていないことを確認する
+// current を指定した場合、既に対象になっている醸造所は確認しない
+func (u *rallyUsecase) checkActiveBreweries(breweryIDs []int, current *entity.Rally) error {
+	for _, breweryID := range breweryIDs {
+		if current != nil && current.IncludesBrewery(breweryID) {
+			continue
+		}
+		brewery, err := u.breweryRepo.GetByID(breweryID)
+		if err != nil {
+			return err
+		}
+		if brewery.IsArchived() {
+			return domainerr.ErrBreweryNotFound
+		}
+	}
+	return nil
+}
+
+// checkOrganizer スタンプラリーを編集・特典を引き換える権限があるかどうかを確認する
+// requireAll が true の場合は全ての醸造所、false の場合はいずれかの醸造所を管理していれば許可する
+func (u *rallyUsecase) checkOrganizer(organizer RallyOrganizer, breweryIDs []int, requireAll bool) error {
+	if organizer.IsAdmin {
+		return nil
+	}
+	if !organizer.IsBreweryManager || organizer.UserProfileID <= 0 {
+		return domainerr.ErrNotRallyOrganizer
+	}
+
+	for _, breweryID := range breweryIDs {
+		isManager, err := u.breweryManagerRepo.Exists(organizer.UserProfileID, breweryID)
+		if err != nil {
+			return err
+		}
+		if isManager && !requireAll {
+			return nil
+		}
+		if !isManager && requireAll {
+			return domainerr.ErrNotRallyOrganizer
+		}
+	}
+
+	if requireAll {
+		return nil
+	}
+	return domainerr.ErrNotRallyOrganizer
+}
+
+// newRallyBuilder 入力内容を設定した RallyBuilder を作成する
+func newRallyBuilder(input RallyInput) *entity.RallyBuilder {
+	return entity.NewRallyBuilder().
+		WithTitle(strings.TrimSpace(input.Title)).
+		WithDescription(input.Description).
+		WithBreweryIDs(input.BreweryIDs).
+		WithPeriod(input.StartAt, input.EndAt).
+		WithRequiredCount(input.RequiredCount).
+		WithRewardDescription(input.RewardDescription)
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"time"
+)
+
+// minRecapYear 振り返りを取得できる最も古い年
+const minRecapYear = 2000
+
+// recapUsecase 年間の振り返りのユースケースの実装
+type recapUsecase struct {
+	recapRepo      repository.UserRecapRepository
+	visitStatsRepo repository.VisitStatsRepository
+}
+
+// RecapUsecase 年間の振り返りのビジネスロジックインターフェースを定義する
+type RecapUsecase interface {
+	GetRecap(userProfileID, year int, loc *time.Location) (*entity.UserRecap, error)
+	GenerateRecaps(year int, loc *time.Location, batchSize int) (int, error)
+}
+
+// NewRecapUsecase 新しい年間の振り返りのユースケースを作成する
+func NewRecapUsecase(recapRepo repository.UserRecapRepository, visitStatsRepo repository.VisitStatsRepository) RecapUsecase {
+	return &recapUsecase{
+		recapRepo:      recapRepo,
+		visitStatsRepo: visitStatsRepo,
+	}
+}
+
+// GetRecap ユーザーの年間の振り返りを取得する（年の区切りは loc のタイムゾーンで判定する）
+// 集計中の今年の振り返りは保存せずにその場で集計する。過去の年はバッチで集計済みの結果を返し、
+// 未集計の場合（バッチの実行前・バッチの対象外だったユーザー）はその場で集計して保存する
+func (u *recapUsecase) GetRecap(userProfileID, year int, loc *time.Location) (*entity.UserRecap, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+	if loc == nil {
+		return nil, domainerr.Invalid("time zone is required")
+	}
+
+	currentYear := time.Now().In(loc).Year()
+	if year < minRecapYear || year > currentYear {
+		return nil, domainerr.Invalid(fmt.Sprintf("year must be between %d and %d", minRecapYear, currentYear))
+	}
+
+	if year == currentYear {
+		recap, err := u.compute(userProfileID, year, loc)
+		if err != nil {
+			return nil, err
+		}
+		recap.Provisional = true
+		return recap, nil
+	}
+
+	recap, err := u.recapRepo.GetByUserProfileAndYear(userProfileID, year)
+	if err == nil {
+		return recap, nil
+	}
+	if !errors.Is(err, domainerr.ErrNotFound) {
+		return nil, err
+	}
+
+	recap, err = u.compute(userProfileID, year, loc)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.recapRepo.Save(recap); err != nil {
+		return nil, err
+	}
+	return recap, nil
+}
+
+// GenerateRecaps 終了した年の振り返りを、その年に訪問のある全ユーザーについて集計・保存する（バッチ用）
+// ユーザーを batchSize 件ずつ取得して集計する。既に集計済みの振り返りは置き換えるため、再実行しても結果は変わらない
+// 集計したユーザー数を返す（途中で失敗した場合は、それまでに集計したユーザー数とエラーを返す）
+func (u *recapUsecase) GenerateRecaps(year int, loc *time.Location, batchSize int) (int, error) {
+	if loc == nil {
+		return 0, domainerr.Invalid("time zone is required")
+	}
+	if year < minRecapYear || year >= time.Now().In(loc).Year() {
+		return 0, domainerr.Invalid("recaps can only be generated for years that have ended")
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	from, to := entity.RecapYearRange(year, loc)
+	generated, afterID := 0, 0
+	for {
+		ids, err := u.recapRepo.ListUserProfileIDsWithVisits(from, to, afterID, batchSize)
+		if err != nil {
+			return generated, err
+		}
+
+		for _, userProfileID := range ids {
+			recap, err := u.compute(userProfileID, year, loc)
+			if err != nil {
+				return generated, fmt.Errorf("failed to compute recap for user profile %d: %w", userProfileID, err)
+			}
+			if err := u.recapRepo.Save(recap); err != nil {
+				return generated, fmt.Errorf("failed to save recap for user profile %d: %w", userProfileID, err)
+			}
+			generated++
+		}
+
+		if len(ids) < batchSize {
+			return generated, nil
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+// compute 振り返りを集計する（醸造所間の最長の移動は連続する訪問の組み合わせから求める）
+func (u *recapUsecase) compute(userProfileID, year int, loc *time.Location) (*entity.UserRecap, error) {
+	from, to := entity.RecapYearRange(year, loc)
+
+	recap, err := u.recapRepo.Compute(userProfileID, year, loc.String(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if recap.TotalVisits > 1 {
+		transitions, err := u.visitStatsRepo.GetTransitions(userProfileID, &from, &to)
+		if err != nil {
+			return nil, err
+		}
+		recap.FarthestTrip = entity.FarthestTrip(transitions)
+	}
+
+	recap.GeneratedAt = time.Now()
+	return recap, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"errors"
+	"mybeerlog/domain/domainerr"
 	"mybeerlog/domain/entity"
 	"mybeerlog/domain/repository"
 )
@@ -14,8 +15,9 @@ type userProfileUsecase struct {
 // UserProfileUsecase ユーザープロファイルのビジネスロジックインターフェースを定義する
 type UserProfileUsecase interface {
 	GetProfile(cognitoSub string) (*entity.UserProfile, error)
-	CreateProfile(cognitoSub, displayName, iconURL string) (*entity.UserProfile, error)
-	UpdateProfile(cognitoSub, displayName, iconURL string) (*entity.UserProfile, error)
+	CreateProfile(cognitoSub, displayName, iconURL, handle string) (*entity.UserProfile, error)
+	UpdateProfile(cognitoSub, displayName, iconURL, handle string) (*entity.UserProfile, error)
+	UpdatePrivacy(cognitoSub string, public, hideVisitTimes bool, visitPublishDelayHours int) (*entity.UserProfile, error)
 }
 
 // NewUserProfileUsecase 新しいユーザープロファイルユースケースを作成する
@@ -28,38 +30,46 @@ func NewUserProfileUsecase(repo repository.UserProfileRepository) UserProfileUse
 // GetProfile ユーザープロファイルを取得する
 func (u *userProfileUsecase) GetProfile(cognitoSub string) (*entity.UserProfile, error) {
 	if cognitoSub == "" {
-		return nil, errors.New("cognito_sub is required")
+		return nil, domainerr.Invalid("cognito_sub is required")
 	}
 
 	return u.userProfileRepo.GetByCognitoSub(cognitoSub)
 }
 
-// CreateProfile ユーザープロファイルを作成する
-func (u *userProfileUsecase) CreateProfile(cognitoSub, displayName, iconURL string) (*entity.UserProfile, error) {
+// CreateProfile ユーザープロファイルを作成する（handle は省略可能）
+func (u *userProfileUsecase) CreateProfile(cognitoSub, displayName, iconURL, handle string) (*entity.UserProfile, error) {
 	if cognitoSub == "" {
-		return nil, errors.New("cognito_sub is required")
+		return nil, domainerr.Invalid("cognito_sub is required")
 	}
 
-	// 既存プロファイルチェック
-	existing, _ := u.userProfileRepo.GetByCognitoSub(cognitoSub)
+	// 既存プロファイルチェック（同時作成は一意制約違反として Create が検出する）
+	existing, err := u.userProfileRepo.GetByCognitoSub(cognitoSub)
+	if err != nil && !errors.Is(err, domainerr.ErrUserProfileNotFound) {
+		return nil, err
+	}
 	if existing != nil {
-		return nil, errors.New("profile already exists")
+		return nil, domainerr.ErrUserProfileAlreadyExists
 	}
 
 	profile, err := entity.NewUserProfileBuilder().
 		WithCognitoSub(cognitoSub).
 		WithDisplayName(displayName).
 		WithIconURL(iconURL).
+		WithHandle(handle).
 		Build()
 	if err != nil {
 		return nil, err
 	}
 
+	if err := u.ensureHandleAvailable(profile); err != nil {
+		return nil, err
+	}
+
 	return u.userProfileRepo.Create(profile)
 }
 
-// UpdateProfile ユーザープロファイルを更新する
-func (u *userProfileUsecase) UpdateProfile(cognitoSub, displayName, iconURL string) (*entity.UserProfile, error) {
+// UpdateProfile ユーザープロファイルを更新する（空の項目は変更しない）
+func (u *userProfileUsecase) UpdateProfile(cognitoSub, displayName, iconURL, handle string) (*entity.UserProfile, error) {
 	profile, err := u.userProfileRepo.GetByCognitoSub(cognitoSub)
 	if err != nil {
 		return nil, err
@@ -68,6 +78,7 @@ func (u *userProfileUsecase) UpdateProfile(cognitoSub, displayName, iconURL stri
 	// 新しいプロファイルエンティティを作成（イミュータブル）
 	newDisplayName := profile.DisplayName()
 	newIconURL := profile.IconURL()
+	newHandle := profile.Handle()
 
 	if displayName != "" {
 		newDisplayName = displayName
@@ -75,17 +86,74 @@ func (u *userProfileUsecase) UpdateProfile(cognitoSub, displayName, iconURL stri
 	if iconURL != "" {
 		newIconURL = iconURL
 	}
+	if handle != "" {
+		newHandle = handle
+	}
 
 	updatedProfile, err := entity.NewUserProfileBuilder().
 		WithID(profile.ID()).
 		WithCognitoSub(profile.CognitoSub()).
 		WithDisplayName(newDisplayName).
 		WithIconURL(newIconURL).
+		WithHandle(newHandle).
+		WithPrivacy(profile.Privacy()).
 		WithCreatedAt(profile.CreatedAt()).
 		Build()
 	if err != nil {
 		return nil, err
 	}
 
+	if err := u.ensureHandleAvailable(updatedProfile); err != nil {
+		return nil, err
+	}
+
 	return u.userProfileRepo.Update(updatedProfile)
 }
+
+// UpdatePrivacy 公開プロフィールのプライバシー設定を更新する（公開するにはハンドルの設定が必要）
+func (u *userProfileUsecase) UpdatePrivacy(cognitoSub string, public, hideVisitTimes bool, visitPublishDelayHours int) (*entity.UserProfile, error) {
+	profile, err := u.userProfileRepo.GetByCognitoSub(cognitoSub)
+	if err != nil {
+		return nil, err
+	}
+
+	privacy, err := entity.NewProfilePrivacy(public, hideVisitTimes, visitPublishDelayHours)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedProfile, err := entity.NewUserProfileBuilder().
+		WithID(profile.ID()).
+		WithCognitoSub(profile.CognitoSub()).
+		WithDisplayName(profile.DisplayName()).
+		WithIconURL(profile.IconURL()).
+		WithHandle(profile.Handle()).
+		WithPrivacy(privacy).
+		WithCreatedAt(profile.CreatedAt()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.userProfileRepo.Update(updatedProfile)
+}
+
+// ensureHandleAvailable ハンドルが他のユーザーに使用されていないことを確認する
+// 同時に同じハンドルを設定した場合は一意制約違反として Create / Update が検出する
+func (u *userProfileUsecase) ensureHandleAvailable(profile *entity.UserProfile) error {
+	if profile.Handle() == "" {
+		return nil
+	}
+
+	existing, err := u.userProfileRepo.GetByHandle(profile.Handle())
+	if err != nil {
+		if errors.Is(err, domainerr.ErrUserProfileNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID() != profile.ID() {
+		return domainerr.ErrHandleAlreadyTaken
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"time"
+)
+
+// mostVisitedBreweriesLimit 訪問履歴の集計に含める、最も訪問した醸造所の件数
+const mostVisitedBreweriesLimit = 5
+
+// visitStatsUsecase 訪問履歴の集計のユースケースの実装
+type visitStatsUsecase struct {
+	visitStatsRepo repository.VisitStatsRepository
+}
+
+// VisitStatsUsecase 訪問履歴の集計のビジネスロジックインターフェースを定義する
+type VisitStatsUsecase interface {
+	GetStats(userProfileID int, loc *time.Location) (*entity.VisitStats, error)
+}
+
+// NewVisitStatsUsecase 新しい訪問履歴の集計のユースケースを作成する
+func NewVisitStatsUsecase(visitStatsRepo repository.VisitStatsRepository) VisitStatsUsecase {
+	return &visitStatsUsecase{
+		visitStatsRepo: visitStatsRepo,
+	}
+}
+
+// GetStats ユーザーの訪問履歴を集計する（月・曜日・時間帯は loc のタイムゾーンで集計する）
+func (u *visitStatsUsecase) GetStats(userProfileID int, loc *time.Location) (*entity.VisitStats, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+	if loc == nil || loc.String() == "Local" {
+		return nil, domainerr.Invalid("time zone must be an IANA time zone name")
+	}
+
+	stats, err := u.visitStatsRepo.GetStats(userProfileID, loc.String(), mostVisitedBreweriesLimit)
+	if err != nil {
+		return nil, err
+	}
+	if stats.TotalVisits == 0 {
+		return stats, nil
+	}
+
+	transitions, err := u.visitStatsRepo.GetTransitions(userProfileID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	stats.TotalDistanceM = entity.TotalTravelDistance(transitions)
+
+	return stats, nil
+}
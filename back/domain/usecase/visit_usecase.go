@@ -2,103 +2,383 @@ package usecase
 
 import (
 	"errors"
+	"math"
+	"mybeerlog/domain/domainerr"
 	"mybeerlog/domain/entity"
 	"mybeerlog/domain/repository"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // visitUsecase 訪問ユースケースの実装
 type visitUsecase struct {
-	visitRepo   repository.VisitRepository
-	breweryRepo repository.BreweryRepository
+	visitRepo    repository.VisitRepository
+	breweryRepo  repository.BreweryRepository
+	wishlistRepo repository.WishlistRepository
+	rallyRepo    repository.RallyParticipantRepository
+	secretRepo   repository.CheckinSecretRepository
+	badgeUsecase BadgeUsecase
+	feedUsecase  FeedUsecase
+}
+
+// CheckInInput チェックインの入力値
+type CheckInInput struct {
+	UserProfileID int
+	BreweryID     int
+	Latitude      float64
+	Longitude     float64
+	AccuracyM     *float64 // 端末が報告したGPSの精度（メートル、任意）
+	ClientVersion string
+
+	// 不正チェックイン対策の設定
+	MaxDistance       float64       // チェックイン許可半径（メートル）
+	MaxAccuracyM      float64       // 許容するGPS精度の上限（メートル、0以下の場合は判定しない）
+	RequireAccuracy   bool          // GPS精度の送信を必須とするか
+	MaxTravelSpeedKmh float64       // 直前の訪問からの移動速度の上限（km/h、0以下の場合は判定しない）
+	Cooldown          time.Duration // 同一醸造所への再チェックインを禁止する期間（0以下の場合は制限しない）
+}
+
+// QRCheckInInput 醸造所に掲示されたQRコードによるチェックインの入力値
+type QRCheckInInput struct {
+	UserProfileID int
+	Token         string // QRコードから読み取ったトークン
+
+	// コードの検証と不正チェックイン対策の設定
+	Period            time.Duration // コードの切り替え間隔
+	AllowedSkew       int           // 切り替え直後でも受け付ける直前のコードの数（読み取りから送信までの遅延を許容する）
+	MaxTravelSpeedKmh float64       // 直前の訪問からの移動速度の上限（km/h、0以下の場合は判定しない）
+	Cooldown          time.Duration // 同一醸造所への再チェックインを禁止する期間（0以下の場合は制限しない）
+}
+
+// checkinCodeExpiredLookback 期限切れのコードと判定する（不正なコードと区別する）過去のコードの数
+const checkinCodeExpiredLookback = 10
+
+// maxBreweryNameQueryLength 訪問履歴の醸造所名での絞り込みに指定できる最大文字数
+const maxBreweryNameQueryLength = 100
+
+// CheckInResult チェックインの結果（作成された訪問と、新たに獲得したバッジ）
+// *Err は訪問の作成後の反映に失敗した場合のエラー（recordVisit を参照）
+type CheckInResult struct {
+	Visit     *entity.Visit
+	NewBadges []*entity.UserBadge
+	// BadgeErr バッジ付与に失敗した場合のエラー
+	BadgeErr error
+	// WishlistVisited 行きたいリストの醸造所を初めて訪問したかどうか
+	WishlistVisited bool
+	// WishlistErr 行きたいリストの更新に失敗した場合のエラー
+	WishlistErr error
+	// RallyStamps 新たにスタンプを押したスタンプラリーの進捗
+	RallyStamps []*entity.RallyParticipant
+	// RallyErr スタンプラリーのスタンプに失敗した場合のエラー
+	RallyErr error
+	// MilestoneErr 醸造所の訪問数の節目のフィードへの掲載に失敗した場合のエラー
+	MilestoneErr error
 }
 
 // VisitUsecase 訪問のビジネスロジックインターフェースを定義する
 type VisitUsecase interface {
-	CheckIn(userProfileID, breweryID int, lat, lng, maxDistance float64) (*entity.Visit, error)
-	GetVisitHistory(userProfileID int, breweryID *int, limit, offset int) ([]*entity.Visit, int, error)
+	CheckIn(input CheckInInput) (*CheckInResult, error)
+	CheckInWithQR(input QRCheckInInput) (*CheckInResult, error)
+	GetVisitHistory(userProfileID int, filter repository.VisitFilter, page repository.PageQuery) ([]*entity.Visit, *repository.PageResult, error)
 	GetVisit(id, userProfileID int) (*entity.Visit, error)
+	GetVisitForAudit(id int) (*entity.Visit, error)
 }
 
 // NewVisitUsecase 新しい訪問ユースケースを作成する
-func NewVisitUsecase(visitRepo repository.VisitRepository, breweryRepo repository.BreweryRepository) VisitUsecase {
+func NewVisitUsecase(visitRepo repository.VisitRepository, breweryRepo repository.BreweryRepository, wishlistRepo repository.WishlistRepository, rallyRepo repository.RallyParticipantRepository, secretRepo repository.CheckinSecretRepository, badgeUsecase BadgeUsecase, feedUsecase FeedUsecase) VisitUsecase {
 	return &visitUsecase{
-		visitRepo:   visitRepo,
-		breweryRepo: breweryRepo,
+		visitRepo:    visitRepo,
+		breweryRepo:  breweryRepo,
+		wishlistRepo: wishlistRepo,
+		rallyRepo:    rallyRepo,
+		secretRepo:   secretRepo,
+		badgeUsecase: badgeUsecase,
+		feedUsecase:  feedUsecase,
 	}
 }
 
 // CheckIn 醸造所にチェックインする
-func (v *visitUsecase) CheckIn(userProfileID, breweryID int, lat, lng, maxDistance float64) (*entity.Visit, error) {
+func (v *visitUsecase) CheckIn(input CheckInInput) (*CheckInResult, error) {
+	userProfileID, breweryID := input.UserProfileID, input.BreweryID
 	if userProfileID <= 0 || breweryID <= 0 {
-		return nil, errors.New("invalid user profile id or brewery id")
+		return nil, domainerr.Invalid("invalid user profile id or brewery id")
+	}
+	if input.MaxDistance <= 0 {
+		return nil, errors.New("max distance must be positive")
 	}
 
 	// 醸造所情報取得
 	brewery, err := v.breweryRepo.GetByID(breweryID)
 	if err != nil {
-		return nil, errors.New("brewery not found")
+		return nil, err
+	}
+	if brewery.IsArchived() {
+		return nil, domainerr.ErrBreweryNotFound
+	}
+
+	// GPS精度チェック
+	if err := checkLocationAccuracy(input); err != nil {
+		return nil, err
 	}
 
 	// GPS距離チェック
-	withinRange, err := brewery.IsWithinCheckinRange(lat, lng, maxDistance)
+	distance, err := brewery.DistanceFrom(input.Latitude, input.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	if distance > input.MaxDistance {
+		return nil, domainerr.ErrTooFarFromBrewery
+	}
+
+	// 訪問記録作成（監査用にGPSの証跡を保存する）
+	evidence, err := entity.NewCheckinEvidence(
+		input.Latitude,
+		input.Longitude,
+		input.AccuracyM,
+		distance,
+		input.MaxDistance,
+		input.ClientVersion,
+	)
 	if err != nil {
 		return nil, err
 	}
-	if !withinRange {
-		return nil, errors.New("too far from brewery for check-in")
+
+	visit, err := entity.NewVisitBuilder().
+		WithUserProfileID(userProfileID).
+		WithBreweryID(breweryID).
+		WithCheckinEvidence(evidence).
+		Build()
+	if err != nil {
+		return nil, err
 	}
 
-	// 重複チェックイン防止（1時間以内の同一醸造所チェックイン禁止）
-	recent, _, err := v.visitRepo.GetByUserProfileAndBrewery(userProfileID, breweryID, 1, 0)
-	if err == nil && len(recent) > 0 {
-		lastVisit := recent[0]
-		if time.Since(lastVisit.VisitedAt()) < time.Hour {
-			return nil, errors.New("already checked in within the last hour")
+	// 直前の訪問からの移動速度チェック（位置情報の偽装対策）
+	return v.recordVisit(visit, input.Cooldown,
+		impossibleTravelCheck(input.Latitude, input.Longitude, input.AccuracyM, input.MaxTravelSpeedKmh))
+}
+
+// CheckInWithQR 醸造所に掲示されたQRコードで醸造所にチェックインする
+// 位置情報の代わりにコードの有効性で来店を確認し、重複チェックインの防止やバッジの付与は GPS によるチェックインと同様に行う
+func (v *visitUsecase) CheckInWithQR(input QRCheckInInput) (*CheckInResult, error) {
+	userProfileID := input.UserProfileID
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+	if input.Period < time.Second {
+		return nil, errors.New("code period must be at least one second")
+	}
+
+	breweryID, code, err := entity.ParseCheckinToken(input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 醸造所情報取得（アーカイブ済みの醸造所のコードは受け付けない）
+	brewery, err := v.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrBreweryNotFound) {
+			return nil, domainerr.ErrInvalidCheckinCode
 		}
+		return nil, err
+	}
+	if brewery.IsArchived() {
+		return nil, domainerr.ErrInvalidCheckinCode
 	}
 
-	// 訪問記録作成
-	visit, err := entity.NewVisit(userProfileID, breweryID)
+	// コード検証（シークレット未発行の醸造所はコードを掲示していない）
+	secret, err := v.secretRepo.GetByBrewery(breweryID)
 	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, domainerr.ErrInvalidCheckinCode
+		}
 		return nil, err
 	}
+	skew := input.AllowedSkew
+	if skew < 0 {
+		skew = 0
+	}
+	offset, ok := secret.MatchStep(code, time.Now(), input.Period, max(skew, checkinCodeExpiredLookback))
+	if !ok {
+		return nil, domainerr.ErrInvalidCheckinCode
+	}
+	if offset > skew {
+		return nil, domainerr.ErrCheckinCodeExpired
+	}
 
-	createdVisit, err := v.visitRepo.Create(visit)
+	visit, err := entity.NewVisitBuilder().
+		WithUserProfileID(userProfileID).
+		WithBreweryID(breweryID).
+		WithCheckinMethod(entity.CheckinMethodQR).
+		Build()
 	if err != nil {
 		return nil, err
 	}
 
-	return createdVisit, nil
+	// 直前の訪問からの移動速度チェック（醸造所の位置にいたものとして判定する）
+	return v.recordVisit(visit, input.Cooldown,
+		impossibleTravelCheck(brewery.Latitude(), brewery.Longitude(), nil, input.MaxTravelSpeedKmh))
 }
 
-// GetVisitHistory 訪問履歴を取得する
-func (v *visitUsecase) GetVisitHistory(userProfileID int, breweryID *int, limit, offset int) ([]*entity.Visit, int, error) {
-	if userProfileID <= 0 {
-		return nil, 0, errors.New("invalid user profile id")
+// recordVisit 訪問を記録し、行きたいリスト・スタンプラリー・バッジ・フィードに反映する
+// 訪問の作成後の反映はベストエフォートとし、失敗してもチェックイン自体は成功として、エラーは結果に含めて返す
+func (v *visitUsecase) recordVisit(visit *entity.Visit, cooldown time.Duration, checkPrevious repository.PreviousVisitCheck) (*CheckInResult, error) {
+	userProfileID, breweryID := visit.UserProfileID(), visit.BreweryID()
+
+	// 重複チェックイン防止（同一醸造所へのクールダウン期間内のチェックインを禁止）と直前の訪問の検証
+	createdVisit, err := v.visitRepo.CreateWithCooldown(visit, cooldown, checkPrevious)
+	if err != nil {
+		return nil, err
 	}
 
-	if limit <= 0 {
-		limit = 20
+	result := &CheckInResult{
+		Visit:       createdVisit,
+		NewBadges:   []*entity.UserBadge{},
+		RallyStamps: []*entity.RallyParticipant{},
 	}
-	if limit > 100 {
-		limit = 100
+
+	// 行きたいリストに登録済みの醸造所であれば訪問済みにする
+	if v.wishlistRepo != nil {
+		result.WishlistVisited, result.WishlistErr = v.wishlistRepo.MarkVisited(userProfileID, breweryID, createdVisit.VisitedAt())
 	}
-	if offset < 0 {
-		offset = 0
+
+	// 参加中・開催期間中のスタンプラリーのスタンプを押す
+	if v.rallyRepo != nil {
+		stamps, err := v.rallyRepo.StampVisit(userProfileID, breweryID, createdVisit.ID(), createdVisit.VisitedAt())
+		if stamps != nil {
+			result.RallyStamps = stamps
+		}
+		result.RallyErr = err
 	}
 
-	if breweryID != nil && *breweryID > 0 {
-		return v.visitRepo.GetByUserProfileAndBrewery(userProfileID, *breweryID, limit, offset)
+	// バッジ付与（失敗したバッジは次回のチェックイン時に再評価する）
+	if v.badgeUsecase != nil {
+		visitID := createdVisit.ID()
+		badges, err := v.badgeUsecase.AwardBadges(userProfileID, &visitID)
+		if badges != nil {
+			// エラー時も付与済みのバッジは返す
+			result.NewBadges = badges
+		}
+		result.BadgeErr = err
+	}
+
+	// 醸造所の訪問数の節目をフィードに掲載する
+	if v.feedUsecase != nil {
+		result.MilestoneErr = v.feedUsecase.RecordVisitMilestone(breweryID)
+	}
+
+	return result, nil
+}
+
+// checkLocationAccuracy 端末が報告したGPS精度が許容範囲内かどうかを検証する
+func checkLocationAccuracy(input CheckInInput) error {
+	if input.AccuracyM == nil {
+		if input.RequireAccuracy {
+			return domainerr.ErrLocationAccuracyRequired
+		}
+		return nil
+	}
+
+	accuracy := *input.AccuracyM
+	if accuracy < 0 || math.IsNaN(accuracy) || math.IsInf(accuracy, 0) {
+		return domainerr.ErrLocationAccuracyInvalid
+	}
+	if input.MaxAccuracyM > 0 && accuracy > input.MaxAccuracyM {
+		return domainerr.ErrLocationAccuracyTooLow
+	}
+	return nil
+}
+
+// impossibleTravelCheck 直前の訪問地点からの移動速度が現実的かどうかを検証する関数を返す（maxSpeedKmh が0以下の場合は検証しない）
+// 双方のGPS精度分の誤差は移動距離から差し引いて判定する
+func impossibleTravelCheck(latitude, longitude float64, accuracyM *float64, maxSpeedKmh float64) repository.PreviousVisitCheck {
+	if maxSpeedKmh <= 0 {
+		return nil
+	}
+	return func(previous *entity.Visit) error {
+		return checkImpossibleTravel(previous, latitude, longitude, accuracyM, maxSpeedKmh)
+	}
+}
+
+// checkImpossibleTravel 直前の訪問から現在地点までの移動速度が maxSpeedKmh 以下かどうかを検証する
+func checkImpossibleTravel(previous *entity.Visit, latitude, longitude float64, accuracyM *float64, maxSpeedKmh float64) error {
+	prevLat, prevLng, ok := visitLocation(previous)
+	if !ok {
+		return nil
+	}
+
+	distance := entity.GreatCircleDistance(prevLat, prevLng, latitude, longitude)
+	if accuracyM != nil {
+		distance -= *accuracyM
+	}
+	if evidence := previous.CheckinEvidence(); evidence != nil && evidence.AccuracyM() != nil {
+		distance -= *evidence.AccuracyM()
+	}
+	if distance <= 0 {
+		return nil
+	}
+
+	// 同時刻の訪問でも距離があれば不正とみなせるよう、経過時間は最低1秒とする
+	elapsed := math.Max(time.Since(previous.VisitedAt()).Seconds(), 1)
+	speedKmh := distance / elapsed * 3.6
+	if speedKmh > maxSpeedKmh {
+		return domainerr.ErrImpossibleTravel
+	}
+	return nil
+}
+
+// visitLocation 訪問時の位置を取得する（GPS証跡がない訪問は醸造所の位置を使用する）
+func visitLocation(visit *entity.Visit) (float64, float64, bool) {
+	if evidence := visit.CheckinEvidence(); evidence != nil {
+		return evidence.Latitude(), evidence.Longitude(), true
+	}
+	if brewery := visit.Brewery(); brewery != nil {
+		return brewery.Latitude(), brewery.Longitude(), true
+	}
+	return 0, 0, false
+}
+
+// GetVisitHistory 訪問履歴を絞り込み条件に従って取得する（デフォルトは新しい順）
+func (v *visitUsecase) GetVisitHistory(userProfileID int, filter repository.VisitFilter, page repository.PageQuery) ([]*entity.Visit, *repository.PageResult, error) {
+	if userProfileID <= 0 {
+		return nil, nil, domainerr.Invalid("invalid user profile id")
+	}
+
+	filter, err := normalizeVisitFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err = normalizePageQuery(page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return v.visitRepo.ListByUserProfile(userProfileID, filter, page)
+}
+
+// normalizeVisitFilter 訪問履歴の絞り込み条件を検証する
+func normalizeVisitFilter(filter repository.VisitFilter) (repository.VisitFilter, error) {
+	if filter.BreweryID < 0 {
+		return filter, domainerr.Invalid("invalid brewery id")
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, domainerr.Invalid("from must be before to")
 	}
 
-	return v.visitRepo.GetByUserProfile(userProfileID, limit, offset)
+	filter.BreweryName = strings.TrimSpace(filter.BreweryName)
+	if utf8.RuneCountInString(filter.BreweryName) > maxBreweryNameQueryLength {
+		return filter, domainerr.Invalid("brewery name must be at most 100 characters")
+	}
+	if filter.Prefecture != "" && !entity.IsValidPrefecture(filter.Prefecture) {
+		return filter, domainerr.Invalid("invalid prefecture")
+	}
+	return filter, nil
 }
 
 // GetVisit 訪問を取得する
 func (v *visitUsecase) GetVisit(id int, userProfileID int) (*entity.Visit, error) {
 	if id <= 0 || userProfileID <= 0 {
-		return nil, errors.New("invalid visit id or user profile id")
+		return nil, domainerr.Invalid("invalid visit id or user profile id")
 	}
 
 	visit, err := v.visitRepo.GetByID(id)
@@ -108,8 +388,17 @@ func (v *visitUsecase) GetVisit(id int, userProfileID int) (*entity.Visit, error
 
 	// 自分の訪問履歴のみアクセス可能
 	if visit.UserProfileID() != userProfileID {
-		return nil, errors.New("access denied")
+		return nil, domainerr.ErrVisitAccessDenied
 	}
 
 	return visit, nil
 }
+
+// GetVisitForAudit 所有者を問わず訪問を取得する（管理者による監査用）
+func (v *visitUsecase) GetVisitForAudit(id int) (*entity.Visit, error) {
+	if id <= 0 {
+		return nil, domainerr.Invalid("invalid visit id")
+	}
+
+	return v.visitRepo.GetByID(id)
+}
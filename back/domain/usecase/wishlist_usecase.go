@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"mybeerlog/domain/domainerr"
+	"mybeerlog/domain/entity"
+	"mybeerlog/domain/repository"
+	"sort"
+)
+
+// wishlistUsecase 行きたいリストユースケースの実装
+type wishlistUsecase struct {
+	wishlistRepo repository.WishlistRepository
+	breweryRepo  repository.BreweryRepository
+}
+
+// WishlistUsecase 行きたいリストのビジネスロジックインターフェースを定義する
+type WishlistUsecase interface {
+	GetWishlist(userProfileID int, lat, lng *float64) ([]*entity.NearbyWishlistItem, error)
+	AddToWishlist(userProfileID, breweryID int) (*entity.WishlistItem, bool, error)
+	RemoveFromWishlist(userProfileID, breweryID int) error
+}
+
+// NewWishlistUsecase 新しい行きたいリストユースケースを作成する
+func NewWishlistUsecase(wishlistRepo repository.WishlistRepository, breweryRepo repository.BreweryRepository) WishlistUsecase {
+	return &wishlistUsecase{
+		wishlistRepo: wishlistRepo,
+		breweryRepo:  breweryRepo,
+	}
+}
+
+// GetWishlist 行きたいリストを取得する
+// lat と lng を指定した場合は各醸造所までの距離を求め、距離の近い順に返す
+func (u *wishlistUsecase) GetWishlist(userProfileID int, lat, lng *float64) ([]*entity.NearbyWishlistItem, error) {
+	if userProfileID <= 0 {
+		return nil, domainerr.Invalid("invalid user profile id")
+	}
+	withLocation := lat != nil && lng != nil
+	if withLocation && (*lat < -90 || *lat > 90 || *lng < -180 || *lng > 180) {
+		return nil, domainerr.ErrInvalidSearchRange
+	}
+
+	items, err := u.wishlistRepo.GetByUserProfile(userProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*entity.NearbyWishlistItem, len(items))
+	for i, item := range items {
+		var distanceM *float64
+		if withLocation && item.Brewery() != nil {
+			distance, err := item.Brewery().DistanceFrom(*lat, *lng)
+			if err != nil {
+				return nil, err
+			}
+			distanceM = &distance
+		}
+		results[i] = entity.NewNearbyWishlistItem(item, distanceM)
+	}
+
+	if withLocation {
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].DistanceM() == nil || results[j].DistanceM() == nil {
+				return results[j].DistanceM() == nil && results[i].DistanceM() != nil
+			}
+			return *results[i].DistanceM() < *results[j].DistanceM()
+		})
+	}
+
+	return results, nil
+}
+
+// AddToWishlist 醸造所を行きたいリストに登録する（登録済みの場合は既存の登録を返し、created は false）
+func (u *wishlistUsecase) AddToWishlist(userProfileID, breweryID int) (*entity.WishlistItem, bool, error) {
+	if userProfileID <= 0 || breweryID <= 0 {
+		return nil, false, domainerr.Invalid("invalid user profile id or brewery id")
+	}
+
+	brewery, err := u.breweryRepo.GetByID(breweryID)
+	if err != nil {
+		return nil, false, err
+	}
+	if brewery.IsArchived() {
+		return nil, false, domainerr.ErrBreweryNotFound
+	}
+
+	return u.wishlistRepo.Add(userProfileID, breweryID)
+}
+
+// RemoveFromWishlist 醸造所を行きたいリストから削除する
+func (u *wishlistUsecase) RemoveFromWishlist(userProfileID, breweryID int) error {
+	if userProfileID <= 0 || breweryID <= 0 {
+		return domainerr.Invalid("invalid user profile id or brewery id")
+	}
+
+	return u.wishlistRepo.Remove(userProfileID, breweryID)
+}
@@ -6,8 +6,15 @@ CREATE TABLE user_profile (
     cognito_sub VARCHAR(255) UNIQUE NOT NULL,
     display_name VARCHAR(255),
     icon_url VARCHAR(512),
+    -- 公開プロフィールのハンドル（/users/{handle}、未設定の場合は NULL）
+    handle VARCHAR(30) UNIQUE CHECK (handle ~ '^[a-z0-9_]{3,30}$'),
+    -- 公開プロフィールのプライバシー設定
+    profile_public BOOLEAN NOT NULL DEFAULT FALSE,
+    hide_visit_times BOOLEAN NOT NULL DEFAULT FALSE,
+    visit_publish_delay_hours INTEGER NOT NULL DEFAULT 0 CHECK (visit_publish_delay_hours BETWEEN 0 AND 168),
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (NOT profile_public OR handle IS NOT NULL)
 );
 
 -- 醸造所テーブル
@@ -15,24 +22,333 @@ CREATE TABLE brewery (
     id SERIAL PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
     address VARCHAR(512),
+    prefecture VARCHAR(10), -- 都道府県（住所から抽出）
     description TEXT,
     latitude DECIMAL(10,7) NOT NULL,
     longitude DECIMAL(10,7) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active', -- active / archived
+    deleted_at TIMESTAMP, -- アーカイブ（論理削除）日時
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
 -- 訪問テーブル
+-- 醸造所は論理削除のみ行うため、訪問履歴を失わないよう物理削除は禁止する
 CREATE TABLE visit (
     id SERIAL PRIMARY KEY,
     user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
-    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE RESTRICT,
+    -- チェックイン方法（gps: 位置情報 / qr: 醸造所に掲示されたQRコード）
+    checkin_method VARCHAR(10) NOT NULL DEFAULT 'gps' CHECK (checkin_method IN ('gps', 'qr')),
+    -- チェックイン時のGPS証跡（監査・許可半径の調整用、QRコードによるチェックインは NULL）
+    checkin_latitude DECIMAL(10,7),
+    checkin_longitude DECIMAL(10,7),
+    gps_accuracy_m DOUBLE PRECISION, -- 端末が報告した精度
+    distance_m DOUBLE PRECISION, -- 醸造所までの距離
+    checkin_radius_m DOUBLE PRECISION, -- 適用した許可半径
+    client_version VARCHAR(100),
     visited_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
+-- 醸造所管理者テーブル（ユーザーと管理対象醸造所の紐付け）
+CREATE TABLE brewery_manager (
+    id SERIAL PRIMARY KEY,
+    user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_profile_id, brewery_id)
+);
+
+-- ビアスタイルテーブル（ファミリー → スタイル → サブスタイルの分類）
+-- データは back/data/beer_styles.json から投入される（ローカル開発環境は起動時に投入し、style.seed_on_startup = false で無効にできる。
+-- Lambda 環境は起動時には投入せず、BATCH_JOB=seed_beer_styles のバッチジョブで投入する）
+CREATE TABLE beer_style (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    parent_id INTEGER REFERENCES beer_style(id) ON DELETE SET NULL,
+    name_ja VARCHAR(100) NOT NULL,
+    name_en VARCHAR(100) NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+-- 醸造所スタイルテーブル（醸造所が扱うビアスタイルの紐付け）
+CREATE TABLE brewery_style (
+    id SERIAL PRIMARY KEY,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    beer_style_id INTEGER NOT NULL REFERENCES beer_style(id) ON DELETE CASCADE,
+    UNIQUE (brewery_id, beer_style_id)
+);
+
+-- ビールテーブル（醸造所が醸造するビールのカタログ）
+CREATE TABLE beer (
+    id SERIAL PRIMARY KEY,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE RESTRICT,
+    name VARCHAR(255) NOT NULL,
+    style VARCHAR(100),
+    abv DECIMAL(5,2), -- アルコール度数（%）
+    ibu INTEGER, -- 国際苦味単位
+    description TEXT,
+    is_seasonal BOOLEAN NOT NULL DEFAULT FALSE,
+    is_limited BOOLEAN NOT NULL DEFAULT FALSE,
+    available_from DATE, -- 提供開始日（NULL は期限なし）
+    available_until DATE, -- 提供終了日（NULL は期限なし）
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (available_until IS NULL OR available_from IS NULL OR available_until >= available_from)
+);
+
+-- 飲んだビールの記録テーブル（訪問中に飲んだビールの評価・テイスティングノート）
+-- serving_type: draft / bottle / can / cask / flight / other
+CREATE TABLE beer_log (
+    id SERIAL PRIMARY KEY,
+    visit_id INTEGER NOT NULL REFERENCES visit(id) ON DELETE CASCADE,
+    beer_id INTEGER REFERENCES beer(id) ON DELETE SET NULL, -- カタログにないビールは NULL
+    beer_name VARCHAR(255) NOT NULL, -- カタログのビールの場合も記録時の名前を保持する
+    serving_type VARCHAR(20),
+    rating DECIMAL(3,2) CHECK (rating IS NULL OR (rating >= 0 AND rating <= 5)), -- 0.25 刻み
+    notes TEXT,
+    flavor_tags VARCHAR(512), -- カンマ区切り
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- 行きたいリストテーブル
+CREATE TABLE wishlist_item (
+    id SERIAL PRIMARY KEY,
+    user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    visited_at TIMESTAMP, -- 登録後に初めてチェックインした日時（未訪問は NULL）
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_profile_id, brewery_id)
+);
+
+-- 醸造所フォローテーブル
+CREATE TABLE brewery_follow (
+    id SERIAL PRIMARY KEY,
+    user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_profile_id, brewery_id)
+);
+
+-- 醸造所アクティビティテーブル（フォローしている醸造所のフィードに掲載する）
+-- activity_type: brewery_updated / visit_milestone / nearby_brewery_added
+CREATE TABLE brewery_activity (
+    id SERIAL PRIMARY KEY,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    activity_type VARCHAR(30) NOT NULL,
+    related_brewery_id INTEGER REFERENCES brewery(id) ON DELETE CASCADE, -- nearby_brewery_added の新しい醸造所
+    milestone INTEGER, -- visit_milestone の訪問数
+    dedupe_key VARCHAR(100) UNIQUE, -- 同じ出来事の二重掲載を防ぐ
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- 醸造所の投稿テーブル（本文は Markdown、publish_at が未来の投稿は予約投稿）
+-- visibility: public / followers / fan_rank
+CREATE TABLE brewery_post (
+    id SERIAL PRIMARY KEY,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES user_profile(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    publish_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    is_pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    min_fan_rank VARCHAR(20), -- visibility が fan_rank の場合に必要なファンランク（visitor / regular / loyal）
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- 投稿画像テーブル
+CREATE TABLE brewery_post_image (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES brewery_post(id) ON DELETE CASCADE,
+    url VARCHAR(512) NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+-- イベントテーブル（開催日時は UTC で保存し、time_zone は表示・カレンダー用の IANA タイムゾーン名）
+CREATE TABLE event (
+    id SERIAL PRIMARY KEY,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    location VARCHAR(255), -- 会場の補足（空の場合は醸造所）
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP NOT NULL,
+    time_zone VARCHAR(64) NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (end_at > start_at)
+);
+
+-- イベント参加申込テーブル
+-- status: going（参加確定） / waitlisted（キャンセル待ち、id の順に繰り上がる）
+CREATE TABLE event_rsvp (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES event(id) ON DELETE CASCADE,
+    user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    attended_at TIMESTAMP, -- イベント中の GPS チェックインで参加が確認された日時
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, user_profile_id)
+);
+
+-- スタンプラリーテーブル（開催期間中に対象醸造所のうち required_count か所でチェックインすると達成）
+CREATE TABLE rally (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP NOT NULL,
+    required_count INTEGER NOT NULL CHECK (required_count > 0),
+    reward_description TEXT, -- 達成特典の内容
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (end_at > start_at)
+);
+
+-- スタンプラリーの対象醸造所テーブル
+CREATE TABLE rally_brewery (
+    id SERIAL PRIMARY KEY,
+    rally_id INTEGER NOT NULL REFERENCES rally(id) ON DELETE CASCADE,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    UNIQUE (rally_id, brewery_id)
+);
+
+-- スタンプラリーの参加者テーブル
+CREATE TABLE rally_participant (
+    id SERIAL PRIMARY KEY,
+    rally_id INTEGER NOT NULL REFERENCES rally(id) ON DELETE CASCADE,
+    user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP, -- 必要な数のスタンプが揃った日時
+    reward_code VARCHAR(16) UNIQUE, -- 達成時に発行する特典の引換コード
+    reward_redeemed_at TIMESTAMP, -- 醸造所で特典を引き換えた日時
+    UNIQUE (rally_id, user_profile_id)
+);
+
+-- スタンプラリーのスタンプテーブル（醸造所ごとに1つ）
+CREATE TABLE rally_stamp (
+    id SERIAL PRIMARY KEY,
+    rally_id INTEGER NOT NULL REFERENCES rally(id) ON DELETE CASCADE,
+    user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
+    brewery_id INTEGER NOT NULL REFERENCES brewery(id) ON DELETE CASCADE,
+    visit_id INTEGER REFERENCES visit(id) ON DELETE SET NULL, -- スタンプを押したチェックイン
+    stamped_at TIMESTAMP NOT NULL,
+    UNIQUE (rally_id, user_profile_id, brewery_id)
+);
+
+-- QRチェックイン用シークレットテーブル（醸造所に掲示するコードはこのシークレットから一定時間ごとに算出する）
+CREATE TABLE brewery_checkin_secret (
+    id SERIAL PRIMARY KEY,
+    brewery_id INTEGER NOT NULL UNIQUE REFERENCES brewery(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    rotated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- カレンダー購読設定テーブル（購読URLのトークンは SHA-256 のハッシュのみ保存する）
+CREATE TABLE calendar_feed (
+    id SERIAL PRIMARY KEY,
+    user_profile_id INTEGER NOT NULL UNIQUE REFERENCES user_profile(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    rotated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- バッジ定義テーブル（獲得条件をデータとして保持する）
+-- rule_type: total_visits / distinct_breweries / same_brewery_visits / prefecture_complete
+-- データは back/data/badges.json から投入される（ローカル開発環境は起動時に投入し、badge.seed_on_startup = false で無効にできる。
+-- Lambda 環境は起動時には投入せず、BATCH_JOB=seed_badges のバッチジョブで投入する）
+CREATE TABLE badge (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    rule_type VARCHAR(50) NOT NULL,
+    threshold INTEGER NOT NULL DEFAULT 1,
+    prefecture VARCHAR(10), -- prefecture_complete で対象を限定する場合のみ
+    icon_url VARCHAR(512),
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- 獲得バッジテーブル
+CREATE TABLE user_badge (
+    id SERIAL PRIMARY KEY,
+    user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
+    badge_id INTEGER NOT NULL REFERENCES badge(id) ON DELETE CASCADE,
+    visit_id INTEGER REFERENCES visit(id) ON DELETE SET NULL, -- 獲得のきっかけとなった訪問
+    awarded_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_profile_id, badge_id)
+);
+
+-- 年間の振り返りテーブル（年明けにバッチで集計した前年の結果を保存する。年の区切りは visit.default_time_zone で判定）
+CREATE TABLE user_recap (
+    id SERIAL PRIMARY KEY,
+    user_profile_id INTEGER NOT NULL REFERENCES user_profile(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,
+    total_visits INTEGER NOT NULL DEFAULT 0,
+    breweries_visited INTEGER NOT NULL DEFAULT 0,
+    new_breweries INTEGER NOT NULL DEFAULT 0, -- その年に初めて訪問した醸造所の数
+    prefectures_visited INTEGER NOT NULL DEFAULT 0,
+    busiest_month INTEGER NOT NULL DEFAULT 0 CHECK (busiest_month BETWEEN 0 AND 12), -- 訪問がない場合は0
+    busiest_month_visits INTEGER NOT NULL DEFAULT 0,
+    favorite_brewery_id INTEGER REFERENCES brewery(id) ON DELETE SET NULL, -- 最も訪問した醸造所
+    favorite_brewery_visits INTEGER NOT NULL DEFAULT 0,
+    farthest_from_brewery_id INTEGER REFERENCES brewery(id) ON DELETE SET NULL, -- 連続する訪問での醸造所間の最長の移動
+    farthest_to_brewery_id INTEGER REFERENCES brewery(id) ON DELETE SET NULL,
+    farthest_distance_m DOUBLE PRECISION NOT NULL DEFAULT 0,
+    longest_week_streak INTEGER NOT NULL DEFAULT 0,
+    badges_earned INTEGER NOT NULL DEFAULT 0,
+    generated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_profile_id, year)
+);
+
+-- 冪等キーテーブル（Idempotency-Key 付きPOSTリクエストのレスポンスを保存する）
+-- status: processing / completed
+CREATE TABLE idempotency_key (
+    id SERIAL PRIMARY KEY,
+    user_sub VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL, -- メソッド・パス・本文の SHA-256
+    status VARCHAR(20) NOT NULL,
+    response_status INTEGER,
+    response_body TEXT,
+    response_content_type VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    UNIQUE (user_sub, idempotency_key)
+);
+
 -- インデックス作成
 CREATE INDEX idx_user_profile_cognito_sub ON user_profile(cognito_sub);
 CREATE INDEX idx_brewery_location ON brewery(latitude, longitude);
 CREATE INDEX idx_visit_user_profile_id ON visit(user_profile_id);
 CREATE INDEX idx_visit_brewery_id ON visit(brewery_id);
-CREATE INDEX idx_visit_visited_at ON visit(visited_at DESC);
\ No newline at end of file
+CREATE INDEX idx_visit_visited_at ON visit(visited_at DESC);
+CREATE INDEX idx_brewery_manager_brewery_id ON brewery_manager(brewery_id);
+CREATE INDEX idx_brewery_prefecture ON brewery(prefecture);
+CREATE INDEX idx_beer_style_parent_id ON beer_style(parent_id);
+CREATE INDEX idx_brewery_style_beer_style_id ON brewery_style(beer_style_id);
+CREATE INDEX idx_beer_brewery_id ON beer(brewery_id);
+CREATE INDEX idx_beer_log_visit_id ON beer_log(visit_id);
+CREATE INDEX idx_beer_log_beer_id ON beer_log(beer_id);
+CREATE INDEX idx_wishlist_item_brewery_id ON wishlist_item(brewery_id);
+CREATE INDEX idx_brewery_follow_brewery_id ON brewery_follow(brewery_id);
+CREATE INDEX idx_brewery_activity_brewery_id_id ON brewery_activity(brewery_id, id DESC); -- フィードの取得（醸造所ごとの新しい順）
+CREATE INDEX idx_brewery_activity_related_brewery_id ON brewery_activity(related_brewery_id);
+CREATE INDEX idx_brewery_post_brewery_id ON brewery_post(brewery_id, is_pinned DESC, publish_at DESC); -- 投稿一覧の取得（固定表示・新しい順）
+CREATE INDEX idx_brewery_post_image_post_id ON brewery_post_image(post_id);
+CREATE INDEX idx_event_brewery_id ON event(brewery_id, start_at);
+CREATE INDEX idx_event_rsvp_event_status ON event_rsvp(event_id, status, id); -- 定員の確認・キャンセル待ちの繰り上げ
+CREATE INDEX idx_event_rsvp_user_profile_id ON event_rsvp(user_profile_id);
+CREATE INDEX idx_rally_end_at ON rally(end_at, start_at);
+CREATE INDEX idx_rally_brewery_brewery_id ON rally_brewery(brewery_id); -- チェックイン時の対象スタンプラリーの検索
+CREATE INDEX idx_rally_participant_user_profile_id ON rally_participant(user_profile_id);
+CREATE INDEX idx_visit_user_profile_visited_at ON visit(user_profile_id, visited_at DESC, id DESC); -- 訪問履歴のキーセットページング
+CREATE INDEX idx_brewery_created_at_id ON brewery(created_at DESC, id DESC); -- 醸造所一覧のキーセットページング
+CREATE INDEX idx_visit_user_profile_brewery ON visit(user_profile_id, brewery_id);
+CREATE INDEX idx_idempotency_key_expires_at ON idempotency_key(expires_at);
+CREATE INDEX idx_user_badge_user_profile_awarded_at ON user_badge(user_profile_id, awarded_at); -- 年間の振り返りでの獲得バッジ数の集計
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type BadgeResponse struct {
+	ID          int    `json:"id"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	RuleType    string `json:"rule_type"`
+	Threshold   int    `json:"threshold"`
+	Prefecture  string `json:"prefecture,omitempty"`
+	IconURL     string `json:"icon_url,omitempty"`
+}
+
+type UserBadgeResponse struct {
+	ID        int            `json:"id"`
+	Badge     *BadgeResponse `json:"badge"`
+	VisitID   *int           `json:"visit_id,omitempty"`
+	AwardedAt time.Time      `json:"awarded_at"`
+}
+
+type UserBadgesResponse struct {
+	Badges []*UserBadgeResponse `json:"badges"`
+	Total  int                  `json:"total"`
+}
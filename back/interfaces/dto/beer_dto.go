@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+type BeerResponse struct {
+	ID             int                    `json:"id"`
+	BreweryID      int                    `json:"brewery_id"`
+	Brewery        *BreweryPublicResponse `json:"brewery,omitempty"`
+	Name           string                 `json:"name"`
+	Style          string                 `json:"style"`
+	ABV            *float64               `json:"abv"`
+	IBU            *int                   `json:"ibu"`
+	Description    string                 `json:"description"`
+	IsSeasonal     bool                   `json:"is_seasonal"`
+	IsLimited      bool                   `json:"is_limited"`
+	AvailableFrom  *string                `json:"available_from"`
+	AvailableUntil *string                `json:"available_until"`
+	IsAvailable    bool                   `json:"is_available"`
+	AverageRating  *float64               `json:"average_rating"`
+	RatingCount    int                    `json:"rating_count"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// ビール登録リクエスト。提供期間は YYYY-MM-DD 形式で指定する
+type BeerRequest struct {
+	Name           string   `json:"name" valid:"Required"`
+	Style          string   `json:"style"`
+	ABV            *float64 `json:"abv"`
+	IBU            *int     `json:"ibu"`
+	Description    string   `json:"description"`
+	IsSeasonal     bool     `json:"is_seasonal"`
+	IsLimited      bool     `json:"is_limited"`
+	AvailableFrom  *string  `json:"available_from"`
+	AvailableUntil *string  `json:"available_until"`
+}
+
+// ビール更新（PUT）リクエスト。updated_at は楽観的排他制御に使用する
+type BeerUpdateRequest struct {
+	BeerRequest
+	UpdatedAt time.Time `json:"updated_at" valid:"Required"`
+}
+
+type BeersResponse struct {
+	Beers []*BeerResponse `json:"beers"`
+	Total int             `json:"total"`
+}
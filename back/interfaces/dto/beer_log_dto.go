@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type BeerLogResponse struct {
+	ID          int       `json:"id"`
+	VisitID     int       `json:"visit_id"`
+	BeerID      *int      `json:"beer_id"`
+	BeerName    string    `json:"beer_name"`
+	ServingType string    `json:"serving_type,omitempty"`
+	Rating      *float64  `json:"rating"`
+	Notes       string    `json:"notes"`
+	FlavorTags  []string  `json:"flavor_tags"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ビールの記録リクエスト。beer_id（カタログのビール）か beer_name（カタログにないビール）のいずれかを指定する
+type BeerLogRequest struct {
+	BeerID      *int     `json:"beer_id"`
+	BeerName    string   `json:"beer_name"`
+	ServingType string   `json:"serving_type"`
+	Rating      *float64 `json:"rating"`
+	Notes       string   `json:"notes"`
+	FlavorTags  []string `json:"flavor_tags"`
+}
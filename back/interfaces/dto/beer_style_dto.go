@@ -0,0 +1,25 @@
+package dto
+
+type BeerStyleResponse struct {
+	ID       int                  `json:"id"`
+	Code     string               `json:"code"`
+	ParentID *int                 `json:"parent_id"`
+	Level    string               `json:"level,omitempty"`
+	NameJa   string               `json:"name_ja"`
+	NameEn   string               `json:"name_en"`
+	Children []*BeerStyleResponse `json:"children,omitempty"`
+}
+
+type BeerStylesResponse struct {
+	Styles []*BeerStyleResponse `json:"styles"`
+}
+
+type BreweryStylesResponse struct {
+	BreweryID int                  `json:"brewery_id"`
+	Styles    []*BeerStyleResponse `json:"styles"`
+}
+
+// 醸造所のスタイル更新リクエスト。スタイルはコードで指定し、既存の紐付けを置き換える
+type BreweryStylesRequest struct {
+	Styles []string `json:"styles"`
+}
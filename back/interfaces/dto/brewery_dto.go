@@ -3,14 +3,19 @@ package dto
 import "time"
 
 type BreweryResponse struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Address     string    `json:"address"`
-	Description string    `json:"description"`
-	Latitude    float64   `json:"latitude"`
-	Longitude   float64   `json:"longitude"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            int        `json:"id"`
+	Name          string     `json:"name"`
+	Address       string     `json:"address"`
+	Prefecture    string     `json:"prefecture"`
+	Description   string     `json:"description"`
+	Latitude      float64    `json:"latitude"`
+	Longitude     float64    `json:"longitude"`
+	Status        string     `json:"status"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	DistanceM     *float64   `json:"distance_m,omitempty"`
+	WantToGoCount *int       `json:"want_to_go_count,omitempty"` // 行きたいリストに登録したユーザー数
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 type BreweryRequest struct {
@@ -21,17 +26,41 @@ type BreweryRequest struct {
 	Longitude   float64 `json:"longitude" valid:"Required"`
 }
 
+// 醸造所更新（PUT）リクエスト。updated_at は楽観的排他制御に使用する
+type BreweryUpdateRequest struct {
+	Name        string    `json:"name" valid:"Required"`
+	Address     string    `json:"address"`
+	Description string    `json:"description"`
+	Latitude    float64   `json:"latitude" valid:"Required"`
+	Longitude   float64   `json:"longitude" valid:"Required"`
+	UpdatedAt   time.Time `json:"updated_at" valid:"Required"`
+}
+
+// 醸造所部分更新（PATCH）リクエスト。省略した項目は変更しない
+type BreweryPatchRequest struct {
+	Name        *string   `json:"name"`
+	Address     *string   `json:"address"`
+	Description *string   `json:"description"`
+	Latitude    *float64  `json:"latitude"`
+	Longitude   *float64  `json:"longitude"`
+	UpdatedAt   time.Time `json:"updated_at" valid:"Required"`
+}
+
 type BreweriesResponse struct {
-	Breweries []*BreweryResponse `json:"breweries"`
-	Total     int                `json:"total"`
+	Breweries  []*BreweryResponse `json:"breweries"`
+	Total      *int               `json:"total,omitempty"`       // include_total の場合のみ（cursor を指定しない場合の既定）
+	NextCursor string             `json:"next_cursor,omitempty"` // 次のページがない場合は省略（位置情報による検索は offset でページングする）
+	PrevCursor string             `json:"prev_cursor,omitempty"` // 前のページがない場合は省略
 }
 
 // ゲスト用のレスポンス（位置情報を除く）
 type BreweryPublicResponse struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Address     string    `json:"address"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	Address       string    `json:"address"`
+	Prefecture    string    `json:"prefecture"`
+	Description   string    `json:"description"`
+	WantToGoCount *int      `json:"want_to_go_count,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
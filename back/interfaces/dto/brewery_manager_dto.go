@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type BreweryManagerRequest struct {
+	UserProfileID int `json:"user_profile_id" valid:"Required"`
+}
+
+type BreweryManagerResponse struct {
+	ID            int                  `json:"id"`
+	BreweryID     int                  `json:"brewery_id"`
+	UserProfileID int                  `json:"user_profile_id"`
+	UserProfile   *UserProfileResponse `json:"user_profile,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+type BreweryManagersResponse struct {
+	Managers []*BreweryManagerResponse `json:"managers"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// 醸造所の投稿。body は Markdown の原文、body_html はサニタイズ済みの HTML
+type BreweryPostResponse struct {
+	ID         int       `json:"id"`
+	BreweryID  int       `json:"brewery_id"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	BodyHTML   string    `json:"body_html"`
+	ImageURLs  []string  `json:"image_urls"`
+	PublishAt  time.Time `json:"publish_at"`
+	Published  bool      `json:"published"`
+	IsPinned   bool      `json:"is_pinned"`
+	Visibility string    `json:"visibility"`
+	MinFanRank string    `json:"min_fan_rank,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// 投稿の作成・更新リクエスト。publish_at を省略した場合は作成時は即時公開、更新時は元の公開日時を維持する
+type BreweryPostRequest struct {
+	Title      string     `json:"title" valid:"Required"`
+	Body       string     `json:"body" valid:"Required"`
+	ImageURLs  []string   `json:"image_urls"`
+	PublishAt  *time.Time `json:"publish_at"`
+	IsPinned   bool       `json:"is_pinned"`
+	Visibility string     `json:"visibility"`
+	MinFanRank string     `json:"min_fan_rank"`
+}
+
+type BreweryPostsResponse struct {
+	Posts []*BreweryPostResponse `json:"posts"`
+	Total int                    `json:"total"`
+}
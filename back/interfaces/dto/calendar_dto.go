@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// カレンダー購読設定
+type CalendarFeedResponse struct {
+	Enabled   bool       `json:"enabled"`
+	Token     string     `json:"token,omitempty"` // 発行直後のみ
+	Path      string     `json:"path,omitempty"`  // 購読URLのパス（発行直後のみ）
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+}
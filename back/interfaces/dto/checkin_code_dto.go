@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// 醸造所に掲示するQRチェックイン用コード（token をそのままQRコードにして表示する）
+type CheckinCodeResponse struct {
+	BreweryID     int       `json:"brewery_id"`
+	Token         string    `json:"token"`
+	IssuedAt      time.Time `json:"issued_at"`
+	ExpiresAt     time.Time `json:"expires_at"` // この日時を過ぎたら再取得して表示を切り替える
+	PeriodSeconds int       `json:"period_seconds"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// 醸造所のイベント。start_at / end_at は time_zone の時差付きで返す
+type EventResponse struct {
+	ID             int                    `json:"id"`
+	BreweryID      int                    `json:"brewery_id"`
+	Brewery        *BreweryPublicResponse `json:"brewery,omitempty"`
+	Title          string                 `json:"title"`
+	Description    string                 `json:"description"`
+	Location       string                 `json:"location"`
+	StartAt        time.Time              `json:"start_at"`
+	EndAt          time.Time              `json:"end_at"`
+	TimeZone       string                 `json:"time_zone"`
+	Capacity       int                    `json:"capacity"`
+	GoingCount     int                    `json:"going_count"`
+	WaitlistCount  int                    `json:"waitlist_count"`
+	RemainingSeats int                    `json:"remaining_seats"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// イベント登録・更新リクエスト。start_at / end_at は時差付きの RFC 3339 形式で指定する
+type EventRequest struct {
+	Title       string    `json:"title" valid:"Required"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	StartAt     time.Time `json:"start_at" valid:"Required"`
+	EndAt       time.Time `json:"end_at" valid:"Required"`
+	TimeZone    string    `json:"time_zone" valid:"Required"`
+	Capacity    int       `json:"capacity" valid:"Required"`
+}
+
+type EventsResponse struct {
+	Events []*EventResponse `json:"events"`
+	Total  int              `json:"total"`
+}
+
+// イベントへの参加申込
+type EventRSVPResponse struct {
+	EventID          int        `json:"event_id"`
+	Status           string     `json:"status"`                      // going / waitlisted
+	WaitlistPosition int        `json:"waitlist_position,omitempty"` // キャンセル待ちの順番（1始まり）
+	AttendedAt       *time.Time `json:"attended_at"`                 // GPS チェックインで参加が確認された日時
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type EventCheckinRequest struct {
+	Latitude  float64  `json:"latitude" valid:"Required"`
+	Longitude float64  `json:"longitude" valid:"Required"`
+	AccuracyM *float64 `json:"accuracy_m"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// フィードに掲載するアクティビティ
+type FeedItemResponse struct {
+	ID             int              `json:"id"`
+	Type           string           `json:"type"`
+	Brewery        *BreweryResponse `json:"brewery"`
+	RelatedBrewery *BreweryResponse `json:"related_brewery,omitempty"` // nearby_brewery_added の場合の新しい醸造所
+	Milestone      *int             `json:"milestone,omitempty"`       // visit_milestone の場合の訪問数
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+type FeedResponse struct {
+	Items      []*FeedItemResponse `json:"items"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+type BreweryFollowResponse struct {
+	BreweryID int  `json:"brewery_id"`
+	Following bool `json:"following"`
+}
@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// 複数の醸造所を巡るスタンプラリー
+type RallyResponse struct {
+	ID                int                      `json:"id"`
+	Title             string                   `json:"title"`
+	Description       string                   `json:"description"`
+	Breweries         []*BreweryPublicResponse `json:"breweries"`
+	StartAt           time.Time                `json:"start_at"`
+	EndAt             time.Time                `json:"end_at"`
+	RequiredCount     int                      `json:"required_count"` // 達成に必要なスタンプ数
+	RewardDescription string                   `json:"reward_description"`
+	ParticipantCount  int                      `json:"participant_count"`
+	CreatedAt         time.Time                `json:"created_at"`
+	UpdatedAt         time.Time                `json:"updated_at"`
+}
+
+// スタンプラリー登録・更新リクエスト。start_at / end_at は時差付きの RFC 3339 形式で指定する
+type RallyRequest struct {
+	Title             string    `json:"title" valid:"Required"`
+	Description       string    `json:"description"`
+	BreweryIDs        []int     `json:"brewery_ids" valid:"Required"`
+	StartAt           time.Time `json:"start_at" valid:"Required"`
+	EndAt             time.Time `json:"end_at" valid:"Required"`
+	RequiredCount     int       `json:"required_count" valid:"Required"`
+	RewardDescription string    `json:"reward_description"`
+}
+
+type RalliesResponse struct {
+	Rallies []*RallyResponse `json:"rallies"`
+	Total   int              `json:"total"`
+}
+
+// スタンプラリーの対象醸造所ごとのスタンプ
+type RallyStampResponse struct {
+	BreweryID int                    `json:"brewery_id"`
+	Brewery   *BreweryPublicResponse `json:"brewery,omitempty"`
+	Stamped   bool                   `json:"stamped"`
+	StampedAt *time.Time             `json:"stamped_at,omitempty"`
+	VisitID   *int                   `json:"visit_id,omitempty"`
+}
+
+// スタンプラリーの進捗
+type RallyProgressResponse struct {
+	RallyID           int                   `json:"rally_id"`
+	Title             string                `json:"title"`
+	RequiredCount     int                   `json:"required_count"`
+	StampedCount      int                   `json:"stamped_count"`
+	RemainingCount    int                   `json:"remaining_count"`
+	Stamps            []*RallyStampResponse `json:"stamps"`
+	JoinedAt          time.Time             `json:"joined_at"`
+	Completed         bool                  `json:"completed"`
+	CompletedAt       *time.Time            `json:"completed_at,omitempty"`
+	RewardDescription string                `json:"reward_description,omitempty"` // 達成した場合のみ
+	RewardCode        string                `json:"reward_code,omitempty"`        // 達成した場合のみ。醸造所で提示して特典と引き換える
+	RewardRedeemedAt  *time.Time            `json:"reward_redeemed_at,omitempty"`
+}
+
+type RallyRewardRedeemRequest struct {
+	RewardCode string `json:"reward_code" valid:"Required"`
+}
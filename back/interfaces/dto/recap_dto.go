@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// 年間の振り返り（provisional が true の場合は集計中の今年の結果）
+type UserRecapResponse struct {
+	Year               int                        `json:"year"`
+	TimeZone           string                     `json:"time_zone"`
+	Provisional        bool                       `json:"provisional"`
+	TotalVisits        int                        `json:"total_visits"`
+	BreweriesVisited   int                        `json:"breweries_visited"`
+	NewBreweries       int                        `json:"new_breweries"`
+	PrefecturesVisited int                        `json:"prefectures_visited"`
+	BusiestMonth       *MonthlyVisitCountResponse `json:"busiest_month"`
+	FavoriteBrewery    *BreweryVisitCountResponse `json:"favorite_brewery"`
+	FarthestTrip       *BreweryTripResponse       `json:"farthest_trip"`
+	LongestWeekStreak  int                        `json:"longest_week_streak"`
+	BadgesEarned       int                        `json:"badges_earned"`
+	Highlights         []*RecapHighlightResponse  `json:"highlights"`
+	GeneratedAt        time.Time                  `json:"generated_at"`
+}
+
+// 連続する訪問での醸造所間の移動
+type BreweryTripResponse struct {
+	From      *BreweryResponse `json:"from"`
+	To        *BreweryResponse `json:"to"`
+	DistanceM float64          `json:"distance_m"`
+}
+
+type RecapHighlightResponse struct {
+	Code  string `json:"code"`  // new_breweries / prefectures / week_streak / regular / long_trip / badges
+	Value int    `json:"value"` // 醸造所数・都道府県数・週数・訪問数・km・バッジ数
+}
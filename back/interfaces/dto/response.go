@@ -87,6 +87,10 @@ const (
 	ErrorCodeNotFound           = "NOT_FOUND"
 	ErrorCodeResourceExists     = "RESOURCE_EXISTS"
 	ErrorCodeResourceConflict   = "RESOURCE_CONFLICT"
+
+	// 冪等キー関連
+	ErrorCodeIdempotencyKeyMismatch = "IDEMPOTENCY_KEY_MISMATCH"
+	ErrorCodeIdempotencyInProgress  = "IDEMPOTENCY_IN_PROGRESS"
 	
 	// システム関連
 	ErrorCodeInternalServer     = "INTERNAL_SERVER_ERROR"
@@ -97,8 +101,32 @@ const (
 	// ビジネスロジック関連
 	ErrorCodeProfileNotFound    = "PROFILE_NOT_FOUND"
 	ErrorCodeProfileExists      = "PROFILE_EXISTS"
+	ErrorCodeHandleTaken        = "HANDLE_TAKEN"
 	ErrorCodeBreweryNotFound    = "BREWERY_NOT_FOUND"
+	ErrorCodeBeerNotFound       = "BEER_NOT_FOUND"
+	ErrorCodeBeerLogNotFound    = "BEER_LOG_NOT_FOUND"
 	ErrorCodeVisitNotFound      = "VISIT_NOT_FOUND"
+	ErrorCodeWishlistNotFound   = "WISHLIST_ITEM_NOT_FOUND"
+	ErrorCodePostNotFound       = "BREWERY_POST_NOT_FOUND"
+	ErrorCodeNotFollowing       = "NOT_FOLLOWING"
+	ErrorCodeEventNotFound      = "EVENT_NOT_FOUND"
+	ErrorCodeRSVPNotFound       = "RSVP_NOT_FOUND"
+	ErrorCodeEventClosed        = "EVENT_CLOSED"
+	ErrorCodeEventNotInProgress = "EVENT_NOT_IN_PROGRESS"
+	ErrorCodeNotGoingToEvent    = "RSVP_NOT_CONFIRMED"
+	ErrorCodeCalendarNotFound   = "CALENDAR_FEED_NOT_FOUND"
+	ErrorCodeRallyNotFound      = "RALLY_NOT_FOUND"
+	ErrorCodeRallyNotJoined     = "RALLY_NOT_JOINED"
+	ErrorCodeRallyClosed        = "RALLY_CLOSED"
+	ErrorCodeRewardNotFound     = "REWARD_NOT_FOUND"
+	ErrorCodeRewardRedeemed     = "REWARD_ALREADY_REDEEMED"
 	ErrorCodeCheckInFailed      = "CHECKIN_FAILED"
 	ErrorCodeLocationTooFar     = "LOCATION_TOO_FAR"
+	ErrorCodeLocationUnreliable = "LOCATION_UNRELIABLE"
+	ErrorCodeImpossibleTravel   = "IMPOSSIBLE_TRAVEL"
+	ErrorCodeDuplicateCheckin   = "DUPLICATE_CHECKIN"
+	ErrorCodeInvalidCheckinCode = "INVALID_CHECKIN_CODE"
+	ErrorCodeCheckinCodeExpired = "CHECKIN_CODE_EXPIRED"
+	ErrorCodeManagerExists      = "MANAGER_EXISTS"
+	ErrorCodeManagerNotFound    = "MANAGER_NOT_FOUND"
 )
\ No newline at end of file
@@ -3,15 +3,47 @@ package dto
 import "time"
 
 type UserProfileResponse struct {
-	ID          int       `json:"id"`
-	CognitoSub  string    `json:"cognito_sub"`
-	DisplayName string    `json:"display_name"`
-	IconURL     string    `json:"icon_url"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int                     `json:"id"`
+	CognitoSub  string                  `json:"cognito_sub"`
+	DisplayName string                  `json:"display_name"`
+	IconURL     string                  `json:"icon_url"`
+	Handle      string                  `json:"handle,omitempty"` // 未設定の場合は省略
+	Privacy     *ProfilePrivacyResponse `json:"privacy"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
 }
 
 type UserProfileRequest struct {
 	DisplayName string `json:"display_name"`
 	IconURL     string `json:"icon_url"`
+	Handle      string `json:"handle"` // 省略した場合は変更しない
+}
+
+// 公開プロフィールのプライバシー設定
+type ProfilePrivacyResponse struct {
+	Public                 bool `json:"public"`
+	HideVisitTimes         bool `json:"hide_visit_times"`
+	VisitPublishDelayHours int  `json:"visit_publish_delay_hours"`
+}
+
+// プライバシー設定の更新（すべての項目を置き換える）
+type ProfilePrivacyRequest struct {
+	Public                 bool `json:"public"`
+	HideVisitTimes         bool `json:"hide_visit_times"`
+	VisitPublishDelayHours int  `json:"visit_publish_delay_hours"`
+}
+
+// ハンドルで閲覧する公開プロフィール（プライバシー設定で許可された情報のみ）
+type PublicProfileResponse struct {
+	Handle       string                 `json:"handle"`
+	DisplayName  string                 `json:"display_name"`
+	IconURL      string                 `json:"icon_url"`
+	VisitCount   int                    `json:"visit_count"`
+	RecentVisits []*PublicVisitResponse `json:"recent_visits"`
+}
+
+// 公開プロフィールの訪問（チェックイン方法や証跡は含めない）
+type PublicVisitResponse struct {
+	Brewery   *BreweryResponse `json:"brewery"`
+	VisitedAt *time.Time       `json:"visited_at,omitempty"` // 訪問日時を伏せる設定の場合は省略
 }
@@ -3,25 +3,50 @@ package dto
 import "time"
 
 type VisitResponse struct {
-	ID            int              `json:"id"`
-	UserProfileID int              `json:"user_profile_id"`
-	BreweryID     int              `json:"brewery_id"`
-	Brewery       *BreweryResponse `json:"brewery,omitempty"`
-	VisitedAt     time.Time        `json:"visited_at"`
+	ID              int                      `json:"id"`
+	UserProfileID   int                      `json:"user_profile_id"`
+	BreweryID       int                      `json:"brewery_id"`
+	Brewery         *BreweryResponse         `json:"brewery,omitempty"`
+	CheckinMethod   string                   `json:"checkin_method"` // gps / qr
+	CheckinEvidence *CheckinEvidenceResponse `json:"checkin_evidence,omitempty"`
+	Beers           []*BeerLogResponse       `json:"beers,omitempty"`
+	VisitedAt       time.Time                `json:"visited_at"`
+}
+
+// チェックイン時のGPS証跡（訪問の所有者と管理者にのみ返却する）
+type CheckinEvidenceResponse struct {
+	Latitude      float64  `json:"latitude"`
+	Longitude     float64  `json:"longitude"`
+	AccuracyM     *float64 `json:"accuracy_m,omitempty"`
+	DistanceM     float64  `json:"distance_m"`
+	RadiusM       float64  `json:"radius_m"`
+	ClientVersion string   `json:"client_version,omitempty"`
 }
 
 type CheckinRequest struct {
-	BreweryID int     `json:"brewery_id" valid:"Required"`
-	Latitude  float64 `json:"latitude" valid:"Required"`
-	Longitude float64 `json:"longitude" valid:"Required"`
+	BreweryID     int      `json:"brewery_id" valid:"Required"`
+	Latitude      float64  `json:"latitude" valid:"Required"`
+	Longitude     float64  `json:"longitude" valid:"Required"`
+	AccuracyM     *float64 `json:"accuracy_m"`
+	ClientVersion string   `json:"client_version"`
+}
+
+// 醸造所に掲示されたQRコードによるチェックイン
+type QRCheckinRequest struct {
+	Token string `json:"token" valid:"Required"` // QRコードから読み取った文字列
 }
 
 type CheckinResponse struct {
-	Visit   *VisitResponse `json:"visit"`
-	Message string         `json:"message"`
+	Visit           *VisitResponse           `json:"visit"`
+	NewBadges       []*UserBadgeResponse     `json:"new_badges"`
+	WishlistVisited bool                     `json:"wishlist_visited"` // 行きたいリストの醸造所を初めて訪問した場合 true
+	RallyStamps     []*RallyProgressResponse `json:"rally_stamps"`     // 新たにスタンプを押したスタンプラリーの進捗
+	Message         string                   `json:"message"`
 }
 
 type VisitsResponse struct {
-	Visits []*VisitResponse `json:"visits"`
-	Total  int              `json:"total"`
+	Visits     []*VisitResponse `json:"visits"`
+	Total      *int             `json:"total,omitempty"`       // include_total の場合のみ（cursor を指定しない場合の既定）
+	NextCursor string           `json:"next_cursor,omitempty"` // 次のページがない場合は省略
+	PrevCursor string           `json:"prev_cursor,omitempty"` // 前のページがない場合は省略
 }
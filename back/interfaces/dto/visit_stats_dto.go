@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// 訪問履歴の集計（月・曜日・時間帯は time_zone で集計する）
+type VisitStatsResponse struct {
+	TimeZone             string                       `json:"time_zone"`
+	TotalVisits          int                          `json:"total_visits"`
+	DistinctBreweries    int                          `json:"distinct_breweries"`
+	FirstVisitAt         *time.Time                   `json:"first_visit_at"`
+	LastVisitAt          *time.Time                   `json:"last_visit_at"`
+	VisitsByMonth        []*MonthlyVisitCountResponse `json:"visits_by_month"`   // 訪問のある月のみ、古い順
+	VisitsByWeekday      []int                        `json:"visits_by_weekday"` // 日曜日から土曜日の7要素
+	VisitsByHour         []int                        `json:"visits_by_hour"`    // 0時から23時の24要素
+	LongestWeekStreak    int                          `json:"longest_week_streak"`
+	MostVisitedBreweries []*BreweryVisitCountResponse `json:"most_visited_breweries"`
+	TotalDistanceM       float64                      `json:"total_distance_m"`
+}
+
+type MonthlyVisitCountResponse struct {
+	Month string `json:"month"` // YYYY-MM
+	Count int    `json:"count"`
+}
+
+type BreweryVisitCountResponse struct {
+	Brewery       *BreweryResponse `json:"brewery"`
+	VisitCount    int              `json:"visit_count"`
+	LastVisitedAt *time.Time       `json:"last_visited_at,omitempty"`
+}
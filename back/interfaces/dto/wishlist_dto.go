@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// 行きたいリストの登録内容
+type WishlistItemResponse struct {
+	BreweryID int              `json:"brewery_id"`
+	Brewery   *BreweryResponse `json:"brewery"`
+	DistanceM *float64         `json:"distance_m,omitempty"` // 位置を指定した場合のみ
+	Visited   bool             `json:"visited"`
+	VisitedAt *time.Time       `json:"visited_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type WishlistResponse struct {
+	Items []*WishlistItemResponse `json:"items"`
+	Total int                     `json:"total"`
+}
@@ -0,0 +1,47 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// BadgeEntityToResponse バッジエンティティをレスポンスDTOに変換する
+func BadgeEntityToResponse(e *entity.Badge) *dto.BadgeResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.BadgeResponse{
+		ID:          e.ID(),
+		Code:        e.Code(),
+		Name:        e.Name(),
+		Description: e.Description(),
+		RuleType:    e.RuleType(),
+		Threshold:   e.Threshold(),
+		Prefecture:  e.Prefecture(),
+		IconURL:     e.IconURL(),
+	}
+}
+
+// UserBadgeEntityToResponse 獲得バッジエンティティをレスポンスDTOに変換する
+func UserBadgeEntityToResponse(e *entity.UserBadge) *dto.UserBadgeResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.UserBadgeResponse{
+		ID:        e.ID(),
+		Badge:     BadgeEntityToResponse(e.Badge()),
+		VisitID:   e.VisitID(),
+		AwardedAt: e.AwardedAt(),
+	}
+}
+
+// UserBadgeEntitiesToResponses 獲得バッジエンティティの配列をレスポンスDTOの配列に変換する
+func UserBadgeEntitiesToResponses(entities []*entity.UserBadge) []*dto.UserBadgeResponse {
+	responses := make([]*dto.UserBadgeResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = UserBadgeEntityToResponse(e)
+	}
+	return responses
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// BeerLogEntityToResponse ビールの記録エンティティをレスポンスDTOに変換する
+func BeerLogEntityToResponse(e *entity.BeerLog) *dto.BeerLogResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.BeerLogResponse{
+		ID:          e.ID(),
+		VisitID:     e.VisitID(),
+		BeerID:      e.BeerID(),
+		BeerName:    e.BeerName(),
+		ServingType: e.ServingType(),
+		Rating:      e.Rating(),
+		Notes:       e.Notes(),
+		FlavorTags:  e.FlavorTags(),
+		CreatedAt:   e.CreatedAt(),
+		UpdatedAt:   e.UpdatedAt(),
+	}
+}
+
+// BeerLogEntitiesToResponses ビールの記録エンティティの配列をレスポンスDTOの配列に変換する
+func BeerLogEntitiesToResponses(entities []*entity.BeerLog) []*dto.BeerLogResponse {
+	responses := make([]*dto.BeerLogResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = BeerLogEntityToResponse(e)
+	}
+	return responses
+}
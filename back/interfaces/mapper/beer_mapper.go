@@ -0,0 +1,70 @@
+package mapper
+
+import (
+	"math"
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+	"time"
+)
+
+// BeerDateFormat ビールの提供期間の日付形式
+const BeerDateFormat = "2006-01-02"
+
+// BeerEntityToResponse ビールエンティティをレスポンスDTOに変換する
+func BeerEntityToResponse(e *entity.Beer) *dto.BeerResponse {
+	if e == nil {
+		return nil
+	}
+
+	response := &dto.BeerResponse{
+		ID:             e.ID(),
+		BreweryID:      e.BreweryID(),
+		Name:           e.Name(),
+		Style:          e.Style(),
+		ABV:            e.ABV(),
+		IBU:            e.IBU(),
+		Description:    e.Description(),
+		IsSeasonal:     e.IsSeasonal(),
+		IsLimited:      e.IsLimited(),
+		AvailableFrom:  formatBeerDate(e.AvailableFrom()),
+		AvailableUntil: formatBeerDate(e.AvailableUntil()),
+		IsAvailable:    e.IsAvailableOn(time.Now()),
+		AverageRating:  roundRating(e.AverageRating()),
+		RatingCount:    e.RatingCount(),
+		CreatedAt:      e.CreatedAt(),
+		UpdatedAt:      e.UpdatedAt(),
+	}
+
+	if e.Brewery() != nil {
+		response.Brewery = BreweryEntityToPublicResponse(e.Brewery())
+	}
+
+	return response
+}
+
+// BeerEntitiesToResponses ビールエンティティの配列をレスポンスDTOの配列に変換する
+func BeerEntitiesToResponses(entities []*entity.Beer) []*dto.BeerResponse {
+	responses := make([]*dto.BeerResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = BeerEntityToResponse(e)
+	}
+	return responses
+}
+
+// formatBeerDate 提供期間の日付を文字列に変換する
+func formatBeerDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(BeerDateFormat)
+	return &formatted
+}
+
+// roundRating 平均評価を小数点以下2桁に丸める
+func roundRating(rating *float64) *float64 {
+	if rating == nil {
+		return nil
+	}
+	rounded := math.Round(*rating*100) / 100
+	return &rounded
+}
@@ -0,0 +1,49 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// BeerStyleEntityToResponse ビアスタイルエンティティをレスポンスDTOに変換する（子スタイルは含めない）
+func BeerStyleEntityToResponse(e *entity.BeerStyle) *dto.BeerStyleResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.BeerStyleResponse{
+		ID:       e.ID(),
+		Code:     e.Code(),
+		ParentID: e.ParentID(),
+		NameJa:   e.NameJa(),
+		NameEn:   e.NameEn(),
+	}
+}
+
+// BeerStyleEntitiesToResponses ビアスタイルエンティティの配列をレスポンスDTOの配列に変換する
+func BeerStyleEntitiesToResponses(entities []*entity.BeerStyle) []*dto.BeerStyleResponse {
+	responses := make([]*dto.BeerStyleResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = BeerStyleEntityToResponse(e)
+	}
+	return responses
+}
+
+// BeerStyleTreeToResponses ビアスタイルの木構造を、子スタイルを入れ子にしたレスポンスDTOの配列に変換する
+func BeerStyleTreeToResponses(tree *entity.BeerStyleTree) []*dto.BeerStyleResponse {
+	return beerStyleNodesToResponses(tree.Roots())
+}
+
+// beerStyleNodesToResponses 木構造のスタイルを子スタイルとともに変換する
+func beerStyleNodesToResponses(styles []*entity.BeerStyle) []*dto.BeerStyleResponse {
+	responses := make([]*dto.BeerStyleResponse, len(styles))
+	for i, style := range styles {
+		response := BeerStyleEntityToResponse(style)
+		response.Level = style.Level()
+		if len(style.Children()) > 0 {
+			response.Children = beerStyleNodesToResponses(style.Children())
+		}
+		responses[i] = response
+	}
+	return responses
+}
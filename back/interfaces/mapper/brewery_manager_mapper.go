@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// BreweryManagerEntityToResponse 醸造所管理者エンティティをレスポンスDTOに変換する
+func BreweryManagerEntityToResponse(e *entity.BreweryManager) *dto.BreweryManagerResponse {
+	if e == nil {
+		return nil
+	}
+
+	response := &dto.BreweryManagerResponse{
+		ID:            e.ID(),
+		BreweryID:     e.BreweryID(),
+		UserProfileID: e.UserProfileID(),
+		CreatedAt:     e.CreatedAt(),
+	}
+
+	if e.UserProfile() != nil {
+		response.UserProfile = UserProfileEntityToResponse(e.UserProfile())
+	}
+
+	return response
+}
+
+// BreweryManagerEntitiesToResponses 醸造所管理者エンティティの配列をレスポンスDTOの配列に変換する
+func BreweryManagerEntitiesToResponses(entities []*entity.BreweryManager) []*dto.BreweryManagerResponse {
+	responses := make([]*dto.BreweryManagerResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = BreweryManagerEntityToResponse(e)
+	}
+	return responses
+}
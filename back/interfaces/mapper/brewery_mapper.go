@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"math"
 	"mybeerlog/domain/entity"
 	"mybeerlog/interfaces/dto"
 )
@@ -10,16 +11,20 @@ func BreweryEntityToResponse(e *entity.Brewery) *dto.BreweryResponse {
 	if e == nil {
 		return nil
 	}
-	
+
 	return &dto.BreweryResponse{
-		ID:          e.ID(),
-		Name:        e.Name(),
-		Address:     e.Address(),
-		Description: e.Description(),
-		Latitude:    e.Latitude(),
-		Longitude:   e.Longitude(),
-		CreatedAt:   e.CreatedAt(),
-		UpdatedAt:   e.UpdatedAt(),
+		ID:            e.ID(),
+		Name:          e.Name(),
+		Address:       e.Address(),
+		Prefecture:    e.Prefecture(),
+		Description:   e.Description(),
+		Latitude:      e.Latitude(),
+		Longitude:     e.Longitude(),
+		Status:        e.Status(),
+		DeletedAt:     e.DeletedAt(),
+		WantToGoCount: e.WantToGoCount(),
+		CreatedAt:     e.CreatedAt(),
+		UpdatedAt:     e.UpdatedAt(),
 	}
 }
 
@@ -28,14 +33,16 @@ func BreweryEntityToPublicResponse(e *entity.Brewery) *dto.BreweryPublicResponse
 	if e == nil {
 		return nil
 	}
-	
+
 	return &dto.BreweryPublicResponse{
-		ID:          e.ID(),
-		Name:        e.Name(),
-		Address:     e.Address(),
-		Description: e.Description(),
-		CreatedAt:   e.CreatedAt(),
-		UpdatedAt:   e.UpdatedAt(),
+		ID:            e.ID(),
+		Name:          e.Name(),
+		Address:       e.Address(),
+		Prefecture:    e.Prefecture(),
+		Description:   e.Description(),
+		WantToGoCount: e.WantToGoCount(),
+		CreatedAt:     e.CreatedAt(),
+		UpdatedAt:     e.UpdatedAt(),
 	}
 }
 
@@ -48,6 +55,37 @@ func BreweryEntitiesToResponses(entities []*entity.Brewery) []*dto.BreweryRespon
 	return responses
 }
 
+// NearbyBreweryToResponse 距離付き醸造所エンティティを distance_m を含むレスポンスDTOに変換する
+func NearbyBreweryToResponse(n *entity.NearbyBrewery) *dto.BreweryResponse {
+	if n == nil {
+		return nil
+	}
+
+	response := BreweryEntityToResponse(n.Brewery())
+	distanceM := math.Round(n.DistanceM()*10) / 10
+	response.DistanceM = &distanceM
+	return response
+}
+
+// NearbyBreweriesToResponses 距離付き醸造所エンティティの配列をレスポンスDTOの配列に変換する
+func NearbyBreweriesToResponses(entities []*entity.NearbyBrewery) []*dto.BreweryResponse {
+	responses := make([]*dto.BreweryResponse, len(entities))
+	for i, n := range entities {
+		responses[i] = NearbyBreweryToResponse(n)
+	}
+	return responses
+}
+
+// NearbyBreweriesToPublicResponses 距離付き醸造所エンティティの配列をパブリックレスポンスDTOの配列に変換する
+// ゲストには検索地点を推定できる距離情報を返さない
+func NearbyBreweriesToPublicResponses(entities []*entity.NearbyBrewery) []*dto.BreweryPublicResponse {
+	responses := make([]*dto.BreweryPublicResponse, len(entities))
+	for i, n := range entities {
+		responses[i] = BreweryEntityToPublicResponse(n.Brewery())
+	}
+	return responses
+}
+
 // BreweryEntitiesToPublicResponses 醸造所エンティティの配列をパブリックレスポンスDTOの配列に変換する
 func BreweryEntitiesToPublicResponses(entities []*entity.Brewery) []*dto.BreweryPublicResponse {
 	responses := make([]*dto.BreweryPublicResponse, len(entities))
@@ -55,4 +93,4 @@ func BreweryEntitiesToPublicResponses(entities []*entity.Brewery) []*dto.Brewery
 		responses[i] = BreweryEntityToPublicResponse(e)
 	}
 	return responses
-}
\ No newline at end of file
+}
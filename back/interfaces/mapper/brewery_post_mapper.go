@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/utils"
+	"time"
+)
+
+// BreweryPostEntityToResponse 投稿エンティティをレスポンスDTOに変換する（本文の Markdown はサニタイズ済みの HTML に変換する）
+func BreweryPostEntityToResponse(e *entity.BreweryPost) *dto.BreweryPostResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.BreweryPostResponse{
+		ID:         e.ID(),
+		BreweryID:  e.BreweryID(),
+		Title:      e.Title(),
+		Body:       e.Body(),
+		BodyHTML:   utils.RenderMarkdown(e.Body()),
+		ImageURLs:  e.ImageURLs(),
+		PublishAt:  e.PublishAt(),
+		Published:  e.IsPublishedAt(time.Now()),
+		IsPinned:   e.IsPinned(),
+		Visibility: e.Visibility(),
+		MinFanRank: e.MinFanRank(),
+		CreatedAt:  e.CreatedAt(),
+		UpdatedAt:  e.UpdatedAt(),
+	}
+}
+
+// BreweryPostEntitiesToResponses 投稿エンティティの配列をレスポンスDTOの配列に変換する
+func BreweryPostEntitiesToResponses(entities []*entity.BreweryPost) []*dto.BreweryPostResponse {
+	responses := make([]*dto.BreweryPostResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = BreweryPostEntityToResponse(e)
+	}
+	return responses
+}
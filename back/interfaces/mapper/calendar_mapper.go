@@ -0,0 +1,77 @@
+package mapper
+
+import (
+	"fmt"
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+	"mybeerlog/utils"
+	"strings"
+)
+
+// calendarVisitDuration カレンダー上の訪問の長さ（訪問は時刻のみ記録しているため、表示用に一定の長さを持たせる）
+const calendarVisitDuration = "PT1H"
+
+// CalendarFeedEntityToResponse カレンダー購読設定をレスポンスDTOに変換する
+// token は発行直後のみ指定する（保存しているのはハッシュのみのため、それ以外では返せない）
+func CalendarFeedEntityToResponse(e *entity.CalendarFeed, token string) *dto.CalendarFeedResponse {
+	if e == nil {
+		return &dto.CalendarFeedResponse{Enabled: false}
+	}
+
+	rotatedAt := e.RotatedAt()
+	response := &dto.CalendarFeedResponse{
+		Enabled:   true,
+		RotatedAt: &rotatedAt,
+	}
+	if token != "" {
+		response.Token = token
+		response.Path = "/users/calendar/" + token + ".ics"
+	}
+	return response
+}
+
+// VisitsToICalendar 訪問履歴を iCalendar（RFC 5545）形式に変換する
+// UID は訪問IDから決まるため、同じ訪問は何度取得しても同じ予定として扱われる
+func VisitsToICalendar(visits []*entity.Visit, calendarName string) string {
+	w := utils.NewICalWriter()
+	w.Begin("VCALENDAR")
+	w.Property("VERSION", "2.0")
+	w.Property("PRODID", "-//MyBeerLog//Visit History//JA")
+	w.Property("CALSCALE", "GREGORIAN")
+	w.Property("METHOD", "PUBLISH")
+	w.Text("X-WR-CALNAME", calendarName)
+	w.Property("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	w.Property("X-PUBLISHED-TTL", "PT1H")
+
+	for _, visit := range visits {
+		w.Begin("VEVENT")
+		w.Property("UID", fmt.Sprintf("visit-%d@mybeerlog", visit.ID()))
+		// 訪問は更新されないため、訪問日時を DTSTAMP にして出力を安定させる
+		w.DateTime("DTSTAMP", visit.VisitedAt())
+		w.DateTime("DTSTART", visit.VisitedAt())
+		w.Property("DURATION", calendarVisitDuration)
+
+		if brewery := visit.Brewery(); brewery != nil {
+			w.Text("SUMMARY", brewery.Name())
+			w.Text("LOCATION", breweryLocation(brewery))
+			w.Property("GEO", fmt.Sprintf("%.6f;%.6f", brewery.Latitude(), brewery.Longitude()))
+		} else {
+			w.Text("SUMMARY", "醸造所訪問")
+		}
+
+		w.Property("TRANSP", "TRANSPARENT")
+		w.End("VEVENT")
+	}
+
+	w.End("VCALENDAR")
+	return w.String()
+}
+
+// breweryLocation 醸造所の所在地を返す（住所に都道府県が含まれない場合は先頭に付ける）
+func breweryLocation(brewery *entity.Brewery) string {
+	address := brewery.Address()
+	if brewery.Prefecture() != "" && !strings.HasPrefix(address, brewery.Prefecture()) {
+		address = brewery.Prefecture() + address
+	}
+	return address
+}
@@ -0,0 +1,21 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// CheckinCodeEntityToResponse QRチェックイン用コードをレスポンスDTOに変換する
+func CheckinCodeEntityToResponse(e *entity.CheckinCode) *dto.CheckinCodeResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.CheckinCodeResponse{
+		BreweryID:     e.BreweryID(),
+		Token:         e.Token(),
+		IssuedAt:      e.IssuedAt(),
+		ExpiresAt:     e.ExpiresAt(),
+		PeriodSeconds: int(e.ExpiresAt().Sub(e.IssuedAt()).Seconds()),
+	}
+}
@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// EventEntityToResponse イベントエンティティをレスポンスDTOに変換する（開催日時は開催地のタイムゾーンで表す）
+func EventEntityToResponse(e *entity.Event) *dto.EventResponse {
+	if e == nil {
+		return nil
+	}
+
+	loc := e.TimeLocation()
+	response := &dto.EventResponse{
+		ID:             e.ID(),
+		BreweryID:      e.BreweryID(),
+		Title:          e.Title(),
+		Description:    e.Description(),
+		Location:       e.Location(),
+		StartAt:        e.StartAt().In(loc),
+		EndAt:          e.EndAt().In(loc),
+		TimeZone:       e.TimeZone(),
+		Capacity:       e.Capacity(),
+		GoingCount:     e.GoingCount(),
+		WaitlistCount:  e.WaitlistCount(),
+		RemainingSeats: e.RemainingSeats(),
+		CreatedAt:      e.CreatedAt(),
+		UpdatedAt:      e.UpdatedAt(),
+	}
+
+	if e.Brewery() != nil {
+		response.Brewery = BreweryEntityToPublicResponse(e.Brewery())
+	}
+
+	return response
+}
+
+// EventEntitiesToResponses イベントエンティティの配列をレスポンスDTOの配列に変換する
+func EventEntitiesToResponses(entities []*entity.Event) []*dto.EventResponse {
+	responses := make([]*dto.EventResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = EventEntityToResponse(e)
+	}
+	return responses
+}
+
+// EventRSVPEntityToResponse 参加申込エンティティをレスポンスDTOに変換する
+func EventRSVPEntityToResponse(e *entity.EventRSVP) *dto.EventRSVPResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.EventRSVPResponse{
+		EventID:          e.EventID(),
+		Status:           e.Status(),
+		WaitlistPosition: e.WaitlistPosition(),
+		AttendedAt:       e.AttendedAt(),
+		CreatedAt:        e.CreatedAt(),
+		UpdatedAt:        e.UpdatedAt(),
+	}
+}
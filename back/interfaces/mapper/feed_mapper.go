@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// BreweryActivityEntityToResponse アクティビティエンティティをフィードのレスポンスDTOに変換する
+func BreweryActivityEntityToResponse(e *entity.BreweryActivity) *dto.FeedItemResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.FeedItemResponse{
+		ID:             e.ID(),
+		Type:           e.Type(),
+		Brewery:        BreweryEntityToResponse(e.Brewery()),
+		RelatedBrewery: BreweryEntityToResponse(e.RelatedBrewery()),
+		Milestone:      e.Milestone(),
+		CreatedAt:      e.CreatedAt(),
+	}
+}
+
+// BreweryActivityEntitiesToResponses アクティビティエンティティの配列をフィードのレスポンスDTOの配列に変換する
+func BreweryActivityEntitiesToResponses(entities []*entity.BreweryActivity) []*dto.FeedItemResponse {
+	responses := make([]*dto.FeedItemResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = BreweryActivityEntityToResponse(e)
+	}
+	return responses
+}
@@ -0,0 +1,96 @@
+package mapper
+
+import (
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// RallyEntityToResponse スタンプラリーエンティティをレスポンスDTOに変換する
+func RallyEntityToResponse(e *entity.Rally) *dto.RallyResponse {
+	if e == nil {
+		return nil
+	}
+
+	breweries := make([]*dto.BreweryPublicResponse, len(e.Breweries()))
+	for i, brewery := range e.Breweries() {
+		breweries[i] = BreweryEntityToPublicResponse(brewery)
+	}
+
+	return &dto.RallyResponse{
+		ID:                e.ID(),
+		Title:             e.Title(),
+		Description:       e.Description(),
+		Breweries:         breweries,
+		StartAt:           e.StartAt(),
+		EndAt:             e.EndAt(),
+		RequiredCount:     e.RequiredCount(),
+		RewardDescription: e.RewardDescription(),
+		ParticipantCount:  e.ParticipantCount(),
+		CreatedAt:         e.CreatedAt(),
+		UpdatedAt:         e.UpdatedAt(),
+	}
+}
+
+// RallyEntitiesToResponses スタンプラリーエンティティの配列をレスポンスDTOの配列に変換する
+func RallyEntitiesToResponses(entities []*entity.Rally) []*dto.RallyResponse {
+	responses := make([]*dto.RallyResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = RallyEntityToResponse(e)
+	}
+	return responses
+}
+
+// RallyParticipantEntityToProgressResponse スタンプラリーへの参加を進捗のレスポンスDTOに変換する
+// スタンプは対象醸造所の登録順に並べ、未獲得の醸造所も含める
+func RallyParticipantEntityToProgressResponse(e *entity.RallyParticipant) *dto.RallyProgressResponse {
+	if e == nil {
+		return nil
+	}
+
+	response := &dto.RallyProgressResponse{
+		RallyID:        e.RallyID(),
+		StampedCount:   e.StampedCount(),
+		RemainingCount: e.RemainingCount(),
+		Stamps:         []*dto.RallyStampResponse{},
+		JoinedAt:       e.JoinedAt(),
+		Completed:      e.IsCompleted(),
+		CompletedAt:    e.CompletedAt(),
+	}
+
+	if rally := e.Rally(); rally != nil {
+		response.Title = rally.Title()
+		response.RequiredCount = rally.RequiredCount()
+		for _, brewery := range rally.Breweries() {
+			stampResponse := &dto.RallyStampResponse{
+				BreweryID: brewery.ID(),
+				Brewery:   BreweryEntityToPublicResponse(brewery),
+			}
+			if stamp := e.StampFor(brewery.ID()); stamp != nil {
+				stampedAt := stamp.StampedAt()
+				stampResponse.Stamped = true
+				stampResponse.StampedAt = &stampedAt
+				stampResponse.VisitID = stamp.VisitID()
+			}
+			response.Stamps = append(response.Stamps, stampResponse)
+		}
+		if e.IsCompleted() {
+			response.RewardDescription = rally.RewardDescription()
+		}
+	}
+
+	if e.IsCompleted() {
+		response.RewardCode = e.RewardCode()
+		response.RewardRedeemedAt = e.RewardRedeemedAt()
+	}
+
+	return response
+}
+
+// RallyParticipantEntitiesToProgressResponses スタンプラリーへの参加の配列を進捗のレスポンスDTOの配列に変換する
+func RallyParticipantEntitiesToProgressResponses(entities []*entity.RallyParticipant) []*dto.RallyProgressResponse {
+	responses := make([]*dto.RallyProgressResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = RallyParticipantEntityToProgressResponse(e)
+	}
+	return responses
+}
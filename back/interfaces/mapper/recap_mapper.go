@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"math"
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+	"time"
+)
+
+// UserRecapEntityToResponse 年間の振り返りをレスポンスDTOに変換する（日時は loc のタイムゾーンで返す）
+func UserRecapEntityToResponse(e *entity.UserRecap, loc *time.Location) *dto.UserRecapResponse {
+	if e == nil {
+		return nil
+	}
+
+	response := &dto.UserRecapResponse{
+		Year:               e.Year,
+		TimeZone:           loc.String(),
+		Provisional:        e.Provisional,
+		TotalVisits:        e.TotalVisits,
+		BreweriesVisited:   e.BreweriesVisited,
+		NewBreweries:       e.NewBreweries,
+		PrefecturesVisited: e.PrefecturesVisited,
+		BusiestMonth:       monthlyVisitCountToResponse(e.BusiestMonth),
+		LongestWeekStreak:  e.LongestWeekStreak,
+		BadgesEarned:       e.BadgesEarned,
+		GeneratedAt:        e.GeneratedAt.In(loc),
+	}
+
+	if e.FavoriteBrewery != nil {
+		response.FavoriteBrewery = &dto.BreweryVisitCountResponse{
+			Brewery:    BreweryEntityToResponse(e.FavoriteBrewery),
+			VisitCount: e.FavoriteBreweryVisits,
+		}
+	}
+	if e.FarthestTrip != nil {
+		response.FarthestTrip = &dto.BreweryTripResponse{
+			From:      BreweryEntityToResponse(e.FarthestTrip.From),
+			To:        BreweryEntityToResponse(e.FarthestTrip.To),
+			DistanceM: math.Round(e.FarthestTrip.DistanceM),
+		}
+	}
+
+	highlights := e.Highlights()
+	response.Highlights = make([]*dto.RecapHighlightResponse, len(highlights))
+	for i, highlight := range highlights {
+		response.Highlights[i] = &dto.RecapHighlightResponse{
+			Code:  highlight.Code,
+			Value: highlight.Value,
+		}
+	}
+
+	return response
+}
@@ -3,6 +3,7 @@ package mapper
 import (
 	"mybeerlog/domain/entity"
 	"mybeerlog/interfaces/dto"
+	"time"
 )
 
 // UserProfileEntityToResponse ユーザープロファイルエンティティをレスポンスDTOに変換する
@@ -16,7 +17,47 @@ func UserProfileEntityToResponse(e *entity.UserProfile) *dto.UserProfileResponse
 		CognitoSub:  e.CognitoSub(),
 		DisplayName: e.DisplayName(),
 		IconURL:     e.IconURL(),
-		CreatedAt:   e.CreatedAt(),
-		UpdatedAt:   e.UpdatedAt(),
+		Handle:      e.Handle(),
+		Privacy: &dto.ProfilePrivacyResponse{
+			Public:                 e.Privacy().IsPublic(),
+			HideVisitTimes:         e.Privacy().HideVisitTimes(),
+			VisitPublishDelayHours: e.Privacy().VisitPublishDelayHours(),
+		},
+		CreatedAt: e.CreatedAt(),
+		UpdatedAt: e.UpdatedAt(),
 	}
 }
+
+// PublicProfileEntityToResponse 公開プロフィールエンティティをレスポンスDTOに変換する
+// プライバシー設定はここで適用する: 公開前の訪問（now の時点で公開の遅延時間が経過していない訪問）は除き、
+// 訪問日時を伏せる設定の場合は訪問日時を含めない。ユーザーのIDや Cognito SUB、チェックインの証跡は含めない
+func PublicProfileEntityToResponse(e *entity.PublicProfile, now time.Time) *dto.PublicProfileResponse {
+	if e == nil || e.Profile == nil {
+		return nil
+	}
+
+	privacy := e.Profile.Privacy()
+	response := &dto.PublicProfileResponse{
+		Handle:       e.Profile.Handle(),
+		DisplayName:  e.Profile.DisplayName(),
+		IconURL:      e.Profile.IconURL(),
+		VisitCount:   e.VisitCount,
+		RecentVisits: make([]*dto.PublicVisitResponse, 0, len(e.RecentVisits)),
+	}
+
+	for _, visit := range e.RecentVisits {
+		if visit == nil || !privacy.IsVisitPublished(visit.VisitedAt(), now) {
+			continue
+		}
+		visitResponse := &dto.PublicVisitResponse{
+			Brewery: BreweryEntityToResponse(visit.Brewery()),
+		}
+		if !privacy.HideVisitTimes() {
+			visitedAt := visit.VisitedAt()
+			visitResponse.VisitedAt = &visitedAt
+		}
+		response.RecentVisits = append(response.RecentVisits, visitResponse)
+	}
+
+	return response
+}
@@ -15,6 +15,7 @@ func VisitEntityToResponse(e *entity.Visit) *dto.VisitResponse {
 		ID:            e.ID(),
 		UserProfileID: e.UserProfileID(),
 		BreweryID:     e.BreweryID(),
+		CheckinMethod: e.CheckinMethod(),
 		VisitedAt:     e.VisitedAt(),
 	}
 
@@ -33,3 +34,33 @@ func VisitEntitiesToResponses(entities []*entity.Visit) []*dto.VisitResponse {
 	}
 	return responses
 }
+
+// VisitEntityToOwnerResponse 訪問エンティティをチェックイン証跡を含むレスポンスDTOに変換する（所有者・管理者向け）
+func VisitEntityToOwnerResponse(e *entity.Visit) *dto.VisitResponse {
+	response := VisitEntityToResponse(e)
+	if response == nil {
+		return nil
+	}
+
+	if evidence := e.CheckinEvidence(); evidence != nil {
+		response.CheckinEvidence = &dto.CheckinEvidenceResponse{
+			Latitude:      evidence.Latitude(),
+			Longitude:     evidence.Longitude(),
+			AccuracyM:     evidence.AccuracyM(),
+			DistanceM:     evidence.DistanceM(),
+			RadiusM:       evidence.RadiusM(),
+			ClientVersion: evidence.ClientVersion(),
+		}
+	}
+
+	return response
+}
+
+// VisitEntitiesToOwnerResponses 訪問エンティティの配列をチェックイン証跡を含むレスポンスDTOの配列に変換する
+func VisitEntitiesToOwnerResponses(entities []*entity.Visit) []*dto.VisitResponse {
+	responses := make([]*dto.VisitResponse, len(entities))
+	for i, e := range entities {
+		responses[i] = VisitEntityToOwnerResponse(e)
+	}
+	return responses
+}
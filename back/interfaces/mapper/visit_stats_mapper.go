@@ -0,0 +1,63 @@
+package mapper
+
+import (
+	"fmt"
+	"math"
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+	"time"
+)
+
+// VisitStatsEntityToResponse 訪問履歴の集計をレスポンスDTOに変換する（日時は loc のタイムゾーンで返す）
+func VisitStatsEntityToResponse(e *entity.VisitStats, loc *time.Location) *dto.VisitStatsResponse {
+	if e == nil {
+		return nil
+	}
+
+	response := &dto.VisitStatsResponse{
+		TimeZone:             loc.String(),
+		TotalVisits:          e.TotalVisits,
+		DistinctBreweries:    e.DistinctBreweries,
+		VisitsByMonth:        make([]*dto.MonthlyVisitCountResponse, len(e.VisitsByMonth)),
+		VisitsByWeekday:      e.VisitsByWeekday[:],
+		VisitsByHour:         e.VisitsByHour[:],
+		LongestWeekStreak:    e.LongestWeekStreak,
+		MostVisitedBreweries: make([]*dto.BreweryVisitCountResponse, len(e.MostVisitedBreweries)),
+		// 大円距離の端数はダッシュボードの表示に不要なため、メートル単位に丸める
+		TotalDistanceM: math.Round(e.TotalDistanceM),
+	}
+	if e.FirstVisitAt != nil {
+		firstVisitAt := e.FirstVisitAt.In(loc)
+		response.FirstVisitAt = &firstVisitAt
+	}
+	if e.LastVisitAt != nil {
+		lastVisitAt := e.LastVisitAt.In(loc)
+		response.LastVisitAt = &lastVisitAt
+	}
+
+	for i := range e.VisitsByMonth {
+		response.VisitsByMonth[i] = monthlyVisitCountToResponse(&e.VisitsByMonth[i])
+	}
+	for i, brewery := range e.MostVisitedBreweries {
+		lastVisitedAt := brewery.LastVisitedAt.In(loc)
+		response.MostVisitedBreweries[i] = &dto.BreweryVisitCountResponse{
+			Brewery:       BreweryEntityToResponse(brewery.Brewery),
+			VisitCount:    brewery.VisitCount,
+			LastVisitedAt: &lastVisitedAt,
+		}
+	}
+
+	return response
+}
+
+// monthlyVisitCountToResponse 月ごとの訪問数をレスポンスDTOに変換する
+func monthlyVisitCountToResponse(e *entity.MonthlyVisitCount) *dto.MonthlyVisitCountResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.MonthlyVisitCountResponse{
+		Month: fmt.Sprintf("%04d-%02d", e.Year, int(e.Month)),
+		Count: e.Count,
+	}
+}
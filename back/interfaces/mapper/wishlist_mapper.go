@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"math"
+	"mybeerlog/domain/entity"
+	"mybeerlog/interfaces/dto"
+)
+
+// WishlistItemEntityToResponse 行きたいリストの登録エンティティをレスポンスDTOに変換する
+func WishlistItemEntityToResponse(e *entity.WishlistItem) *dto.WishlistItemResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto.WishlistItemResponse{
+		BreweryID: e.BreweryID(),
+		Brewery:   BreweryEntityToResponse(e.Brewery()),
+		Visited:   e.IsVisited(),
+		VisitedAt: e.VisitedAt(),
+		CreatedAt: e.CreatedAt(),
+	}
+}
+
+// NearbyWishlistItemsToResponses 距離付きの行きたいリストの登録をレスポンスDTOの配列に変換する
+func NearbyWishlistItemsToResponses(entities []*entity.NearbyWishlistItem) []*dto.WishlistItemResponse {
+	responses := make([]*dto.WishlistItemResponse, len(entities))
+	for i, n := range entities {
+		response := WishlistItemEntityToResponse(n.Item())
+		if n.DistanceM() != nil {
+			distanceM := math.Round(*n.DistanceM()*10) / 10
+			response.DistanceM = &distanceM
+		}
+		responses[i] = response
+	}
+	return responses
+}
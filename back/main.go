@@ -6,6 +6,7 @@ import (
 	"mybeerlog/models"
 	"mybeerlog/utils"
 	"os"
+	_ "time/tzdata" // イベントのタイムゾーンを実行環境の tzdata に依存せず解決する
 
 	"github.com/astaxie/beego"
 	"github.com/astaxie/beego/orm"
@@ -42,6 +43,28 @@ func init() {
 		new(models.UserProfile),
 		new(models.Brewery),
 		new(models.Visit),
+		new(models.BreweryManager),
+		new(models.Beer),
+		new(models.BeerLog),
+		new(models.BeerStyle),
+		new(models.BreweryStyle),
+		new(models.WishlistItem),
+		new(models.BreweryFollow),
+		new(models.BreweryActivity),
+		new(models.BreweryPost),
+		new(models.BreweryPostImage),
+		new(models.Event),
+		new(models.EventRsvp),
+		new(models.CalendarFeed),
+		new(models.BreweryCheckinSecret),
+		new(models.Rally),
+		new(models.RallyBrewery),
+		new(models.RallyParticipant),
+		new(models.RallyStamp),
+		new(models.Badge),
+		new(models.UserBadge),
+		new(models.IdempotencyKey),
+		new(models.UserRecap),
 	)
 
 	// Lambda 環境では run.mode を production に設定
@@ -70,6 +93,13 @@ func setupMiddleware() {
 	
 	// 4. CORS ミドルウェア
 	beego.InsertFilter("*", beego.BeforeRouter, utils.CORSMiddleware)
+
+	// 5. 認証ミドルウェア（JWT検証・クレームをコンテキストに設定）
+	beego.InsertFilter("*", beego.BeforeRouter, utils.AuthenticationMiddleware)
+
+	// 6. 冪等キーミドルウェア（認証済みユーザー単位でレスポンスを保存・再送）
+	beego.InsertFilter("*", beego.BeforeRouter, utils.IdempotencyMiddleware)
+	beego.InsertFilter("*", beego.FinishRouter, utils.IdempotencyFinishMiddleware, false)
 }
 
 // setupRoutes ルーティングを設定する
@@ -80,17 +110,97 @@ func setupRoutes() {
 	// ユーザープロファイル管理
 	userController := controllers.NewUserController()
 	beego.Router("/users/profile", userController, "get:GetProfile;post:CreateProfile;put:UpdateProfile")
+	beego.Router("/users/profile/privacy", userController, "put:UpdatePrivacy")
+
+	// バッジ関連
+	badgeController := controllers.NewBadgeController()
+	beego.Router("/users/profile/badges", badgeController, "get:GetMyBadges")
+
+	// 訪問履歴の集計
+	visitStatsController := controllers.NewVisitStatsController()
+	beego.Router("/users/profile/stats", visitStatsController, "get:GetMyStats")
+
+	// 年間の振り返り
+	recapController := controllers.NewRecapController()
+	beego.Router("/users/profile/recap/:year", recapController, "get:GetMyRecap")
+
+	// 行きたいリスト
+	wishlistController := controllers.NewWishlistController()
+	beego.Router("/users/profile/wishlist", wishlistController, "get:GetWishlist")
+	beego.Router("/users/profile/wishlist/:brewery_id", wishlistController, "post:AddToWishlist;delete:RemoveFromWishlist")
+
+	// 訪問履歴のカレンダー購読
+	calendarController := controllers.NewCalendarController()
+	beego.Router("/users/profile/calendar", calendarController, "get:GetFeed")
+	beego.Router("/users/profile/calendar/token", calendarController, "post:RotateToken;delete:RevokeToken")
+	beego.Router("/users/calendar/:token.ics", calendarController, "get:GetCalendar")
+
+	// 醸造所のフォロー・フィード
+	followController := controllers.NewFollowController()
+	beego.Router("/users/profile/follows", followController, "get:GetFollowedBreweries")
+	beego.Router("/breweries/:brewery_id/follow", followController, "post:FollowBrewery;delete:UnfollowBrewery")
+	feedController := controllers.NewFeedController()
+	beego.Router("/feed", feedController, "get:GetFeed")
+
+	// 公開プロフィール（/users/ 以下の固定のパスが優先される。衝突するハンドルは予約語として使用できない）
+	publicProfileController := controllers.NewPublicProfileController()
+	beego.Router("/users/:handle", publicProfileController, "get:GetPublicProfile")
 
 	// 醸造所管理
 	breweryController := controllers.NewBreweryController()
 	beego.Router("/breweries", breweryController, "get:GetBreweries;post:CreateBrewery")
-	beego.Router("/breweries/:brewery_id", breweryController, "get:GetBrewery")
+	beego.Router("/breweries/:brewery_id", breweryController, "get:GetBrewery;put:UpdateBrewery;patch:PatchBrewery;delete:DeleteBrewery")
+
+	// ビアスタイル
+	beerStyleController := controllers.NewBeerStyleController()
+	beego.Router("/styles", beerStyleController, "get:GetStyles")
+	beego.Router("/breweries/:brewery_id/styles", beerStyleController, "get:GetBreweryStyles;put:UpdateBreweryStyles")
+
+	// ビール
+	beerController := controllers.NewBeerController()
+	beego.Router("/breweries/:brewery_id/beers", beerController, "get:GetBreweryBeers;post:CreateBeer")
+	beego.Router("/beers/:beer_id", beerController, "get:GetBeer;put:UpdateBeer;delete:DeleteBeer")
+
+	// 醸造所の投稿
+	breweryPostController := controllers.NewBreweryPostController()
+	beego.Router("/breweries/:brewery_id/posts", breweryPostController, "get:GetPosts;post:CreatePost")
+	beego.Router("/breweries/:brewery_id/posts/:post_id", breweryPostController, "get:GetPost;put:UpdatePost;delete:DeletePost")
+
+	// 醸造所のイベント
+	eventController := controllers.NewEventController()
+	beego.Router("/breweries/:brewery_id/events", eventController, "get:GetBreweryEvents;post:CreateEvent")
+	beego.Router("/events/:event_id", eventController, "get:GetEvent;put:UpdateEvent;delete:DeleteEvent")
+	beego.Router("/events/:event_id/rsvp", eventController, "get:GetMyRSVP;post:RSVP;delete:CancelRSVP")
+	beego.Router("/events/:event_id/checkin", eventController, "post:CheckIn")
+
+	// スタンプラリー
+	rallyController := controllers.NewRallyController()
+	beego.Router("/rallies", rallyController, "get:GetRallies;post:CreateRally")
+	beego.Router("/rallies/:rally_id", rallyController, "get:GetRally;put:UpdateRally;delete:DeleteRally")
+	beego.Router("/rallies/:rally_id/join", rallyController, "post:JoinRally")
+	beego.Router("/rallies/:rally_id/progress", rallyController, "get:GetProgress")
+	beego.Router("/rallies/:rally_id/rewards/redeem", rallyController, "post:RedeemReward")
+
+	// 醸造所管理者
+	breweryManagerController := controllers.NewBreweryManagerController()
+	beego.Router("/breweries/:brewery_id/managers", breweryManagerController, "get:GetManagers;post:AssignManager")
+	beego.Router("/breweries/:brewery_id/managers/:user_profile_id", breweryManagerController, "delete:RevokeManager")
+	beego.Router("/manager/breweries", breweryManagerController, "get:GetManagedBreweries")
 
 	// 訪問・チェックイン
 	visitController := controllers.NewVisitController()
 	beego.Router("/checkin", visitController, "post:CheckIn")
+	beego.Router("/checkin/qr", visitController, "post:CheckInWithQR")
 	beego.Router("/visits", visitController, "get:GetVisits")
 	beego.Router("/visits/:visit_id", visitController, "get:GetVisit")
+	checkinCodeController := controllers.NewCheckinCodeController()
+	beego.Router("/breweries/:brewery_id/checkin-code", checkinCodeController, "get:GetCode")
+	beego.Router("/breweries/:brewery_id/checkin-code/rotate", checkinCodeController, "post:RotateSecret")
+
+	// 訪問中に飲んだビールの記録
+	beerLogController := controllers.NewBeerLogController()
+	beego.Router("/visits/:visit_id/beers", beerLogController, "post:AddBeerLog")
+	beego.Router("/visits/:visit_id/beers/:beer_log_id", beerLogController, "put:UpdateBeerLog;delete:DeleteBeerLog")
 }
 
 // Handler Lambda ハンドラー関数
@@ -109,9 +219,28 @@ func getEnvOrDefault(key, defaultValue string) string {
 
 func main() {
 	// Lambda 環境かどうかをチェック
+	// BATCH_JOB を指定した場合は API ではなくバッチジョブとして実行する
+	batchJob := os.Getenv("BATCH_JOB")
+
 	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+		if batchJob != "" {
+			// Lambda 環境でバッチジョブを実行（EventBridge のスケジュールから起動する）
+			if err := startBatchJob(batchJob); err != nil {
+				utils.Logger.WithError(err).Fatal("Batch job failed to start")
+			}
+			return
+		}
+		// インスタンス間で共通のカーソル署名鍵が必要なため、未設定の場合は起動しない
+		if err := utils.CheckPageCursorKey(); err != nil {
+			utils.Logger.WithError(err).Fatal("Pagination cursor secret is not configured")
+		}
 		// Lambda 環境で実行
 		lambda.Start(Handler)
+	} else if batchJob != "" {
+		// ローカル開発環境でバッチジョブを1回実行
+		if err := runBatchJobOnce(batchJob); err != nil {
+			utils.Logger.WithError(err).Fatal("Batch job failed")
+		}
 	} else {
 		// ローカル開発環境で実行
 		utils.Logger.Info("Running in local development mode")
@@ -122,6 +251,19 @@ func main() {
 			utils.Logger.WithError(err).Fatal("Table creation failed")
 		}
 
+		// ビアスタイルの初期データ投入（テーブル作成後に実行する。失敗してもAPIは起動する）
+		// Lambda 環境では起動時に投入せず、デプロイ時にバッチジョブ（BATCH_JOB=seed_beer_styles）で投入する
+		if beego.AppConfig.DefaultBool("style.seed_on_startup", true) {
+			// 失敗した場合のログは SeedStylesJobHandler が出力する
+			_, _ = SeedStylesJobHandler(context.Background())
+		}
+
+		// バッジ定義の初期データ投入（同上。Lambda 環境ではバッチジョブ BATCH_JOB=seed_badges で投入する）
+		if beego.AppConfig.DefaultBool("badge.seed_on_startup", true) {
+			// 失敗した場合のログは SeedBadgesJobHandler が出力する
+			_, _ = SeedBadgesJobHandler(context.Background())
+		}
+
 		// テスト用エンドポイント（開発環境のみ）
 		testController := controllers.NewTestController()
 		beego.Router("/test/generate-token", testController, "get:GenerateToken")
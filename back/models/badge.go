@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+type Badge struct {
+	Id          int       `orm:"auto" json:"id"`
+	Code        string    `orm:"unique;size(50)" json:"code"`
+	Name        string    `orm:"size(100)" json:"name"`
+	Description string    `orm:"null;type(text)" json:"description"`
+	RuleType    string    `orm:"size(50)" json:"rule_type"`
+	Threshold   int       `orm:"default(1)" json:"threshold"`
+	Prefecture  string    `orm:"null;size(10)" json:"prefecture"`
+	IconURL     string    `orm:"null;size(512)" json:"icon_url"`
+	SortOrder   int       `orm:"default(0)" json:"sort_order"`
+	CreatedAt   time.Time `orm:"auto_now_add;type(datetime)" json:"created_at"`
+}
+
+type UserBadge struct {
+	Id          int          `orm:"auto" json:"id"`
+	UserProfile *UserProfile `orm:"rel(fk)" json:"user_profile"`
+	Badge       *Badge       `orm:"rel(fk)" json:"badge"`
+	Visit       *Visit       `orm:"null;rel(fk);on_delete(set_null)" json:"visit"`
+	AwardedAt   time.Time    `orm:"auto_now_add;type(datetime)" json:"awarded_at"`
+}
+
+// TableUnique 同一バッジの重複獲得を防ぐ
+func (m *UserBadge) TableUnique() [][]string {
+	return [][]string{
+		{"UserProfile", "Badge"},
+	}
+}
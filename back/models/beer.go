@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+type Beer struct {
+	Id             int        `orm:"auto" json:"id"`
+	Brewery        *Brewery   `orm:"rel(fk);on_delete(do_nothing)" json:"brewery"`
+	Name           string     `orm:"size(255)" json:"name"`
+	Style          string     `orm:"null;size(100)" json:"style"`
+	Abv            *float64   `orm:"null;digits(5);decimals(2)" json:"abv"`
+	Ibu            *int       `orm:"null" json:"ibu"`
+	Description    string     `orm:"null;type(text)" json:"description"`
+	IsSeasonal     bool       `orm:"default(false)" json:"is_seasonal"`
+	IsLimited      bool       `orm:"default(false)" json:"is_limited"`
+	AvailableFrom  *time.Time `orm:"null;type(date)" json:"available_from"`
+	AvailableUntil *time.Time `orm:"null;type(date)" json:"available_until"`
+	CreatedAt      time.Time  `orm:"auto_now_add;type(datetime)" json:"created_at"`
+	UpdatedAt      time.Time  `orm:"auto_now;type(datetime)" json:"updated_at"`
+}
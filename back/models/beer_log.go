@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type BeerLog struct {
+	Id          int       `orm:"auto" json:"id"`
+	Visit       *Visit    `orm:"rel(fk);on_delete(cascade)" json:"visit"`
+	Beer        *Beer     `orm:"null;rel(fk);on_delete(set_null)" json:"beer"`
+	BeerName    string    `orm:"size(255)" json:"beer_name"`
+	ServingType string    `orm:"null;size(20)" json:"serving_type"`
+	Rating      *float64  `orm:"null;digits(3);decimals(2)" json:"rating"`
+	Notes       string    `orm:"null;type(text)" json:"notes"`
+	FlavorTags  string    `orm:"null;size(512)" json:"flavor_tags"` // カンマ区切り
+	CreatedAt   time.Time `orm:"auto_now_add;type(datetime)" json:"created_at"`
+	UpdatedAt   time.Time `orm:"auto_now;type(datetime)" json:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package models
+
+type BeerStyle struct {
+	Id        int        `orm:"auto" json:"id"`
+	Code      string     `orm:"unique;size(50)" json:"code"`
+	Parent    *BeerStyle `orm:"null;rel(fk);on_delete(set_null)" json:"parent"`
+	NameJa    string     `orm:"size(100)" json:"name_ja"`
+	NameEn    string     `orm:"size(100)" json:"name_en"`
+	SortOrder int        `orm:"default(0)" json:"sort_order"`
+}
+
+// BreweryStyle 醸造所とビアスタイルの紐付け
+type BreweryStyle struct {
+	Id        int        `orm:"auto" json:"id"`
+	Brewery   *Brewery   `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+	BeerStyle *BeerStyle `orm:"rel(fk);on_delete(cascade)" json:"beer_style"`
+}
+
+// TableUnique 同一スタイルの重複登録を防ぐ
+func (m *BreweryStyle) TableUnique() [][]string {
+	return [][]string{
+		{"Brewery", "BeerStyle"},
+	}
+}
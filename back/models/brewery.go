@@ -5,12 +5,15 @@ import (
 )
 
 type Brewery struct {
-	Id          int       `orm:"auto" json:"id"`
-	Name        string    `orm:"size(255)" json:"name"`
-	Address     string    `orm:"null;size(512)" json:"address"`
-	Description string    `orm:"null;type(text)" json:"description"`
-	Latitude    float64   `orm:"digits(10);decimals(7)" json:"latitude"`
-	Longitude   float64   `orm:"digits(10);decimals(7)" json:"longitude"`
-	CreatedAt   time.Time `orm:"auto_now_add;type(datetime)" json:"created_at"`
-	UpdatedAt   time.Time `orm:"auto_now;type(datetime)" json:"updated_at"`
+	Id          int        `orm:"auto" json:"id"`
+	Name        string     `orm:"size(255)" json:"name"`
+	Address     string     `orm:"null;size(512)" json:"address"`
+	Prefecture  string     `orm:"null;size(10)" json:"prefecture"`
+	Description string     `orm:"null;type(text)" json:"description"`
+	Latitude    float64    `orm:"digits(10);decimals(7)" json:"latitude"`
+	Longitude   float64    `orm:"digits(10);decimals(7)" json:"longitude"`
+	Status      string     `orm:"size(20);default(active)" json:"status"`
+	DeletedAt   *time.Time `orm:"null;type(datetime)" json:"deleted_at"`
+	CreatedAt   time.Time  `orm:"auto_now_add;type(datetime)" json:"created_at"`
+	UpdatedAt   time.Time  `orm:"auto_now;type(datetime)" json:"updated_at"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// BreweryActivity フォローしている醸造所のフィードに掲載するアクティビティ
+type BreweryActivity struct {
+	Id             int       `orm:"auto" json:"id"`
+	Brewery        *Brewery  `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+	ActivityType   string    `orm:"size(30)" json:"activity_type"`
+	RelatedBrewery *Brewery  `orm:"null;rel(fk);on_delete(cascade)" json:"related_brewery"`
+	Milestone      *int      `orm:"null" json:"milestone"`
+	DedupeKey      string    `orm:"size(100);null;unique" json:"-"`
+	CreatedAt      time.Time `orm:"auto_now_add;type(datetime)" json:"created_at"`
+}
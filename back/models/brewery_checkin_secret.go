@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// BreweryCheckinSecret 醸造所ごとのQRチェックイン用シークレット（掲示するコードはこのシークレットから算出する）
+type BreweryCheckinSecret struct {
+	Id        int       `orm:"auto" json:"id"`
+	Brewery   *Brewery  `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+	Secret    string    `orm:"size(64)" json:"-"`
+	RotatedAt time.Time `orm:"type(datetime)" json:"rotated_at"`
+}
+
+// TableUnique シークレットは醸造所ごとに1件
+func (m *BreweryCheckinSecret) TableUnique() [][]string {
+	return [][]string{
+		{"Brewery"},
+	}
+}
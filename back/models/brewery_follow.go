@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// BreweryFollow ユーザーがフォローしている醸造所
+type BreweryFollow struct {
+	Id          int          `orm:"auto" json:"id"`
+	UserProfile *UserProfile `orm:"rel(fk);on_delete(cascade)" json:"user_profile"`
+	Brewery     *Brewery     `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+	CreatedAt   time.Time    `orm:"auto_now_add;type(datetime)" json:"created_at"`
+}
+
+// TableUnique 同一醸造所の重複フォローを防ぐ
+func (m *BreweryFollow) TableUnique() [][]string {
+	return [][]string{
+		{"UserProfile", "Brewery"},
+	}
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+type BreweryManager struct {
+	Id          int          `orm:"auto" json:"id"`
+	UserProfile *UserProfile `orm:"rel(fk)" json:"user_profile"`
+	Brewery     *Brewery     `orm:"rel(fk)" json:"brewery"`
+	CreatedAt   time.Time    `orm:"auto_now_add;type(datetime)" json:"created_at"`
+}
+
+// TableUnique 同一ユーザー・同一醸造所の重複登録を防ぐ
+func (m *BreweryManager) TableUnique() [][]string {
+	return [][]string{
+		{"UserProfile", "Brewery"},
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// BreweryPost 醸造所管理者がファンに向けて発信する投稿
+type BreweryPost struct {
+	Id         int          `orm:"auto" json:"id"`
+	Brewery    *Brewery     `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+	Author     *UserProfile `orm:"null;rel(fk);on_delete(set_null)" json:"author"`
+	Title      string       `orm:"size(200)" json:"title"`
+	Body       string       `orm:"type(text)" json:"body"`
+	PublishAt  time.Time    `orm:"type(datetime)" json:"publish_at"`
+	IsPinned   bool         `orm:"default(false)" json:"is_pinned"`
+	Visibility string       `orm:"size(20);default(public)" json:"visibility"`
+	MinFanRank string       `orm:"size(20);null" json:"min_fan_rank"`
+	CreatedAt  time.Time    `orm:"auto_now_add;type(datetime)" json:"created_at"`
+	UpdatedAt  time.Time    `orm:"auto_now;type(datetime)" json:"updated_at"`
+}
+
+// BreweryPostImage 投稿に添付する画像
+type BreweryPostImage struct {
+	Id        int          `orm:"auto" json:"id"`
+	Post      *BreweryPost `orm:"rel(fk);on_delete(cascade)" json:"post"`
+	Url       string       `orm:"size(512)" json:"url"`
+	SortOrder int          `orm:"default(0)" json:"sort_order"`
+}
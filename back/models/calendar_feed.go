@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarFeed ユーザーの訪問履歴のカレンダー購読設定（購読URLのトークンはハッシュのみ保存する）
+type CalendarFeed struct {
+	Id          int          `orm:"auto" json:"id"`
+	UserProfile *UserProfile `orm:"rel(fk);on_delete(cascade)" json:"user_profile"`
+	TokenHash   string       `orm:"size(64);unique" json:"token_hash"`
+	RotatedAt   time.Time    `orm:"type(datetime)" json:"rotated_at"`
+}
+
+// TableUnique 購読設定はユーザーごとに1件
+func (m *CalendarFeed) TableUnique() [][]string {
+	return [][]string{
+		{"UserProfile"},
+	}
+}
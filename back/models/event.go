@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// Event 醸造所が開催するイベント
+type Event struct {
+	Id          int       `orm:"auto" json:"id"`
+	Brewery     *Brewery  `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+	Title       string    `orm:"size(200)" json:"title"`
+	Description string    `orm:"type(text);null" json:"description"`
+	Location    string    `orm:"size(255);null" json:"location"`
+	StartAt     time.Time `orm:"type(datetime)" json:"start_at"`
+	EndAt       time.Time `orm:"type(datetime)" json:"end_at"`
+	TimeZone    string    `orm:"size(64)" json:"time_zone"`
+	Capacity    int       `json:"capacity"`
+	CreatedAt   time.Time `orm:"auto_now_add;type(datetime)" json:"created_at"`
+	UpdatedAt   time.Time `orm:"auto_now;type(datetime)" json:"updated_at"`
+}
+
+// EventRsvp イベントへの参加申込
+type EventRsvp struct {
+	Id          int          `orm:"auto" json:"id"`
+	Event       *Event       `orm:"rel(fk);on_delete(cascade)" json:"event"`
+	UserProfile *UserProfile `orm:"rel(fk);on_delete(cascade)" json:"user_profile"`
+	Status      string       `orm:"size(20)" json:"status"`
+	AttendedAt  *time.Time   `orm:"null;type(datetime)" json:"attended_at"`
+	CreatedAt   time.Time    `orm:"auto_now_add;type(datetime)" json:"created_at"`
+	UpdatedAt   time.Time    `orm:"auto_now;type(datetime)" json:"updated_at"`
+}
+
+// TableUnique 同一イベントへの重複申込を防ぐ
+func (m *EventRsvp) TableUnique() [][]string {
+	return [][]string{
+		{"Event", "UserProfile"},
+	}
+}
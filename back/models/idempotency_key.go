@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyKey Idempotency-Key ヘッダー付きリクエストのレスポンス保存用テーブル
+// 読み書きは utils.IdempotencyMiddleware が生SQLで行う
+type IdempotencyKey struct {
+	Id                  int       `orm:"auto" json:"id"`
+	UserSub             string    `orm:"size(255)" json:"user_sub"`
+	IdempotencyKey      string    `orm:"size(255)" json:"idempotency_key"`
+	RequestHash         string    `orm:"size(64)" json:"request_hash"`
+	Status              string    `orm:"size(20)" json:"status"`
+	ResponseStatus      int       `orm:"null" json:"response_status"`
+	ResponseBody        string    `orm:"null;type(text)" json:"response_body"`
+	ResponseContentType string    `orm:"null;size(255)" json:"response_content_type"`
+	CreatedAt           time.Time `orm:"type(datetime)" json:"created_at"`
+	ExpiresAt           time.Time `orm:"type(datetime);index" json:"expires_at"`
+}
+
+// TableUnique 同一ユーザー・同一キーの重複登録を防ぐ
+func (m *IdempotencyKey) TableUnique() [][]string {
+	return [][]string{
+		{"UserSub", "IdempotencyKey"},
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+)
+
+// Rally 複数の醸造所を巡るスタンプラリー
+type Rally struct {
+	Id                int       `orm:"auto" json:"id"`
+	Title             string    `orm:"size(200)" json:"title"`
+	Description       string    `orm:"type(text);null" json:"description"`
+	StartAt           time.Time `orm:"type(datetime)" json:"start_at"`
+	EndAt             time.Time `orm:"type(datetime)" json:"end_at"`
+	RequiredCount     int       `json:"required_count"`
+	RewardDescription string    `orm:"type(text);null" json:"reward_description"`
+	CreatedAt         time.Time `orm:"auto_now_add;type(datetime)" json:"created_at"`
+	UpdatedAt         time.Time `orm:"auto_now;type(datetime)" json:"updated_at"`
+}
+
+// RallyBrewery スタンプラリーの対象醸造所
+type RallyBrewery struct {
+	Id      int      `orm:"auto" json:"id"`
+	Rally   *Rally   `orm:"rel(fk);on_delete(cascade)" json:"rally"`
+	Brewery *Brewery `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+}
+
+// TableUnique 同一醸造所の重複登録を防ぐ
+func (m *RallyBrewery) TableUnique() [][]string {
+	return [][]string{
+		{"Rally", "Brewery"},
+	}
+}
+
+// RallyParticipant スタンプラリーの参加者
+type RallyParticipant struct {
+	Id               int          `orm:"auto" json:"id"`
+	Rally            *Rally       `orm:"rel(fk);on_delete(cascade)" json:"rally"`
+	UserProfile      *UserProfile `orm:"rel(fk);on_delete(cascade)" json:"user_profile"`
+	JoinedAt         time.Time    `orm:"type(datetime)" json:"joined_at"`
+	CompletedAt      *time.Time   `orm:"null;type(datetime)" json:"completed_at"`
+	RewardCode       string       `orm:"size(16);null;unique" json:"reward_code"`
+	RewardRedeemedAt *time.Time   `orm:"null;type(datetime)" json:"reward_redeemed_at"`
+}
+
+// TableUnique 同一スタンプラリーへの重複参加を防ぐ
+func (m *RallyParticipant) TableUnique() [][]string {
+	return [][]string{
+		{"Rally", "UserProfile"},
+	}
+}
+
+// RallyStamp スタンプラリーで獲得したスタンプ（醸造所ごとに1つ）
+type RallyStamp struct {
+	Id          int          `orm:"auto" json:"id"`
+	Rally       *Rally       `orm:"rel(fk);on_delete(cascade)" json:"rally"`
+	UserProfile *UserProfile `orm:"rel(fk);on_delete(cascade)" json:"user_profile"`
+	Brewery     *Brewery     `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+	Visit       *Visit       `orm:"null;rel(fk);on_delete(set_null)" json:"visit"`
+	StampedAt   time.Time    `orm:"type(datetime)" json:"stamped_at"`
+}
+
+// TableUnique 同一醸造所での重複スタンプを防ぐ
+func (m *RallyStamp) TableUnique() [][]string {
+	return [][]string{
+		{"Rally", "UserProfile", "Brewery"},
+	}
+}
@@ -5,10 +5,14 @@ import (
 )
 
 type UserProfile struct {
-	Id          int       `orm:"auto" json:"id"`
-	CognitoSub  string    `orm:"unique;size(255)" json:"cognito_sub"`
-	DisplayName string    `orm:"null;size(255)" json:"display_name"`
-	IconURL     string    `orm:"null;size(512)" json:"icon_url"`
-	CreatedAt   time.Time `orm:"auto_now_add;type(datetime)" json:"created_at"`
-	UpdatedAt   time.Time `orm:"auto_now;type(datetime)" json:"updated_at"`
+	Id                     int       `orm:"auto" json:"id"`
+	CognitoSub             string    `orm:"unique;size(255)" json:"cognito_sub"`
+	DisplayName            string    `orm:"null;size(255)" json:"display_name"`
+	IconURL                string    `orm:"null;size(512)" json:"icon_url"`
+	Handle                 *string   `orm:"null;unique;size(30)" json:"handle"` // 未設定の場合は NULL（一意制約の対象外）
+	ProfilePublic          bool      `orm:"default(false)" json:"profile_public"`
+	HideVisitTimes         bool      `orm:"default(false)" json:"hide_visit_times"`
+	VisitPublishDelayHours int       `orm:"default(0)" json:"visit_publish_delay_hours"`
+	CreatedAt              time.Time `orm:"auto_now_add;type(datetime)" json:"created_at"`
+	UpdatedAt              time.Time `orm:"auto_now;type(datetime)" json:"updated_at"`
 }
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// UserRecap ユーザーの年間の振り返り（年が明けた後にバッチで集計した結果を保存する）
+type UserRecap struct {
+	Id                    int          `orm:"auto" json:"id"`
+	UserProfile           *UserProfile `orm:"rel(fk);on_delete(cascade)" json:"user_profile"`
+	Year                  int          `json:"year"`
+	TotalVisits           int          `orm:"default(0)" json:"total_visits"`
+	BreweriesVisited      int          `orm:"default(0)" json:"breweries_visited"`
+	NewBreweries          int          `orm:"default(0)" json:"new_breweries"`
+	PrefecturesVisited    int          `orm:"default(0)" json:"prefectures_visited"`
+	BusiestMonth          int          `orm:"default(0)" json:"busiest_month"` // 1〜12（訪問がない場合は0）
+	BusiestMonthVisits    int          `orm:"default(0)" json:"busiest_month_visits"`
+	FavoriteBrewery       *Brewery     `orm:"null;rel(fk);on_delete(set_null)" json:"favorite_brewery"`
+	FavoriteBreweryVisits int          `orm:"default(0)" json:"favorite_brewery_visits"`
+	FarthestFromBrewery   *Brewery     `orm:"null;rel(fk);on_delete(set_null)" json:"farthest_from_brewery"`
+	FarthestToBrewery     *Brewery     `orm:"null;rel(fk);on_delete(set_null)" json:"farthest_to_brewery"`
+	FarthestDistanceM     float64      `orm:"default(0)" json:"farthest_distance_m"`
+	LongestWeekStreak     int          `orm:"default(0)" json:"longest_week_streak"`
+	BadgesEarned          int          `orm:"default(0)" json:"badges_earned"`
+	GeneratedAt           time.Time    `orm:"type(datetime)" json:"generated_at"`
+}
+
+// TableUnique 振り返りはユーザー・年ごとに1件
+func (m *UserRecap) TableUnique() [][]string {
+	return [][]string{
+		{"UserProfile", "Year"},
+	}
+}
@@ -7,6 +7,15 @@ import (
 type Visit struct {
 	Id          int          `orm:"auto" json:"id"`
 	UserProfile *UserProfile `orm:"rel(fk)" json:"user_profile"`
-	Brewery     *Brewery     `orm:"rel(fk)" json:"brewery"`
-	VisitedAt   time.Time    `orm:"auto_now_add;type(datetime)" json:"visited_at"`
+	Brewery     *Brewery     `orm:"rel(fk);on_delete(do_nothing)" json:"brewery"`
+	// チェックイン方法（gps: 位置情報 / qr: 醸造所に掲示されたQRコード）
+	CheckinMethod string `orm:"size(10);default(gps)" json:"checkin_method"`
+	// チェックイン時のGPS証跡（記録開始前の訪問は NULL）
+	CheckinLatitude  *float64  `orm:"null;digits(10);decimals(7)" json:"checkin_latitude"`
+	CheckinLongitude *float64  `orm:"null;digits(10);decimals(7)" json:"checkin_longitude"`
+	GpsAccuracyM     *float64  `orm:"null" json:"gps_accuracy_m"`
+	DistanceM        *float64  `orm:"null" json:"distance_m"`
+	CheckinRadiusM   *float64  `orm:"null" json:"checkin_radius_m"`
+	ClientVersion    string    `orm:"null;size(100)" json:"client_version"`
+	VisitedAt        time.Time `orm:"auto_now_add;type(datetime)" json:"visited_at"`
 }
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// WishlistItem 行きたいリストに登録した醸造所
+type WishlistItem struct {
+	Id          int          `orm:"auto" json:"id"`
+	UserProfile *UserProfile `orm:"rel(fk);on_delete(cascade)" json:"user_profile"`
+	Brewery     *Brewery     `orm:"rel(fk);on_delete(cascade)" json:"brewery"`
+	VisitedAt   *time.Time   `orm:"null;type(datetime)" json:"visited_at"`
+	CreatedAt   time.Time    `orm:"auto_now_add;type(datetime)" json:"created_at"`
+}
+
+// TableUnique 同一醸造所の重複登録を防ぐ
+func (m *WishlistItem) TableUnique() [][]string {
+	return [][]string{
+		{"UserProfile", "Brewery"},
+	}
+}
@@ -0,0 +1,424 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+// 認証関連のエラー定義
+var (
+	ErrTokenMalformed       = errors.New("token is malformed")
+	ErrTokenExpired         = errors.New("token has expired")
+	ErrTokenInvalid         = errors.New("token is invalid")
+	ErrCognitoNotConfigured = errors.New("cognito user pool is not configured")
+)
+
+// AuthClaimsKey 検証済みクレームをコンテキストに保存するためのキー
+type AuthClaimsKey struct{}
+
+// AuthErrorKey トークン検証エラーをコンテキストに保存するためのキー
+type AuthErrorKey struct{}
+
+// AuthClaims 検証済みのCognitoトークンから取り出したクレーム
+type AuthClaims struct {
+	Sub      string   `json:"sub"`
+	Email    string   `json:"email,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	TokenUse string   `json:"token_use"`
+}
+
+// HasGroup 指定されたグループに所属しているかどうかを判定する
+func (c *AuthClaims) HasGroup(groups ...string) bool {
+	for _, own := range c.Groups {
+		for _, group := range groups {
+			if own == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetAuthClaimsToContext コンテキストに検証済みクレームを設定する
+func SetAuthClaimsToContext(ctx context.Context, claims *AuthClaims) context.Context {
+	return context.WithValue(ctx, AuthClaimsKey{}, claims)
+}
+
+// GetAuthClaimsFromContext コンテキストから検証済みクレームを取得する
+func GetAuthClaimsFromContext(ctx context.Context) *AuthClaims {
+	if claims, ok := ctx.Value(AuthClaimsKey{}).(*AuthClaims); ok {
+		return claims
+	}
+	return nil
+}
+
+// SetAuthErrorToContext コンテキストにトークン検証エラーを設定する
+func SetAuthErrorToContext(ctx context.Context, err error) context.Context {
+	return context.WithValue(ctx, AuthErrorKey{}, err)
+}
+
+// GetAuthErrorFromContext コンテキストからトークン検証エラーを取得する
+func GetAuthErrorFromContext(ctx context.Context) error {
+	if err, ok := ctx.Value(AuthErrorKey{}).(error); ok {
+		return err
+	}
+	return nil
+}
+
+// cognitoJWTClaims Cognitoが発行するID/アクセストークンのペイロード
+type cognitoJWTClaims struct {
+	Sub      string   `json:"sub"`
+	Iss      string   `json:"iss"`
+	Aud      string   `json:"aud"`
+	ClientID string   `json:"client_id"`
+	TokenUse string   `json:"token_use"`
+	Exp      int64    `json:"exp"`
+	Iat      int64    `json:"iat"`
+	Email    string   `json:"email"`
+	Groups   []string `json:"cognito:groups"`
+}
+
+// jwtHeader JWTヘッダー
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jsonWebKey JWKSに含まれる公開鍵
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// jsonWebKeySet JWKSドキュメント
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// CognitoJWTVerifier Cognitoユーザープールが発行したJWTを検証する
+type CognitoJWTVerifier struct {
+	region     string
+	userPoolID string
+	clientID   string
+	jwksFile   string
+	cacheTTL   time.Duration
+	clockSkew  time.Duration
+	httpClient *http.Client
+
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	fetching  *jwksFetch // 実行中のJWKSの取得（同時に複数のリクエストから取得しないよう共有する）
+	mutex     sync.RWMutex
+}
+
+// jwksFetch 実行中のJWKSの取得。done が閉じた後に err を参照できる
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// NewCognitoJWTVerifier 新しいCognitoJWTVerifierインスタンスを作成する
+// jwksFile が指定された場合はネットワークではなくローカルファイルから公開鍵を読み込む
+func NewCognitoJWTVerifier(region, userPoolID, clientID, jwksFile string) *CognitoJWTVerifier {
+	return &CognitoJWTVerifier{
+		region:     strings.TrimSpace(region),
+		userPoolID: strings.TrimSpace(userPoolID),
+		clientID:   strings.TrimSpace(clientID),
+		jwksFile:   strings.TrimSpace(jwksFile),
+		cacheTTL:   time.Hour,
+		clockSkew:  time.Minute,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		keys:       make(map[string]*rsa.PublicKey),
+	}
+}
+
+// Issuer 期待するトークン発行者を返す
+func (v *CognitoJWTVerifier) Issuer() string {
+	return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", v.region, v.userPoolID)
+}
+
+// jwksURL JWKSの取得先URLを返す
+func (v *CognitoJWTVerifier) jwksURL() string {
+	return v.Issuer() + "/.well-known/jwks.json"
+}
+
+// IsConfigured ユーザープールの設定が揃っているかどうかを判定する
+func (v *CognitoJWTVerifier) IsConfigured() bool {
+	return v.region != "" && v.userPoolID != "" && v.clientID != ""
+}
+
+// Verify トークンの署名とクレームを検証し、検証済みクレームを返す
+func (v *CognitoJWTVerifier) Verify(ctx context.Context, token string) (*AuthClaims, error) {
+	if !v.IsConfigured() {
+		return nil, ErrCognitoNotConfigured
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, ErrTokenMalformed
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrTokenInvalid, header.Alg)
+	}
+
+	key, err := v.getKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 署名検証
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: signature verification failed", ErrTokenInvalid)
+	}
+
+	var claims cognitoJWTClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	if err := v.validateClaims(&claims); err != nil {
+		return nil, err
+	}
+
+	return &AuthClaims{
+		Sub:      claims.Sub,
+		Email:    claims.Email,
+		Groups:   claims.Groups,
+		TokenUse: claims.TokenUse,
+	}, nil
+}
+
+// validateClaims 発行者・対象・用途・有効期限を検証する
+func (v *CognitoJWTVerifier) validateClaims(claims *cognitoJWTClaims) error {
+	if claims.Iss != v.Issuer() {
+		return fmt.Errorf("%w: unexpected issuer", ErrTokenInvalid)
+	}
+	if claims.Sub == "" {
+		return fmt.Errorf("%w: sub claim is missing", ErrTokenInvalid)
+	}
+
+	// IDトークンは aud、アクセストークンは client_id にアプリクライアントIDが入る
+	switch claims.TokenUse {
+	case "id":
+		if claims.Aud != v.clientID {
+			return fmt.Errorf("%w: unexpected audience", ErrTokenInvalid)
+		}
+	case "access":
+		if claims.ClientID != v.clientID {
+			return fmt.Errorf("%w: unexpected client_id", ErrTokenInvalid)
+		}
+	default:
+		return fmt.Errorf("%w: unexpected token_use %q", ErrTokenInvalid, claims.TokenUse)
+	}
+
+	now := time.Now()
+	if claims.Exp == 0 || now.After(time.Unix(claims.Exp, 0).Add(v.clockSkew)) {
+		return ErrTokenExpired
+	}
+	if claims.Iat != 0 && time.Unix(claims.Iat, 0).After(now.Add(v.clockSkew)) {
+		return fmt.Errorf("%w: token issued in the future", ErrTokenInvalid)
+	}
+
+	return nil
+}
+
+// getKey kidに対応する公開鍵を取得する（キャッシュ切れや未知のkidの場合は再取得する）
+func (v *CognitoJWTVerifier) getKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mutex.RLock()
+	key, exists := v.keys[kid]
+	fresh := time.Since(v.fetchedAt) < v.cacheTTL
+	v.mutex.RUnlock()
+
+	if exists && fresh {
+		return key, nil
+	}
+
+	if err := v.refreshKeys(ctx); err != nil {
+		// 取得に失敗した場合でもキャッシュ済みの鍵があれば利用する
+		if exists {
+			LogWarn(ctx, "Failed to refresh JWKS, using cached key")
+			return key, nil
+		}
+		return nil, err
+	}
+
+	v.mutex.RLock()
+	key, exists = v.keys[kid]
+	v.mutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: unknown key id", ErrTokenInvalid)
+	}
+
+	return key, nil
+}
+
+// refreshKeys JWKSを読み込み、公開鍵キャッシュを更新する
+// 取得中はロックを保持せず、キャッシュ済みの鍵での検証を妨げない。同時に呼ばれた場合は実行中の取得の完了を待つ
+func (v *CognitoJWTVerifier) refreshKeys(ctx context.Context) error {
+	v.mutex.Lock()
+	// 未知のkidによる連続再取得を抑制する
+	if time.Since(v.fetchedAt) < 10*time.Second {
+		v.mutex.Unlock()
+		return nil
+	}
+	if fetch := v.fetching; fetch != nil {
+		v.mutex.Unlock()
+		select {
+		case <-fetch.done:
+			return fetch.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	fetch := &jwksFetch{done: make(chan struct{})}
+	v.fetching = fetch
+	v.mutex.Unlock()
+
+	// 取得を開始したリクエストがキャンセルされても、待機中の他のリクエストのために取得を続ける（HTTPクライアントのタイムアウトで打ち切る）
+	keys, err := v.fetchKeys(context.WithoutCancel(ctx))
+
+	v.mutex.Lock()
+	if err == nil {
+		v.keys = keys
+		v.fetchedAt = time.Now()
+	}
+	v.fetching = nil
+	v.mutex.Unlock()
+
+	fetch.err = err
+	close(fetch.done)
+	return err
+}
+
+// fetchKeys JWKSを読み込み、RSA公開鍵をkidごとに取り出す
+func (v *CognitoJWTVerifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	data, err := v.loadJWKS(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWKS: %w", err)
+	}
+
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			LogWarn(ctx, "Skipping invalid JWK", map[string]interface{}{
+				"kid": jwk.Kid,
+			})
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// loadJWKS ローカルファイルまたはCognitoのエンドポイントからJWKSを読み込む
+func (v *CognitoJWTVerifier) loadJWKS(ctx context.Context) ([]byte, error) {
+	if v.jwksFile != "" {
+		return os.ReadFile(v.jwksFile)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// publicKey JWKからRSA公開鍵を生成する
+func (k *jsonWebKey) publicKey() (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(big.Int).SetBytes(eBytes)
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid RSA exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(e.Int64()),
+	}, nil
+}
+
+// decodeJWTSegment Base64URLエンコードされたJWTセグメントをデコードする
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// グローバルインスタンス（シングルトンパターン）
+var (
+	cognitoVerifier     *CognitoJWTVerifier
+	cognitoVerifierOnce sync.Once
+)
+
+// GetCognitoJWTVerifier app.confの設定からCognitoJWTVerifierのシングルトンインスタンスを返す
+func GetCognitoJWTVerifier() *CognitoJWTVerifier {
+	cognitoVerifierOnce.Do(func() {
+		cognitoVerifier = NewCognitoJWTVerifier(
+			beego.AppConfig.String("cognito.region"),
+			beego.AppConfig.String("cognito.user_pool_id"),
+			beego.AppConfig.String("cognito.client_id"),
+			beego.AppConfig.String("cognito.jwks_file"),
+		)
+	})
+	return cognitoVerifier
+}
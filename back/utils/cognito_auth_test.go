@@ -0,0 +1,289 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testCognitoRegion     = "ap-northeast-1"
+	testCognitoUserPoolID = "ap-northeast-1_TEST"
+	testCognitoClientID   = "test-client-id"
+	testCognitoKeyID      = "test-key"
+)
+
+var (
+	testCognitoKeysOnce sync.Once
+	testCognitoKey      *rsa.PrivateKey
+	testCognitoOtherKey *rsa.PrivateKey
+)
+
+// cognitoTestKeys テスト用のRSA鍵を生成する（生成に時間がかかるためテスト間で共有する）
+func cognitoTestKeys(t *testing.T) (*rsa.PrivateKey, *rsa.PrivateKey) {
+	t.Helper()
+	testCognitoKeysOnce.Do(func() {
+		var err error
+		if testCognitoKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+		if testCognitoOtherKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return testCognitoKey, testCognitoOtherKey
+}
+
+// jwksRoundTripper JWKSのエンドポイントの代わりに公開鍵を返す
+// release が指定された場合は、閉じられるまで応答を返さない
+type jwksRoundTripper struct {
+	body     []byte
+	release  chan struct{}
+	started  chan struct{}
+	requests int32
+}
+
+func (rt *jwksRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.AddInt32(&rt.requests, 1) == 1 && rt.started != nil {
+		close(rt.started)
+	}
+	if rt.release != nil {
+		<-rt.release
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(rt.body)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+// newTestCognitoVerifier テスト用の公開鍵を返すJWKSのエンドポイントを使用する検証器を作成する
+func newTestCognitoVerifier(t *testing.T, key *rsa.PublicKey) (*CognitoJWTVerifier, *jwksRoundTripper) {
+	t.Helper()
+	set := jsonWebKeySet{Keys: []jsonWebKey{{
+		Kid: testCognitoKeyID,
+		Kty: "RSA",
+		Alg: "RS256",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	body, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &jwksRoundTripper{body: body}
+	verifier := NewCognitoJWTVerifier(testCognitoRegion, testCognitoUserPoolID, testCognitoClientID, "")
+	verifier.httpClient = &http.Client{Transport: transport}
+	return verifier, transport
+}
+
+// signTestJWT ヘッダーとクレームからJWTを作成する（alg が RS256 以外の場合は HMAC で署名する）
+func signTestJWT(t *testing.T, key *rsa.PrivateKey, header, claims map[string]interface{}) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+
+	var signature []byte
+	if header["alg"] == "RS256" {
+		digest := sha256.Sum256([]byte(signingInput))
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		// 公開鍵をHMACの鍵に流用する、アルゴリズムの取り違えを狙ったトークン
+		mac := hmac.New(sha256.New, key.PublicKey.N.Bytes())
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// testIDTokenClaims 検証に成功するIDトークンのクレーム
+func testIDTokenClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"sub":            "user-sub",
+		"iss":            "https://cognito-idp." + testCognitoRegion + ".amazonaws.com/" + testCognitoUserPoolID,
+		"aud":            testCognitoClientID,
+		"token_use":      "id",
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"email":          "user@example.com",
+		"cognito:groups": []string{"admin"},
+	}
+}
+
+func TestCognitoJWTVerifierVerify(t *testing.T) {
+	key, otherKey := cognitoTestKeys(t)
+	now := time.Now()
+	rs256 := map[string]interface{}{"alg": "RS256", "kid": testCognitoKeyID}
+
+	with := func(overrides map[string]interface{}) map[string]interface{} {
+		claims := testIDTokenClaims(now)
+		for name, value := range overrides {
+			if value == nil {
+				delete(claims, name)
+				continue
+			}
+			claims[name] = value
+		}
+		return claims
+	}
+
+	accessClaims := with(map[string]interface{}{"token_use": "access", "aud": nil, "client_id": testCognitoClientID})
+	valid := signTestJWT(t, key, rs256, testIDTokenClaims(now))
+	validParts := strings.Split(valid, ".")
+	// 署名はそのままで、クレームを別のユーザーのものに差し替えたトークン
+	otherParts := strings.Split(signTestJWT(t, key, rs256, with(map[string]interface{}{"sub": "other-sub"})), ".")
+	tamperedPayload := validParts[0] + "." + otherParts[1] + "." + validParts[2]
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid id token", token: valid},
+		{name: "valid access token", token: signTestJWT(t, key, rs256, accessClaims)},
+		{name: "expired within clock skew", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()}))},
+		{name: "wrong issuer", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"iss": "https://cognito-idp." + testCognitoRegion + ".amazonaws.com/other-pool"})), wantErr: ErrTokenInvalid},
+		{name: "wrong audience", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"aud": "other-client"})), wantErr: ErrTokenInvalid},
+		{name: "wrong client_id on access token", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"token_use": "access", "client_id": "other-client"})), wantErr: ErrTokenInvalid},
+		{name: "unknown token_use", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"token_use": "refresh"})), wantErr: ErrTokenInvalid},
+		{name: "missing sub", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"sub": nil})), wantErr: ErrTokenInvalid},
+		{name: "expired", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"exp": now.Add(-2 * time.Minute).Unix()})), wantErr: ErrTokenExpired},
+		{name: "missing exp", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"exp": nil})), wantErr: ErrTokenExpired},
+		{name: "issued in the future", token: signTestJWT(t, key, rs256, with(map[string]interface{}{"iat": now.Add(10 * time.Minute).Unix()})), wantErr: ErrTokenInvalid},
+		{name: "alg none", token: signTestJWT(t, key, map[string]interface{}{"alg": "none", "kid": testCognitoKeyID}, testIDTokenClaims(now)), wantErr: ErrTokenInvalid},
+		{name: "alg HS256 with public key", token: signTestJWT(t, key, map[string]interface{}{"alg": "HS256", "kid": testCognitoKeyID}, testIDTokenClaims(now)), wantErr: ErrTokenInvalid},
+		{name: "signed with another key", token: signTestJWT(t, otherKey, rs256, testIDTokenClaims(now)), wantErr: ErrTokenInvalid},
+		{name: "tampered payload", token: tamperedPayload, wantErr: ErrTokenInvalid},
+		{name: "unknown kid", token: signTestJWT(t, key, map[string]interface{}{"alg": "RS256", "kid": "other-key"}, testIDTokenClaims(now)), wantErr: ErrTokenInvalid},
+		{name: "not a jwt", token: "not-a-jwt", wantErr: ErrTokenMalformed},
+		{name: "header is not base64", token: "!!!." + validParts[1] + "." + validParts[2], wantErr: ErrTokenMalformed},
+	}
+
+	verifier, _ := newTestCognitoVerifier(t, &key.PublicKey)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Verify() = %+v, %v, want %v", claims, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if claims.Sub != "user-sub" {
+				t.Errorf("Verify() sub = %q, want %q", claims.Sub, "user-sub")
+			}
+		})
+	}
+}
+
+func TestCognitoJWTVerifierNotConfigured(t *testing.T) {
+	verifier := NewCognitoJWTVerifier(testCognitoRegion, "", testCognitoClientID, "")
+	if _, err := verifier.Verify(context.Background(), "a.b.c"); !errors.Is(err, ErrCognitoNotConfigured) {
+		t.Errorf("Verify() error = %v, want ErrCognitoNotConfigured", err)
+	}
+}
+
+func TestCognitoJWTVerifierSharesConcurrentJWKSFetch(t *testing.T) {
+	key, _ := cognitoTestKeys(t)
+	verifier, transport := newTestCognitoVerifier(t, &key.PublicKey)
+	transport.started = make(chan struct{})
+	transport.release = make(chan struct{})
+	token := signTestJWT(t, key, map[string]interface{}{"alg": "RS256", "kid": testCognitoKeyID}, testIDTokenClaims(time.Now()))
+
+	const concurrency = 10
+	errs := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			_, err := verifier.Verify(context.Background(), token)
+			errs <- err
+		}()
+	}
+
+	<-transport.started
+	// 取得中の他のリクエストが完了を待つまで応答を保留する
+	time.Sleep(50 * time.Millisecond)
+	close(transport.release)
+
+	for i := 0; i < concurrency; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+	}
+	if requests := atomic.LoadInt32(&transport.requests); requests != 1 {
+		t.Errorf("JWKS requests = %d, want 1", requests)
+	}
+}
+
+func TestCognitoJWTVerifierUsesCachedKeysDuringFetch(t *testing.T) {
+	key, _ := cognitoTestKeys(t)
+	verifier, transport := newTestCognitoVerifier(t, &key.PublicKey)
+	token := signTestJWT(t, key, map[string]interface{}{"alg": "RS256", "kid": testCognitoKeyID}, testIDTokenClaims(time.Now()))
+	if _, err := verifier.Verify(context.Background(), token); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	// 連続再取得の抑制期間を過ぎた状態で、未知のkidによる再取得を保留させる
+	verifier.mutex.Lock()
+	verifier.fetchedAt = time.Now().Add(-time.Minute)
+	verifier.mutex.Unlock()
+	transport.started = make(chan struct{})
+	transport.release = make(chan struct{})
+	atomic.StoreInt32(&transport.requests, 0)
+
+	unknownKid := signTestJWT(t, key, map[string]interface{}{"alg": "RS256", "kid": "other-key"}, testIDTokenClaims(time.Now()))
+	unknownErr := make(chan error, 1)
+	go func() {
+		_, err := verifier.Verify(context.Background(), unknownKid)
+		unknownErr <- err
+	}()
+	<-transport.started
+
+	verified := make(chan error, 1)
+	go func() {
+		_, err := verifier.Verify(context.Background(), token)
+		verified <- err
+	}()
+	select {
+	case err := <-verified:
+		if err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Verify() with a cached key was blocked by the JWKS fetch")
+	}
+
+	close(transport.release)
+	if err := <-unknownErr; !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("Verify() with unknown kid error = %v, want ErrTokenInvalid", err)
+	}
+}
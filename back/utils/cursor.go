@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+// cursorSignatureBytes カーソルの署名に使用するHMACの先頭バイト数
+const cursorSignatureBytes = 16
+
+// ErrInvalidCursor カーソルの形式・署名が不正、または別の一覧で発行されたカーソル
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrCursorSecretNotConfigured Lambda 環境で pagination.cursor_secret が設定されていない
+// インスタンスごとに異なる鍵で署名すると、別のインスタンスが発行したカーソルを検証できないため必須とする
+var ErrCursorSecretNotConfigured = errors.New("pagination.cursor_secret is required when running on Lambda")
+
+// PageCursor 一覧のページ位置を表すカーソルの内容
+// クライアントには署名付きの不透明な文字列として渡し、改ざん・他の一覧での使い回しを検出する
+type PageCursor struct {
+	Scope    string `json:"s"`           // カーソルを発行した一覧（一覧の種類・ユーザーなど）
+	Backward bool   `json:"b,omitempty"` // 前のページを取得するカーソルかどうか
+	Time     int64  `json:"t"`           // 並び順のキーとなる日時（UNIX マイクロ秒）
+	ID       int    `json:"i"`           // 並び順のキーとなるID
+}
+
+var (
+	cursorKeyOnce sync.Once
+	cursorKey     []byte
+	cursorKeyErr  error
+)
+
+// NewPageCursor ページの先頭・末尾の行からカーソルを作成する
+func NewPageCursor(scope string, backward bool, at time.Time, id int) PageCursor {
+	return PageCursor{
+		Scope:    scope,
+		Backward: backward,
+		Time:     at.UnixMicro(),
+		ID:       id,
+	}
+}
+
+// At 並び順のキーとなる日時を取得する
+func (c PageCursor) At() time.Time {
+	return time.UnixMicro(c.Time)
+}
+
+// EncodePageCursor カーソルを署名付きの文字列に変換する
+func EncodePageCursor(cursor PageCursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	signature, err := signCursor(encoded)
+	if err != nil {
+		return "", err
+	}
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// DecodePageCursor 署名付きの文字列からカーソルを取り出す
+// 署名が一致しない場合や scope の一覧で発行されたカーソルでない場合は ErrInvalidCursor を返す
+// 署名鍵を用意できない場合はそのエラーを返す
+func DecodePageCursor(value, scope string) (*PageCursor, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	expectedSignature, err := signCursor(encoded)
+	if err != nil {
+		return nil, err
+	}
+	decodedSignature, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decodedSignature, expectedSignature) {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor PageCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.Scope != scope || cursor.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// signCursor カーソルの署名を算出する
+func signCursor(encoded string) ([]byte, error) {
+	key, err := pageCursorKey()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)[:cursorSignatureBytes], nil
+}
+
+// CheckPageCursorKey カーソルの署名鍵を用意できるかどうかを確認する（起動時の設定確認用）
+func CheckPageCursorKey() error {
+	_, err := pageCursorKey()
+	return err
+}
+
+// pageCursorKey 設定からカーソルの署名鍵を取得する
+// 未設定の場合、Lambda 環境では ErrCursorSecretNotConfigured を返す
+// ローカル開発環境ではプロセスごとに乱数の鍵を生成する（再起動すると以前のカーソルが無効になる）
+func pageCursorKey() ([]byte, error) {
+	cursorKeyOnce.Do(func() {
+		if secret := beego.AppConfig.String("pagination.cursor_secret"); secret != "" {
+			cursorKey = []byte(secret)
+			return
+		}
+		if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+			cursorKeyErr = ErrCursorSecretNotConfigured
+			return
+		}
+
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			cursorKeyErr = fmt.Errorf("failed to generate cursor signing key: %w", err)
+			return
+		}
+		cursorKey = key
+		LogWarn(context.Background(), "pagination.cursor_secret is not set; pagination cursors are only valid within this process")
+	})
+	return cursorKey, cursorKeyErr
+}
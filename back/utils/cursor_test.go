@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// useTestCursorKey テスト用の固定の署名鍵を使用する（設定・環境変数に依存しないようにする）
+func useTestCursorKey(t *testing.T) {
+	t.Helper()
+	cursorKeyOnce.Do(func() {})
+	cursorKey = []byte("test-cursor-secret")
+	cursorKeyErr = nil
+}
+
+// signedCursorWithKey 指定した鍵でカーソルに署名する
+func signedCursorWithKey(t *testing.T, key []byte, cursor PageCursor) string {
+	t.Helper()
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signedPayloadWithKey(key, payload)
+}
+
+// signedPayloadWithKey 指定した鍵で任意の内容に署名する
+func signedPayloadWithKey(key, payload []byte) string {
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encoded))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:cursorSignatureBytes])
+}
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	useTestCursorKey(t)
+
+	at := time.Date(2024, 5, 1, 12, 30, 15, 123456000, time.UTC)
+	tests := []struct {
+		name     string
+		backward bool
+	}{
+		{name: "next page", backward: false},
+		{name: "previous page", backward: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := EncodePageCursor(NewPageCursor("visits:1", tt.backward, at, 42))
+			if err != nil {
+				t.Fatalf("EncodePageCursor() error = %v", err)
+			}
+
+			cursor, err := DecodePageCursor(value, "visits:1")
+			if err != nil {
+				t.Fatalf("DecodePageCursor() error = %v", err)
+			}
+			if !cursor.At().Equal(at) || cursor.ID != 42 || cursor.Backward != tt.backward {
+				t.Errorf("DecodePageCursor() = %+v, want at=%v id=42 backward=%v", cursor, at, tt.backward)
+			}
+		})
+	}
+}
+
+func TestDecodePageCursorRejectsInvalidCursors(t *testing.T) {
+	useTestCursorKey(t)
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	valid, err := EncodePageCursor(NewPageCursor("visits:1", false, at, 42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, signature, _ := strings.Cut(valid, ".")
+
+	// 署名はそのままで、内容の scope を書き換えたカーソル
+	tamperedPayload, _ := json.Marshal(NewPageCursor("visits:2", false, at, 42))
+	tampered := base64.RawURLEncoding.EncodeToString(tamperedPayload) + "." + signature
+
+	// 署名の1バイトを書き換えたカーソル
+	signatureBytes, _ := base64.RawURLEncoding.DecodeString(signature)
+	signatureBytes[0] ^= 0xff
+	tamperedSignature := encoded + "." + base64.RawURLEncoding.EncodeToString(signatureBytes)
+
+	tests := []struct {
+		name  string
+		value string
+		scope string
+	}{
+		{name: "empty", value: "", scope: "visits:1"},
+		{name: "missing signature", value: encoded, scope: "visits:1"},
+		{name: "tampered payload", value: tampered, scope: "visits:2"},
+		{name: "tampered signature", value: tamperedSignature, scope: "visits:1"},
+		{name: "signature is not base64", value: encoded + ".!!!", scope: "visits:1"},
+		{name: "signed with another key", value: signedCursorWithKey(t, []byte("another-secret"), NewPageCursor("visits:1", false, at, 42)), scope: "visits:1"},
+		{name: "issued for another user", value: valid, scope: "visits:2"},
+		{name: "issued for another list", value: valid, scope: "breweries"},
+		{name: "payload is not json", value: signedPayloadWithKey(cursorKey, []byte("not json")), scope: "visits:1"},
+		{name: "non-positive id", value: signedCursorWithKey(t, cursorKey, NewPageCursor("visits:1", false, at, 0)), scope: "visits:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := DecodePageCursor(tt.value, tt.scope)
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodePageCursor() = %+v, %v, want ErrInvalidCursor", cursor, err)
+			}
+		})
+	}
+}
+
+func TestPageCursorKeyRequiresSecretOnLambda(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "mybeerlog-api")
+	cursorKeyOnce, cursorKey, cursorKeyErr = sync.Once{}, nil, nil
+	t.Cleanup(func() {
+		cursorKeyOnce, cursorKey, cursorKeyErr = sync.Once{}, nil, nil
+	})
+
+	if err := CheckPageCursorKey(); !errors.Is(err, ErrCursorSecretNotConfigured) {
+		t.Fatalf("CheckPageCursorKey() error = %v, want ErrCursorSecretNotConfigured", err)
+	}
+	if _, err := EncodePageCursor(NewPageCursor("visits:1", false, time.Now(), 1)); !errors.Is(err, ErrCursorSecretNotConfigured) {
+		t.Errorf("EncodePageCursor() error = %v, want ErrCursorSecretNotConfigured", err)
+	}
+}
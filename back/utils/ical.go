@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar（RFC 5545）形式のデータを組み立てる
+// 行は CRLF で区切り、75 オクテットを超える行は UTF-8 の文字の途中で切らないように折り返す
+
+// icalMaxLineOctets 折り返し前の1行の最大オクテット数
+const icalMaxLineOctets = 75
+
+// icalDateTimeFormat UTC の日時の形式（例: 20240102T030405Z）
+const icalDateTimeFormat = "20060102T150405Z"
+
+// ICalWriter iCalendar のプロパティを順に書き出す
+type ICalWriter struct {
+	builder strings.Builder
+}
+
+// NewICalWriter 新しいICalWriterを作成する
+func NewICalWriter() *ICalWriter {
+	return &ICalWriter{}
+}
+
+// Begin コンポーネントを開始する（例: VCALENDAR, VEVENT）
+func (w *ICalWriter) Begin(component string) {
+	w.writeLine("BEGIN:" + component)
+}
+
+// End コンポーネントを終了する
+func (w *ICalWriter) End(component string) {
+	w.writeLine("END:" + component)
+}
+
+// Property 値をそのまま書き出す（エスケープ不要な値に使う）
+func (w *ICalWriter) Property(name, value string) {
+	w.writeLine(name + ":" + value)
+}
+
+// Text TEXT 型の値をエスケープして書き出す（空の場合は書き出さない）
+func (w *ICalWriter) Text(name, value string) {
+	if value == "" {
+		return
+	}
+	w.writeLine(name + ":" + EscapeICalText(value))
+}
+
+// DateTime 日時を UTC に変換して書き出す
+func (w *ICalWriter) DateTime(name string, t time.Time) {
+	w.writeLine(name + ":" + t.UTC().Format(icalDateTimeFormat))
+}
+
+// String 書き出した内容を返す
+func (w *ICalWriter) String() string {
+	return w.builder.String()
+}
+
+// writeLine 1行を必要に応じて折り返して書き出す
+// 折り返した行は先頭に空白1文字を付ける（その空白も行の長さに含む）
+func (w *ICalWriter) writeLine(line string) {
+	limit := icalMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.builder.WriteString(line[:cut])
+		w.builder.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalMaxLineOctets - 1
+	}
+	w.builder.WriteString(line)
+	w.builder.WriteString("\r\n")
+}
+
+// icalTextReplacer TEXT 型の値で特別な意味を持つ文字のエスケープ
+var icalTextReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// EscapeICalText TEXT 型の値をエスケープする
+func EscapeICalText(value string) string {
+	return icalTextReplacer.Replace(value)
+}
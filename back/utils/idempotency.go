@@ -0,0 +1,324 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"mybeerlog/interfaces/dto"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+	beegoCtx "github.com/astaxie/beego/context"
+	"github.com/astaxie/beego/orm"
+	"github.com/sirupsen/logrus"
+)
+
+// IdempotencyKeyHeader 冪等キーのリクエストヘッダー名
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyReplayedHeader 保存済みレスポンスを再送したことを示すレスポンスヘッダー名
+const IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+const (
+	// idempotencyDataKey 処理中のリクエスト情報を beego コンテキストに保持するキー
+	idempotencyDataKey = "idempotency_record"
+	// idempotencyMaxKeyLength 冪等キーの最大長
+	idempotencyMaxKeyLength = 255
+	// idempotencyProcessingTimeout 処理中のまま残ったレコード（プロセス停止など）を破棄するまでの時間
+	idempotencyProcessingTimeout = 30 * time.Second
+	// idempotencyDefaultTTL 保存したレスポンスを再送する期間のデフォルト値
+	idempotencyDefaultTTL = 24 * time.Hour
+	// idempotencyPurgeBatchSize 期限切れのレコードを1回の DELETE で削除する最大件数（ロックを長時間保持しないため）
+	idempotencyPurgeBatchSize = 1000
+)
+
+// idempotentRoutes 冪等キーに対応するエンドポイント（メソッド + パス）
+var idempotentRoutes = map[string]bool{
+	"POST /checkin":       true,
+	"POST /checkin/qr":    true,
+	"POST /users/profile": true,
+	"POST /breweries":     true,
+}
+
+// IdempotencyRecord 冪等キーごとに保存されたリクエストとレスポンス
+type IdempotencyRecord struct {
+	UserSub         string
+	Key             string
+	RequestHash     string
+	Completed       bool
+	ResponseStatus  int
+	ResponseBody    []byte
+	ResponseContent string
+}
+
+// IdempotencyStore 冪等キーの保存先インターフェース
+type IdempotencyStore interface {
+	// Reserve 冪等キーを処理中として登録する。既に登録済みの場合は既存のレコードと false を返す
+	Reserve(userSub, key, requestHash string, ttl time.Duration) (*IdempotencyRecord, bool, error)
+	// Complete レスポンスを保存し、以降のリトライで再送できるようにする
+	Complete(record *IdempotencyRecord) error
+	// Release 処理中の登録を取り消し、同じキーで再実行できるようにする
+	Release(userSub, key string) error
+}
+
+// dbIdempotencyStore idempotency_key テーブルを使用する IdempotencyStore の実装
+type dbIdempotencyStore struct{}
+
+// Reserve 冪等キーを処理中として登録する
+func (s *dbIdempotencyStore) Reserve(userSub, key, requestHash string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	o := orm.NewOrm()
+	now := time.Now()
+
+	// 期限切れ・処理中のまま放置されたレコードを削除する
+	_, err := o.Raw(`DELETE FROM idempotency_key
+			WHERE user_sub = ? AND idempotency_key = ?
+			AND (expires_at < ? OR (status = 'processing' AND created_at < ?))`,
+		userSub, key, now, now.Add(-idempotencyProcessingTimeout)).Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var insertedID int
+	err = o.Raw(`INSERT INTO idempotency_key (user_sub, idempotency_key, request_hash, status, created_at, expires_at)
+			VALUES (?, ?, ?, 'processing', ?, ?)
+			ON CONFLICT (user_sub, idempotency_key) DO NOTHING
+			RETURNING id`,
+		userSub, key, requestHash, now, now.Add(ttl)).QueryRow(&insertedID)
+	if err == nil {
+		return &IdempotencyRecord{UserSub: userSub, Key: key, RequestHash: requestHash}, true, nil
+	}
+	if err != orm.ErrNoRows {
+		return nil, false, err
+	}
+
+	var existing struct {
+		RequestHash         string
+		Status              string
+		ResponseStatus      int
+		ResponseBody        string
+		ResponseContentType string
+	}
+	err = o.Raw(`SELECT request_hash, status, COALESCE(response_status, 0) AS response_status,
+				COALESCE(response_body, '') AS response_body, COALESCE(response_content_type, '') AS response_content_type
+			FROM idempotency_key
+			WHERE user_sub = ? AND idempotency_key = ?`,
+		userSub, key).QueryRow(&existing)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &IdempotencyRecord{
+		UserSub:         userSub,
+		Key:             key,
+		RequestHash:     existing.RequestHash,
+		Completed:       existing.Status == "completed",
+		ResponseStatus:  existing.ResponseStatus,
+		ResponseBody:    []byte(existing.ResponseBody),
+		ResponseContent: existing.ResponseContentType,
+	}, false, nil
+}
+
+// Complete レスポンスを保存する
+func (s *dbIdempotencyStore) Complete(record *IdempotencyRecord) error {
+	_, err := orm.NewOrm().Raw(`UPDATE idempotency_key
+			SET status = 'completed', response_status = ?, response_body = ?, response_content_type = ?
+			WHERE user_sub = ? AND idempotency_key = ? AND status = 'processing'`,
+		record.ResponseStatus, string(record.ResponseBody), record.ResponseContent,
+		record.UserSub, record.Key).Exec()
+	return err
+}
+
+// Release 処理中の登録を取り消す
+func (s *dbIdempotencyStore) Release(userSub, key string) error {
+	_, err := orm.NewOrm().Raw(`DELETE FROM idempotency_key
+			WHERE user_sub = ? AND idempotency_key = ? AND status = 'processing'`,
+		userSub, key).Exec()
+	return err
+}
+
+// PurgeExpiredIdempotencyKeys 期限切れ・処理中のまま放置された冪等キーを全ユーザー分削除する（バッチ用）
+// Reserve は同じユーザー・キーのレコードしか削除しないため、定期的に実行してテーブルの肥大化を防ぐ
+// 削除した件数を返す（途中で失敗した場合は、それまでに削除した件数とエラーを返す）
+func PurgeExpiredIdempotencyKeys(now time.Time) (int64, error) {
+	o := orm.NewOrm()
+	var purged int64
+	for {
+		result, err := o.Raw(`DELETE FROM idempotency_key WHERE id IN (
+					SELECT id FROM idempotency_key
+					WHERE expires_at < ? OR (status = 'processing' AND created_at < ?)
+					LIMIT ?
+				)`,
+			now, now.Add(-idempotencyProcessingTimeout), idempotencyPurgeBatchSize).Exec()
+		if err != nil {
+			return purged, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return purged, err
+		}
+		purged += affected
+		if affected < idempotencyPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+var (
+	idempotencyStore     IdempotencyStore = &dbIdempotencyStore{}
+	idempotencyStoreLock sync.RWMutex
+)
+
+// SetIdempotencyStore 冪等キーの保存先を差し替える
+func SetIdempotencyStore(store IdempotencyStore) {
+	idempotencyStoreLock.Lock()
+	defer idempotencyStoreLock.Unlock()
+	idempotencyStore = store
+}
+
+// getIdempotencyStore 冪等キーの保存先を取得する
+func getIdempotencyStore() IdempotencyStore {
+	idempotencyStoreLock.RLock()
+	defer idempotencyStoreLock.RUnlock()
+	return idempotencyStore
+}
+
+// idempotencyRecorder レスポンスを保存するために本文を記録するResponseWriter
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (rw *idempotencyRecorder) Write(b []byte) (int, error) {
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+// idempotencyState 処理中のリクエストの冪等キー情報
+type idempotencyState struct {
+	record   *IdempotencyRecord
+	recorder *idempotencyRecorder
+}
+
+// IdempotencyMiddleware Idempotency-Key ヘッダー付きのPOSTリクエストを冪等に処理するミドルウェア
+// 初回のレスポンスを ユーザー + キー 単位で保存し、TTL 内のリトライには保存済みのレスポンスを再送する。
+// 同じキーで異なるリクエスト本文が送信された場合は 422 を返す。認証ミドルウェアの後に登録すること
+func IdempotencyMiddleware(ctx *beegoCtx.Context) {
+	key := ctx.Request.Header.Get(IdempotencyKeyHeader)
+	if key == "" || !idempotentRoutes[ctx.Request.Method+" "+ctx.Request.URL.Path] {
+		return
+	}
+
+	// 未認証のリクエストはコントローラーで 401 となるため対象外とする
+	claims := GetAuthClaimsFromContext(ctx.Request.Context())
+	if claims == nil {
+		return
+	}
+
+	reqCtx := ctx.Request.Context()
+	if len(key) > idempotencyMaxKeyLength {
+		writeIdempotencyError(ctx, http.StatusBadRequest, dto.ErrorCodeInvalidParameter, "Idempotency-Key must be 255 characters or less")
+		return
+	}
+
+	requestHash := hashIdempotentRequest(ctx)
+	record, reserved, err := getIdempotencyStore().Reserve(claims.Sub, key, requestHash, idempotencyTTL())
+	if err != nil {
+		// 保存先の障害時は冪等性を保証せずに処理を継続する
+		LogError(reqCtx, err, "Failed to reserve idempotency key", logrus.Fields{"idempotency_key": key})
+		return
+	}
+
+	if !reserved {
+		switch {
+		case record.RequestHash != requestHash:
+			writeIdempotencyError(ctx, http.StatusUnprocessableEntity, dto.ErrorCodeIdempotencyKeyMismatch, "Idempotency-Key was already used with a different request")
+		case !record.Completed:
+			writeIdempotencyError(ctx, http.StatusConflict, dto.ErrorCodeIdempotencyInProgress, "A request with this Idempotency-Key is still being processed")
+		default:
+			replayIdempotentResponse(ctx, record)
+		}
+		return
+	}
+
+	recorder := &idempotencyRecorder{ResponseWriter: ctx.ResponseWriter.ResponseWriter}
+	ctx.ResponseWriter.ResponseWriter = recorder
+	ctx.Input.SetData(idempotencyDataKey, &idempotencyState{record: record, recorder: recorder})
+}
+
+// IdempotencyFinishMiddleware コントローラーの処理後にレスポンスを保存するミドルウェア
+// レスポンス出力後も実行されるよう、FinishRouter に returnOnOutput = false で登録すること
+func IdempotencyFinishMiddleware(ctx *beegoCtx.Context) {
+	state, ok := ctx.Input.GetData(idempotencyDataKey).(*idempotencyState)
+	if !ok || state == nil {
+		return
+	}
+
+	reqCtx := ctx.Request.Context()
+	status := ctx.ResponseWriter.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	// サーバーエラーは一時的な障害の可能性があるため保存せず、同じキーでの再実行を許可する
+	store := getIdempotencyStore()
+	if status >= http.StatusInternalServerError {
+		if err := store.Release(state.record.UserSub, state.record.Key); err != nil {
+			LogError(reqCtx, err, "Failed to release idempotency key", logrus.Fields{"idempotency_key": state.record.Key})
+		}
+		return
+	}
+
+	state.record.Completed = true
+	state.record.ResponseStatus = status
+	state.record.ResponseBody = state.recorder.body.Bytes()
+	state.record.ResponseContent = ctx.ResponseWriter.Header().Get("Content-Type")
+	if err := store.Complete(state.record); err != nil {
+		LogError(reqCtx, err, "Failed to store idempotent response", logrus.Fields{"idempotency_key": state.record.Key})
+	}
+}
+
+// hashIdempotentRequest メソッド・パス・本文からリクエストのハッシュを計算する
+func hashIdempotentRequest(ctx *beegoCtx.Context) string {
+	hash := sha256.New()
+	hash.Write([]byte(ctx.Request.Method + "\n" + ctx.Request.URL.Path + "\n"))
+	hash.Write(ctx.Input.RequestBody)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyTTL 保存したレスポンスを再送する期間を設定から取得する
+func idempotencyTTL() time.Duration {
+	hours := beego.AppConfig.DefaultInt("idempotency.ttl_hours", 0)
+	if hours <= 0 {
+		return idempotencyDefaultTTL
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// replayIdempotentResponse 保存済みのレスポンスを再送する
+func replayIdempotentResponse(ctx *beegoCtx.Context, record *IdempotencyRecord) {
+	LogInfo(ctx.Request.Context(), "Replaying idempotent response", logrus.Fields{
+		"idempotency_key": record.Key,
+		"status":          record.ResponseStatus,
+	})
+
+	if record.ResponseContent != "" {
+		ctx.ResponseWriter.Header().Set("Content-Type", record.ResponseContent)
+	}
+	ctx.ResponseWriter.Header().Set(IdempotencyReplayedHeader, "true")
+	ctx.ResponseWriter.WriteHeader(record.ResponseStatus)
+	ctx.ResponseWriter.Write(record.ResponseBody)
+}
+
+// writeIdempotencyError 冪等キー関連のエラーレスポンスを送信する
+func writeIdempotencyError(ctx *beegoCtx.Context, status int, code, message string) {
+	requestID := GetRequestIDFromContext(ctx.Request.Context())
+	LogWarn(ctx.Request.Context(), message, logrus.Fields{"code": code})
+
+	body, _ := json.Marshal(dto.NewErrorResponse(code, message, "", requestID))
+	ctx.ResponseWriter.Header().Set("Content-Type", "application/json; charset=utf-8")
+	ctx.ResponseWriter.WriteHeader(status)
+	ctx.ResponseWriter.Write(body)
+}
@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Markdown のサブセットを HTML に変換する
+// 入力は全て HTML エスケープしてから書式のタグだけを組み立てるため、利用者が書いた HTML タグや属性は出力されない
+// 対応する書式: 見出し（#〜###）、段落、改行、箇条書き（- / * / +）、番号付きリスト、引用（>）、
+// 水平線、コードブロック（```）、インラインコード、太字、斜体、取り消し線、リンク（http / https / mailto のみ）
+
+var (
+	markdownHeadingPattern     = regexp.MustCompile(`^(#{1,3})\s+(.+?)\s*#*\s*$`)
+	markdownBulletPattern      = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	markdownOrderedPattern     = regexp.MustCompile(`^\s*\d{1,9}[.)]\s+(.*)$`)
+	markdownRulePattern        = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	markdownCodeSpanPattern    = regexp.MustCompile("`([^`]+)`")
+	markdownLinkPattern        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownBoldPattern        = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownItalicPattern      = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	markdownStrikePattern      = regexp.MustCompile(`~~([^~]+)~~`)
+	markdownPlaceholderPattern = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// markdownAllowedSchemes リンクに使用できる URL スキーム
+var markdownAllowedSchemes = []string{"http://", "https://", "mailto:"}
+
+// RenderMarkdown Markdown をサニタイズ済みの HTML に変換する
+func RenderMarkdown(source string) string {
+	// プレースホルダーと衝突しないよう制御文字の NUL を除き、改行コードを揃える
+	source = strings.ReplaceAll(source, "\x00", "")
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	lines := strings.Split(source, "\n")
+
+	var out strings.Builder
+	var paragraph, quote []string
+	listTag := ""
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + renderMarkdownLines(paragraph) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	flushQuote := func() {
+		if len(quote) > 0 {
+			out.WriteString("<blockquote><p>" + renderMarkdownLines(quote) + "</p></blockquote>\n")
+			quote = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			out.WriteString("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+	flushAll := func() {
+		flushParagraph()
+		flushQuote()
+		closeList()
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		// コードブロックは閉じる ``` まで（なければ末尾まで）をそのまま出力する
+		if strings.HasPrefix(trimmed, "```") {
+			flushAll()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flushAll()
+		case markdownRulePattern.MatchString(line):
+			flushAll()
+			out.WriteString("<hr>\n")
+		case markdownHeadingPattern.MatchString(trimmed):
+			flushAll()
+			match := markdownHeadingPattern.FindStringSubmatch(trimmed)
+			level := len(match[1])
+			out.WriteString(fmt.Sprintf("<h%d>%s</h%d>\n", level, renderMarkdownInline(match[2]), level))
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			closeList()
+			quote = append(quote, strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))
+		case markdownBulletPattern.MatchString(line):
+			flushParagraph()
+			flushQuote()
+			writeMarkdownListItem(&out, &listTag, "ul", markdownBulletPattern.FindStringSubmatch(line)[1])
+		case markdownOrderedPattern.MatchString(line):
+			flushParagraph()
+			flushQuote()
+			writeMarkdownListItem(&out, &listTag, "ol", markdownOrderedPattern.FindStringSubmatch(line)[1])
+		default:
+			flushQuote()
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushAll()
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// writeMarkdownListItem リストの項目を出力する（種類の異なるリストが続く場合は開き直す）
+func writeMarkdownListItem(out *strings.Builder, listTag *string, tag, item string) {
+	if *listTag != tag {
+		if *listTag != "" {
+			out.WriteString("</" + *listTag + ">\n")
+		}
+		out.WriteString("<" + tag + ">\n")
+		*listTag = tag
+	}
+	out.WriteString("<li>" + renderMarkdownInline(strings.TrimSpace(item)) + "</li>\n")
+}
+
+// renderMarkdownLines 段落内の複数行を改行タグでつないで変換する
+func renderMarkdownLines(lines []string) string {
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		rendered[i] = renderMarkdownInline(line)
+	}
+	return strings.Join(rendered, "<br>\n")
+}
+
+// renderMarkdownInline 行内の書式を変換する
+// インラインコードとリンクは後続の書式の変換で壊れないよう、プレースホルダーに置き換えてから最後に戻す
+func renderMarkdownInline(text string) string {
+	var fragments []string
+	protect := func(fragment string) string {
+		fragments = append(fragments, fragment)
+		return fmt.Sprintf("\x00%d\x00", len(fragments)-1)
+	}
+
+	text = markdownCodeSpanPattern.ReplaceAllStringFunc(text, func(match string) string {
+		code := markdownCodeSpanPattern.FindStringSubmatch(match)[1]
+		return protect("<code>" + html.EscapeString(code) + "</code>")
+	})
+
+	text = html.EscapeString(text)
+
+	text = markdownLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := markdownLinkPattern.FindStringSubmatch(match)
+		label, escapedURL := parts[1], parts[2]
+		if !isAllowedMarkdownURL(html.UnescapeString(escapedURL)) {
+			return match
+		}
+		return protect(`<a href="`+escapedURL+`" rel="nofollow noopener noreferrer">`) + label + protect("</a>")
+	})
+
+	text = markdownBoldPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = markdownItalicPattern.ReplaceAllString(text, "<em>$1</em>")
+	text = markdownStrikePattern.ReplaceAllString(text, "<del>$1</del>")
+
+	return markdownPlaceholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		index, _ := strconv.Atoi(markdownPlaceholderPattern.FindStringSubmatch(match)[1])
+		return fragments[index]
+	})
+}
+
+// isAllowedMarkdownURL リンク先の URL スキームが許可されているかどうかを判定する（javascript: などを除く）
+func isAllowedMarkdownURL(url string) bool {
+	lower := strings.ToLower(strings.TrimSpace(url))
+	for _, scheme := range markdownAllowedSchemes {
+		if strings.HasPrefix(lower, scheme) && len(lower) > len(scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package utils
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "heading and paragraph",
+			source: "# 見出し\n本文",
+			want:   "<h1>見出し</h1>\n<p>本文</p>",
+		},
+		{
+			name:   "inline formatting",
+			source: "**太字** と *斜体* と ~~取消~~",
+			want:   "<p><strong>太字</strong> と <em>斜体</em> と <del>取消</del></p>",
+		},
+		{
+			name:   "line breaks and paragraphs",
+			source: "1行目\r\n2行目\n\n次の段落",
+			want:   "<p>1行目<br>\n2行目</p>\n<p>次の段落</p>",
+		},
+		{
+			name:   "bullet list followed by ordered list",
+			source: "- a\n- b\n1. c",
+			want:   "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n<ol>\n<li>c</li>\n</ol>",
+		},
+		{
+			name:   "blockquote",
+			source: "> 引用\n> 続き",
+			want:   "<blockquote><p>引用<br>\n続き</p></blockquote>",
+		},
+		{
+			name:   "horizontal rule",
+			source: "---",
+			want:   "<hr>",
+		},
+		{
+			name:   "http link",
+			source: "[公式](https://example.com/?a=1&b=2)",
+			want:   `<p><a href="https://example.com/?a=1&amp;b=2" rel="nofollow noopener noreferrer">公式</a></p>`,
+		},
+		{
+			name:   "mailto link",
+			source: "[mail](mailto:info@example.com)",
+			want:   `<p><a href="mailto:info@example.com" rel="nofollow noopener noreferrer">mail</a></p>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.source); got != tt.want {
+				t.Errorf("RenderMarkdown(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownSanitizesUnsafeInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "javascript link",
+			source: "[x](javascript:alert(1))",
+			want:   "<p>[x](javascript:alert(1))</p>",
+		},
+		{
+			name:   "mixed case javascript link",
+			source: "[x](JavaScript:alert(1))",
+			want:   "<p>[x](JavaScript:alert(1))</p>",
+		},
+		{
+			name:   "entity encoded javascript link",
+			source: "[x](&#106;avascript:alert(1))",
+			want:   "<p>[x](&amp;#106;avascript:alert(1))</p>",
+		},
+		{
+			name:   "data url link",
+			source: "[x](data:text/html,<script>)",
+			want:   "<p>[x](data:text/html,&lt;script&gt;)</p>",
+		},
+		{
+			name:   "scheme without target",
+			source: "[x](https:)",
+			want:   "<p>[x](https:)</p>",
+		},
+		{
+			name:   "quote in link url cannot add attributes",
+			source: `[x](https://example.com/"onmouseover="alert(1))`,
+			want:   `<p><a href="https://example.com/&#34;onmouseover=&#34;alert(1" rel="nofollow noopener noreferrer">x</a>)</p>`,
+		},
+		{
+			name:   "html in link label",
+			source: "[<b>x</b>](https://example.com)",
+			want:   `<p><a href="https://example.com" rel="nofollow noopener noreferrer">&lt;b&gt;x&lt;/b&gt;</a></p>`,
+		},
+		{
+			name:   "script tag",
+			source: "<script>alert(1)</script>",
+			want:   "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>",
+		},
+		{
+			name:   "event handler attribute",
+			source: "<img src=x onerror=alert(1)>",
+			want:   "<p>&lt;img src=x onerror=alert(1)&gt;</p>",
+		},
+		{
+			name:   "html in heading",
+			source: "## <iframe src=x>",
+			want:   "<h2>&lt;iframe src=x&gt;</h2>",
+		},
+		{
+			name:   "html in code block",
+			source: "```\n<b>x</b>\n```",
+			want:   "<pre><code>&lt;b&gt;x&lt;/b&gt;</code></pre>",
+		},
+		{
+			name:   "html in inline code",
+			source: "`<i>`",
+			want:   "<p><code>&lt;i&gt;</code></p>",
+		},
+		{
+			name:   "forged placeholder",
+			source: "`a` \x000\x00",
+			want:   "<p><code>a</code> 0</p>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.source); got != tt.want {
+				t.Errorf("RenderMarkdown(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
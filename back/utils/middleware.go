@@ -48,17 +48,28 @@ func RequestLoggingMiddleware(ctx *beegoCtx.Context) {
 	ctx.Request = ctx.Request.WithContext(reqCtx)
 
 	// リクエスト開始ログ
-	LogRequest(reqCtx, ctx.Request.Method, ctx.Request.URL.Path, ctx.Request.UserAgent())
+	LogRequest(reqCtx, ctx.Request.Method, RedactRequestPath(ctx.Request.URL.Path), ctx.Request.UserAgent())
 
 	// リクエスト処理後のログ出力用に後処理を設定
-	ctx.ResponseWriter.ResponseWriter.(*beegoCtx.Response).ResponseWriter = &responseWriter{
-		ResponseWriter: ctx.ResponseWriter.ResponseWriter.(*beegoCtx.Response).ResponseWriter,
+	ctx.ResponseWriter.ResponseWriter = &responseWriter{
+		ResponseWriter: ctx.ResponseWriter.ResponseWriter,
 		statusCode:     200, // デフォルト
 		requestCtx:     reqCtx,
 		startTime:      startTime,
 	}
 }
 
+// calendarFeedPathPrefix カレンダー購読URLのパスの接頭辞（以降のトークンは認証情報として扱う）
+const calendarFeedPathPrefix = "/users/calendar/"
+
+// RedactRequestPath ログに出力するパスから認証情報を含む部分を伏せる
+func RedactRequestPath(path string) string {
+	if strings.HasPrefix(path, calendarFeedPathPrefix) {
+		return calendarFeedPathPrefix + "[REDACTED]"
+	}
+	return path
+}
+
 // responseWriter レスポンス情報を記録するためのカスタムResponseWriter
 type responseWriter struct {
 	http.ResponseWriter
@@ -84,6 +95,51 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return rw.ResponseWriter.Write(b)
 }
 
+// AuthenticationMiddleware Authorizationヘッダーのトークンを検証し、検証済みクレームをコンテキストに設定するミドルウェア
+// トークンが無い・無効な場合もリクエストは継続し、認証が必要かどうかは各コントローラーが判断する
+func AuthenticationMiddleware(ctx *beegoCtx.Context) {
+	token := extractBearerToken(ctx.Request.Header.Get("Authorization"))
+	if token == "" {
+		return
+	}
+
+	reqCtx := ctx.Request.Context()
+	claims, err := authenticateToken(reqCtx, token)
+	if err != nil {
+		LogWarn(reqCtx, "Token verification failed", logrus.Fields{
+			"error": err.Error(),
+		})
+		ctx.Request = ctx.Request.WithContext(SetAuthErrorToContext(reqCtx, err))
+		return
+	}
+
+	ctx.Request = ctx.Request.WithContext(SetAuthClaimsToContext(reqCtx, claims))
+}
+
+// authenticateToken トークンを検証してクレームを返す（開発環境ではテストトークンも受け入れる）
+func authenticateToken(ctx context.Context, token string) (*AuthClaims, error) {
+	if beego.BConfig.RunMode == "dev" {
+		if testToken, err := GetTestAuthTokenManager().LookupToken(token); err == nil {
+			return &AuthClaims{
+				Sub:      testToken.CognitoSub,
+				Groups:   testToken.Groups,
+				TokenUse: "test",
+			}, nil
+		}
+	}
+
+	return GetCognitoJWTVerifier().Verify(ctx, token)
+}
+
+// extractBearerToken Authorizationヘッダーからトークン部分を取り出す
+func extractBearerToken(authHeader string) string {
+	parts := strings.SplitN(strings.TrimSpace(authHeader), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
 // PanicRecoveryMiddleware パニック復旧ミドルウェア
 func PanicRecoveryMiddleware(ctx *beegoCtx.Context) {
 	defer func() {
@@ -104,7 +160,7 @@ func PanicRecoveryMiddleware(ctx *beegoCtx.Context) {
 				"panic":       r,
 				"stack_trace": string(stackTrace[:stackSize]),
 				"method":      ctx.Request.Method,
-				"path":        ctx.Request.URL.Path,
+				"path":        RedactRequestPath(ctx.Request.URL.Path),
 				"type":        "panic_recovery",
 			}).Error("Panic recovered")
 
@@ -143,8 +199,8 @@ func CORSMiddleware(ctx *beegoCtx.Context) {
 		ctx.Output.Header("Access-Control-Allow-Origin", origin)
 	}
 
-	ctx.Output.Header("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
-	ctx.Output.Header("Access-Control-Allow-Headers", "Content-Type,Authorization,X-Request-ID")
+	ctx.Output.Header("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
+	ctx.Output.Header("Access-Control-Allow-Headers", "Content-Type,Authorization,X-Request-ID,X-Client-Version,Idempotency-Key")
 	ctx.Output.Header("Access-Control-Allow-Credentials", "true")
 	ctx.Output.Header("Access-Control-Max-Age", "3600")
 
@@ -14,6 +14,7 @@ import (
 type TestAuthToken struct {
 	Token     string `json:"token"`
 	CognitoSub string `json:"cognito_sub"`
+	Groups    []string `json:"groups,omitempty"`
 	ExpiresAt int64  `json:"expires_at"`
 }
 
@@ -40,8 +41,8 @@ func NewTestAuthTokenManager() *TestAuthTokenManager {
 	return manager
 }
 
-// GenerateToken ローカル開発用のテストトークンを作成する
-func (m *TestAuthTokenManager) GenerateToken(cognitoSub string) *TestAuthToken {
+// GenerateToken ローカル開発用のテストトークンを作成する（グループは任意）
+func (m *TestAuthTokenManager) GenerateToken(cognitoSub string, groups ...string) *TestAuthToken {
 	// ランダムトークン生成
 	tokenBytes := make([]byte, 32)
 	rand.Read(tokenBytes)
@@ -53,6 +54,7 @@ func (m *TestAuthTokenManager) GenerateToken(cognitoSub string) *TestAuthToken {
 	testToken := &TestAuthToken{
 		Token:     token,
 		CognitoSub: cognitoSub,
+		Groups:    groups,
 		ExpiresAt: expiresAt,
 	}
 
@@ -66,9 +68,18 @@ func (m *TestAuthTokenManager) GenerateToken(cognitoSub string) *TestAuthToken {
 
 // ValidateToken テストトークンを検証する（ローカル開発のみ）
 func (m *TestAuthTokenManager) ValidateToken(token string) (string, error) {
+	testToken, err := m.LookupToken(token)
+	if err != nil {
+		return "", err
+	}
+	return testToken.CognitoSub, nil
+}
+
+// LookupToken テストトークンを検証し、トークン情報を返す（ローカル開発のみ）
+func (m *TestAuthTokenManager) LookupToken(token string) (*TestAuthToken, error) {
 	// 本番環境では使用しない
 	if beego.BConfig.RunMode != "dev" {
-		return "", fmt.Errorf("test tokens are only available in development mode")
+		return nil, fmt.Errorf("test tokens are only available in development mode")
 	}
 
 	m.mutex.RLock()
@@ -76,7 +87,7 @@ func (m *TestAuthTokenManager) ValidateToken(token string) (string, error) {
 	m.mutex.RUnlock()
 
 	if !exists {
-		return "", fmt.Errorf("invalid test token")
+		return nil, fmt.Errorf("invalid test token")
 	}
 
 	// 有効期限チェック
@@ -85,10 +96,10 @@ func (m *TestAuthTokenManager) ValidateToken(token string) (string, error) {
 		m.mutex.Lock()
 		delete(m.tokens, token)
 		m.mutex.Unlock()
-		return "", fmt.Errorf("test token has expired")
+		return nil, fmt.Errorf("test token has expired")
 	}
 
-	return testToken.CognitoSub, nil
+	return testToken, nil
 }
 
 // RevokeToken 特定のテストトークンを無効化する